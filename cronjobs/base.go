@@ -13,7 +13,8 @@ import (
 
 var (
 	cronJobs = map[string]CronJobObject{
-		"send-notifications": {CronJob: SendNotifications, Interval: time.Second * 5},
+		"send-notifications":  {CronJob: SendNotifications, Interval: time.Second * 5},
+		"purge-deleted-users": {CronJob: PurgeDeletedUsers, Interval: time.Hour},
 	}
 	stopSignals = map[string]chan bool{}
 )
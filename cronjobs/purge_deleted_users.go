@@ -0,0 +1,18 @@
+package cronjobs
+
+import (
+	"github.com/hngprojects/hng_boilerplate_golang_web/external/request"
+	"github.com/hngprojects/hng_boilerplate_golang_web/pkg/repository/storage"
+	"github.com/hngprojects/hng_boilerplate_golang_web/services/user"
+)
+
+func PurgeDeletedUsers(extReq request.ExternalRequest, db storage.Database) {
+	purged, err := user.PurgeDueAccounts(db.Postgresql)
+	if err != nil {
+		extReq.Logger.Error("error purging deleted users: ", err.Error())
+	}
+
+	if purged > 0 {
+		extReq.Logger.Info("purged deleted users: ", purged)
+	}
+}
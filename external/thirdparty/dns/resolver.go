@@ -0,0 +1,37 @@
+package dns
+
+import (
+	"context"
+	"errors"
+	"net"
+	"strings"
+)
+
+// TXTResolver looks up the TXT records published for a name.
+type TXTResolver interface {
+	LookupTXT(ctx context.Context, name string) ([]string, error)
+}
+
+// Resolver is used to verify domain ownership. It is the system resolver
+// unless replaced, which tests do to avoid real lookups.
+var Resolver TXTResolver = net.DefaultResolver
+
+// HasTXTRecord reports whether name publishes a TXT record equal to value.
+// A name without TXT records is not an error.
+func HasTXTRecord(ctx context.Context, name, value string) (bool, error) {
+	records, err := Resolver.LookupTXT(ctx, name)
+	if err != nil {
+		var dnsErr *net.DNSError
+		if errors.As(err, &dnsErr) && dnsErr.IsNotFound {
+			return false, nil
+		}
+		return false, err
+	}
+
+	for _, record := range records {
+		if strings.TrimSpace(record) == value {
+			return true, nil
+		}
+	}
+	return false, nil
+}
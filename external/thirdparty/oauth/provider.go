@@ -0,0 +1,346 @@
+package oauth
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"golang.org/x/oauth2"
+
+	"github.com/hngprojects/hng_boilerplate_golang_web/internal/config"
+)
+
+// Identity is the account a user signed in with at a provider.
+type Identity struct {
+	Subject       string
+	Email         string
+	EmailVerified bool
+	Name          string
+	AvatarURL     string
+}
+
+// Provider runs the authorization code flow with PKCE against one configured
+// provider. Endpoints of OpenID Connect providers are discovered on first use,
+// and the ID token they return is verified before the identity is trusted.
+type Provider struct {
+	Name        string
+	DisplayName string
+	Type        string
+
+	config      config.OAuthProvider
+	redirectURL string
+	httpClient  *http.Client
+
+	lock        sync.Mutex
+	oauthConfig *oauth2.Config
+	userInfoURL string
+	issuer      string
+	jwksURL     string
+	signingKeys map[string]interface{}
+}
+
+type discoveryDocument struct {
+	Issuer                string `json:"issuer"`
+	JwksURI               string `json:"jwks_uri"`
+	AuthorizationEndpoint string `json:"authorization_endpoint"`
+	TokenEndpoint         string `json:"token_endpoint"`
+	UserinfoEndpoint      string `json:"userinfo_endpoint"`
+}
+
+func newProvider(name string, providerConfig config.OAuthProvider, redirectURL string) (*Provider, error) {
+	provider := &Provider{
+		Name:        name,
+		DisplayName: providerConfig.DisplayName,
+		Type:        providerConfig.Type,
+		config:      providerConfig,
+		redirectURL: redirectURL,
+		httpClient:  &http.Client{Timeout: 10 * time.Second},
+	}
+
+	if provider.Type == "" {
+		provider.Type = name
+	}
+	if provider.DisplayName == "" {
+		provider.DisplayName = name
+	}
+
+	switch provider.Type {
+	case config.OAuthProviderGitHub, config.OAuthProviderGitLab, config.OAuthProviderMicrosoft:
+	case config.OAuthProviderOIDC:
+		if providerConfig.DiscoveryURL == "" {
+			return nil, fmt.Errorf("oauth provider %v: discovery_url is required", name)
+		}
+	default:
+		return nil, fmt.Errorf("oauth provider %v: unsupported type %v", name, provider.Type)
+	}
+
+	if providerConfig.ClientID == "" {
+		return nil, fmt.Errorf("oauth provider %v: client_id is required", name)
+	}
+
+	return provider, nil
+}
+
+// AuthCodeURL returns the provider page the user signs in on. The verifier is
+// sent as an S256 challenge and, like the nonce, must be passed to Exchange
+// with the code.
+func (p *Provider) AuthCodeURL(ctx context.Context, state, verifier, nonce string) (string, error) {
+	oauthConfig, err := p.getOAuthConfig(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	options := []oauth2.AuthCodeOption{oauth2.S256ChallengeOption(verifier)}
+	if p.isOpenIDConnect() {
+		options = append(options, oauth2.SetAuthURLParam("nonce", nonce))
+	}
+
+	return oauthConfig.AuthCodeURL(state, options...), nil
+}
+
+// Exchange trades the authorization code for a token and fetches the identity
+// it belongs to.
+func (p *Provider) Exchange(ctx context.Context, code, verifier, nonce string) (Identity, error) {
+	oauthConfig, err := p.getOAuthConfig(ctx)
+	if err != nil {
+		return Identity{}, err
+	}
+
+	ctx = context.WithValue(ctx, oauth2.HTTPClient, p.httpClient)
+
+	token, err := oauthConfig.Exchange(ctx, code, oauth2.VerifierOption(verifier))
+	if err != nil {
+		return Identity{}, fmt.Errorf("code exchange failed: %v", err)
+	}
+
+	client := oauthConfig.Client(ctx, token)
+
+	var identity Identity
+	if p.isOpenIDConnect() {
+		identity, err = p.openIDIdentity(ctx, token, client, nonce)
+	} else {
+		identity, err = p.githubIdentity(client)
+	}
+	if err != nil {
+		return Identity{}, err
+	}
+
+	if identity.Subject == "" {
+		return Identity{}, errors.New("provider returned no subject")
+	}
+	identity.Email = strings.ToLower(identity.Email)
+
+	return identity, nil
+}
+
+func (p *Provider) getOAuthConfig(ctx context.Context) (*oauth2.Config, error) {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	if p.oauthConfig != nil {
+		return p.oauthConfig, nil
+	}
+
+	oauthConfig := &oauth2.Config{
+		ClientID:     p.config.ClientID,
+		ClientSecret: p.config.ClientSecret,
+		RedirectURL:  p.redirectURL,
+		Scopes:       p.config.Scopes,
+	}
+
+	if p.Type == config.OAuthProviderGitHub {
+		baseURL := strings.TrimSuffix(p.config.BaseURL, "/")
+		if baseURL == "" {
+			baseURL = "https://github.com"
+		}
+
+		oauthConfig.Endpoint = oauth2.Endpoint{
+			AuthURL:  baseURL + "/login/oauth/authorize",
+			TokenURL: baseURL + "/login/oauth/access_token",
+		}
+		if len(oauthConfig.Scopes) == 0 {
+			oauthConfig.Scopes = []string{"read:user", "user:email"}
+		}
+
+		p.oauthConfig = oauthConfig
+		return oauthConfig, nil
+	}
+
+	var document discoveryDocument
+	if err := p.getJSON(ctx, p.discoveryURL(), nil, &document); err != nil {
+		return nil, fmt.Errorf("oauth provider %v discovery failed: %v", p.Name, err)
+	}
+
+	if document.Issuer == "" || document.JwksURI == "" || document.AuthorizationEndpoint == "" || document.TokenEndpoint == "" || document.UserinfoEndpoint == "" {
+		return nil, fmt.Errorf("oauth provider %v discovery document is incomplete", p.Name)
+	}
+
+	oauthConfig.Endpoint = oauth2.Endpoint{
+		AuthURL:  document.AuthorizationEndpoint,
+		TokenURL: document.TokenEndpoint,
+	}
+	if len(oauthConfig.Scopes) == 0 {
+		oauthConfig.Scopes = []string{"openid", "email", "profile"}
+	}
+
+	p.oauthConfig, p.userInfoURL = oauthConfig, document.UserinfoEndpoint
+	p.issuer, p.jwksURL = document.Issuer, document.JwksURI
+	return oauthConfig, nil
+}
+
+// isOpenIDConnect reports whether the provider signs users in with OpenID
+// Connect, which every supported type but GitHub does.
+func (p *Provider) isOpenIDConnect() bool {
+	return p.Type != config.OAuthProviderGitHub
+}
+
+func (p *Provider) discoveryURL() string {
+	switch p.Type {
+	case config.OAuthProviderGitLab:
+		baseURL := strings.TrimSuffix(p.config.BaseURL, "/")
+		if baseURL == "" {
+			baseURL = "https://gitlab.com"
+		}
+		return baseURL + "/.well-known/openid-configuration"
+	case config.OAuthProviderMicrosoft:
+		tenant := p.config.Tenant
+		if tenant == "" {
+			tenant = "common"
+		}
+		return fmt.Sprintf("https://login.microsoftonline.com/%v/v2.0/.well-known/openid-configuration", tenant)
+	default:
+		return p.config.DiscoveryURL
+	}
+}
+
+// openIDIdentity verifies the ID token and reads the rest of the profile from
+// the userinfo endpoint, which must describe the same subject. Claims the ID
+// token carries take precedence over the userinfo response.
+func (p *Provider) openIDIdentity(ctx context.Context, token *oauth2.Token, client *http.Client, nonce string) (Identity, error) {
+	rawIDToken, ok := token.Extra("id_token").(string)
+	if !ok || rawIDToken == "" {
+		return Identity{}, errors.New("provider returned no id token")
+	}
+
+	claims, err := p.verifyIDToken(ctx, rawIDToken, nonce)
+	if err != nil {
+		return Identity{}, err
+	}
+
+	identity, err := p.userInfoIdentity(client)
+	if err != nil {
+		return Identity{}, err
+	}
+
+	if identity.Subject != claims["sub"] {
+		return Identity{}, errors.New("userinfo subject does not match the id token")
+	}
+	if email, ok := claims["email"].(string); ok && email != "" {
+		identity.Email = email
+		identity.EmailVerified = claims["email_verified"] == true || claims["email_verified"] == "true"
+	}
+
+	return identity, nil
+}
+
+// userInfoIdentity reads the standard claims from the OpenID Connect userinfo endpoint.
+func (p *Provider) userInfoIdentity(client *http.Client) (Identity, error) {
+	var claims struct {
+		Subject       string      `json:"sub"`
+		Email         string      `json:"email"`
+		EmailVerified interface{} `json:"email_verified"`
+		Name          string      `json:"name"`
+		Picture       string      `json:"picture"`
+	}
+
+	if err := p.getJSON(context.Background(), p.userInfoURL, client, &claims); err != nil {
+		return Identity{}, fmt.Errorf("userinfo request failed: %v", err)
+	}
+
+	// some providers send email_verified as a string
+	verified := claims.EmailVerified == true || claims.EmailVerified == "true"
+
+	return Identity{
+		Subject:       claims.Subject,
+		Email:         claims.Email,
+		EmailVerified: verified,
+		Name:          claims.Name,
+		AvatarURL:     claims.Picture,
+	}, nil
+}
+
+// githubIdentity uses the REST API, as GitHub does not implement OpenID Connect
+// for user sign in. The primary email is only trusted when GitHub verified it.
+func (p *Provider) githubIdentity(client *http.Client) (Identity, error) {
+	apiURL := "https://api.github.com"
+	if baseURL := strings.TrimSuffix(p.config.BaseURL, "/"); baseURL != "" {
+		apiURL = baseURL + "/api/v3"
+	}
+
+	var user struct {
+		ID        int64  `json:"id"`
+		Login     string `json:"login"`
+		Name      string `json:"name"`
+		AvatarURL string `json:"avatar_url"`
+	}
+	if err := p.getJSON(context.Background(), apiURL+"/user", client, &user); err != nil {
+		return Identity{}, fmt.Errorf("github user request failed: %v", err)
+	}
+
+	var emails []struct {
+		Email    string `json:"email"`
+		Primary  bool   `json:"primary"`
+		Verified bool   `json:"verified"`
+	}
+	if err := p.getJSON(context.Background(), apiURL+"/user/emails", client, &emails); err != nil {
+		return Identity{}, fmt.Errorf("github emails request failed: %v", err)
+	}
+
+	identity := Identity{
+		Name:      user.Name,
+		AvatarURL: user.AvatarURL,
+	}
+	if user.ID != 0 {
+		identity.Subject = fmt.Sprint(user.ID)
+	}
+	if identity.Name == "" {
+		identity.Name = user.Login
+	}
+
+	for _, email := range emails {
+		if email.Primary {
+			identity.Email, identity.EmailVerified = email.Email, email.Verified
+		}
+	}
+
+	return identity, nil
+}
+
+func (p *Provider) getJSON(ctx context.Context, url string, client *http.Client, receiver interface{}) error {
+	if client == nil {
+		client = p.httpClient
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("unexpected status %v", resp.StatusCode)
+	}
+
+	return json.NewDecoder(resp.Body).Decode(receiver)
+}
@@ -0,0 +1,72 @@
+package oauth
+
+import (
+	"errors"
+	"reflect"
+	"sort"
+	"sync"
+
+	"github.com/hngprojects/hng_boilerplate_golang_web/internal/config"
+)
+
+var ErrProviderNotFound = errors.New("oauth provider not found")
+
+type ProviderInfo struct {
+	Name        string `json:"name"`
+	DisplayName string `json:"display_name"`
+	Type        string `json:"type"`
+}
+
+var (
+	registry       map[string]*Provider
+	registryConfig config.OAuth
+	registryLock   sync.Mutex
+)
+
+// getRegistry builds the providers from the configuration, again whenever the
+// configuration changed. Providers that are not configured correctly are left
+// out rather than failing every sign in.
+func getRegistry() map[string]*Provider {
+	registryLock.Lock()
+	defer registryLock.Unlock()
+
+	oauthConfig := config.GetConfig().OAuth
+	if registry != nil && reflect.DeepEqual(oauthConfig, registryConfig) {
+		return registry
+	}
+
+	registry = map[string]*Provider{}
+	for name, providerConfig := range oauthConfig.Providers {
+		provider, err := newProvider(name, providerConfig, oauthConfig.RedirectURL)
+		if err != nil {
+			continue
+		}
+		registry[name] = provider
+	}
+	registryConfig = oauthConfig
+
+	return registry
+}
+
+func GetProvider(name string) (*Provider, error) {
+	provider, ok := getRegistry()[name]
+	if !ok {
+		return nil, ErrProviderNotFound
+	}
+	return provider, nil
+}
+
+// Providers lists the configured providers sorted by name.
+func Providers() []ProviderInfo {
+	providers := []ProviderInfo{}
+	for _, provider := range getRegistry() {
+		providers = append(providers, ProviderInfo{
+			Name:        provider.Name,
+			DisplayName: provider.DisplayName,
+			Type:        provider.Type,
+		})
+	}
+
+	sort.Slice(providers, func(i, j int) bool { return providers[i].Name < providers[j].Name })
+	return providers
+}
@@ -13,21 +13,47 @@ type Configuration struct {
 	IPStack      IPStack
 	Mail         MAIL
 	Redis        Redis
+	OAuth        OAuth
+	WebAuthn     WebAuthn
 }
 
 type BaseConfig struct {
-	SERVER_PORT                      string  `mapstructure:"SERVER_PORT"`
-	SERVER_SECRET                    string  `mapstructure:"SERVER_SECRET"`
-	SERVER_ACCESSTOKENEXPIREDURATION int     `mapstructure:"SERVER_ACCESSTOKENEXPIREDURATION"`
-	REQUEST_PER_SECOND               float64 `mapstructure:"REQUEST_PER_SECOND"`
-	TRUSTED_PROXIES                  string  `mapstructure:"TRUSTED_PROXIES"`
-	EXEMPT_FROM_THROTTLE             string  `mapstructure:"EXEMPT_FROM_THROTTLE"`
-
-	APP_NAME                string `mapstructure:"APP_NAME"`
-	APP_MODE                string `mapstructure:"APP_MODE"`
-	APP_URL                 string `mapstructure:"APP_URL"`
-	MAGIC_LINK_DURATION     int    `mapstructure:"MAGIC_LINK_DURATION"`
-	RESET_PASSWORD_DURATION int    `mapstructure:"RESET_PASSWORD_DURATION"`
+	SERVER_PORT                       string  `mapstructure:"SERVER_PORT"`
+	SERVER_SECRET                     string  `mapstructure:"SERVER_SECRET"`
+	SERVER_ACCESSTOKENEXPIREDURATION  int     `mapstructure:"SERVER_ACCESSTOKENEXPIREDURATION"`
+	SERVER_ACCESSTOKENEXPIREMINUTES   int     `mapstructure:"SERVER_ACCESSTOKENEXPIREMINUTES"`
+	SERVER_REFRESHTOKENEXPIREDURATION int     `mapstructure:"SERVER_REFRESHTOKENEXPIREDURATION"`
+	SERVER_JWTALGORITHM               string  `mapstructure:"SERVER_JWTALGORITHM"`
+	SERVER_JWTACTIVEKEYID             string  `mapstructure:"SERVER_JWTACTIVEKEYID"`
+	SERVER_JWTACTIVEKEY               string  `mapstructure:"SERVER_JWTACTIVEKEY"`
+	SERVER_JWTRETIRINGKEYS            string  `mapstructure:"SERVER_JWTRETIRINGKEYS"`
+	SERVER_JWTACCEPTLEGACYHS256       bool    `mapstructure:"SERVER_JWTACCEPTLEGACYHS256"`
+	REQUEST_PER_SECOND                float64 `mapstructure:"REQUEST_PER_SECOND"`
+	TRUSTED_PROXIES                   string  `mapstructure:"TRUSTED_PROXIES"`
+	EXEMPT_FROM_THROTTLE              string  `mapstructure:"EXEMPT_FROM_THROTTLE"`
+
+	APP_NAME                    string `mapstructure:"APP_NAME"`
+	APP_MODE                    string `mapstructure:"APP_MODE"`
+	APP_URL                     string `mapstructure:"APP_URL"`
+	MAGIC_LINK_DURATION         int    `mapstructure:"MAGIC_LINK_DURATION"`
+	RESET_PASSWORD_DURATION     int    `mapstructure:"RESET_PASSWORD_DURATION"`
+	TWO_FA_CHALLENGE_DURATION   int    `mapstructure:"TWO_FA_CHALLENGE_DURATION"`
+	EMAIL_VERIFICATION_DURATION int    `mapstructure:"EMAIL_VERIFICATION_DURATION"`
+	EMAIL_VERIFICATION_REQUIRED string `mapstructure:"EMAIL_VERIFICATION_REQUIRED"`
+	LOGIN_MAX_ATTEMPTS          int    `mapstructure:"LOGIN_MAX_ATTEMPTS"`
+	LOGIN_MAX_IP_ATTEMPTS       int    `mapstructure:"LOGIN_MAX_IP_ATTEMPTS"`
+	LOGIN_LOCKOUT_DURATION      int    `mapstructure:"LOGIN_LOCKOUT_DURATION"`
+	PASSWORD_MIN_LENGTH         int    `mapstructure:"PASSWORD_MIN_LENGTH"`
+	PASSWORD_REQUIRE_UPPER      bool   `mapstructure:"PASSWORD_REQUIRE_UPPER"`
+	PASSWORD_REQUIRE_LOWER      bool   `mapstructure:"PASSWORD_REQUIRE_LOWER"`
+	PASSWORD_REQUIRE_DIGIT      bool   `mapstructure:"PASSWORD_REQUIRE_DIGIT"`
+	PASSWORD_REQUIRE_SYMBOL     bool   `mapstructure:"PASSWORD_REQUIRE_SYMBOL"`
+	PASSWORD_HISTORY_COUNT      int    `mapstructure:"PASSWORD_HISTORY_COUNT"`
+	PASSWORD_BREACHED_LIST      string `mapstructure:"PASSWORD_BREACHED_LIST"`
+	IMPERSONATION_DURATION      int    `mapstructure:"IMPERSONATION_DURATION"`
+	LOGIN_OTP_DURATION          int    `mapstructure:"LOGIN_OTP_DURATION"`
+	LOGIN_OTP_MAX_ATTEMPTS      int    `mapstructure:"LOGIN_OTP_MAX_ATTEMPTS"`
+	ACCOUNT_DELETION_GRACE_DAYS int    `mapstructure:"ACCOUNT_DELETION_GRACE_DAYS"`
 
 	DB_HOST       string `mapstructure:"DB_HOST"`
 	DB_PORT       string `mapstructure:"DB_PORT"`
@@ -60,32 +86,69 @@ type BaseConfig struct {
 	REDIS_PORT string `mapstructure:"REDIS_PORT"`
 	REDIS_HOST string `mapstructure:"REDIS_HOST"`
 	REDIS_DB   string `mapstructure:"REDIS_DB"`
+
+	OAUTH_REDIRECT_URL string `mapstructure:"OAUTH_REDIRECT_URL"`
+	OAUTH_PROVIDERS    string `mapstructure:"OAUTH_PROVIDERS"`
+
+	WEBAUTHN_RP_ID           string `mapstructure:"WEBAUTHN_RP_ID"`
+	WEBAUTHN_RP_DISPLAY_NAME string `mapstructure:"WEBAUTHN_RP_DISPLAY_NAME"`
+	WEBAUTHN_RP_ORIGINS      string `mapstructure:"WEBAUTHN_RP_ORIGINS"`
 }
 
 func (config *BaseConfig) SetupConfigurationn() *Configuration {
 	trustedProxies := []string{}
 	exemptFromThrottle := []string{}
+	jwtRetiringKeys := map[string]string{}
+	oauthProviders := map[string]OAuthProvider{}
+	webAuthnOrigins := []string{}
 	json.Unmarshal([]byte(config.TRUSTED_PROXIES), &trustedProxies)
 	json.Unmarshal([]byte(config.EXEMPT_FROM_THROTTLE), &exemptFromThrottle)
+	json.Unmarshal([]byte(config.SERVER_JWTRETIRINGKEYS), &jwtRetiringKeys)
+	json.Unmarshal([]byte(config.OAUTH_PROVIDERS), &oauthProviders)
+	json.Unmarshal([]byte(config.WEBAUTHN_RP_ORIGINS), &webAuthnOrigins)
 
 	if config.SERVER_PORT == "" {
 		config.SERVER_PORT = os.Getenv("PORT")
 	}
 	return &Configuration{
 		Server: ServerConfiguration{
-			Port:                      config.SERVER_PORT,
-			Secret:                    config.SERVER_SECRET,
-			AccessTokenExpireDuration: config.SERVER_ACCESSTOKENEXPIREDURATION,
-			RequestPerSecond:          config.REQUEST_PER_SECOND,
-			TrustedProxies:            trustedProxies,
-			ExemptFromThrottle:        exemptFromThrottle,
+			Port:                       config.SERVER_PORT,
+			Secret:                     config.SERVER_SECRET,
+			AccessTokenExpireDuration:  config.SERVER_ACCESSTOKENEXPIREDURATION,
+			AccessTokenExpireMinutes:   config.SERVER_ACCESSTOKENEXPIREMINUTES,
+			RefreshTokenExpireDuration: config.SERVER_REFRESHTOKENEXPIREDURATION,
+			JWTAlgorithm:               config.SERVER_JWTALGORITHM,
+			JWTActiveKeyID:             config.SERVER_JWTACTIVEKEYID,
+			JWTActiveKey:               config.SERVER_JWTACTIVEKEY,
+			JWTRetiringKeys:            jwtRetiringKeys,
+			JWTAcceptLegacyHS256:       config.SERVER_JWTACCEPTLEGACYHS256,
+			RequestPerSecond:           config.REQUEST_PER_SECOND,
+			TrustedProxies:             trustedProxies,
+			ExemptFromThrottle:         exemptFromThrottle,
 		},
 		App: App{
-			Name:                  config.APP_NAME,
-			Mode:                  config.APP_MODE,
-			Url:                   config.APP_URL,
-			MagicLinkDuration:     config.MAGIC_LINK_DURATION,
-			ResetPasswordDuration: config.RESET_PASSWORD_DURATION,
+			Name:                      config.APP_NAME,
+			Mode:                      config.APP_MODE,
+			Url:                       config.APP_URL,
+			MagicLinkDuration:         config.MAGIC_LINK_DURATION,
+			ResetPasswordDuration:     config.RESET_PASSWORD_DURATION,
+			TwoFAChallengeDuration:    config.TWO_FA_CHALLENGE_DURATION,
+			EmailVerificationDuration: config.EMAIL_VERIFICATION_DURATION,
+			EmailVerificationRequired: config.EMAIL_VERIFICATION_REQUIRED,
+			LoginMaxAttempts:          config.LOGIN_MAX_ATTEMPTS,
+			LoginMaxIPAttempts:        config.LOGIN_MAX_IP_ATTEMPTS,
+			LoginLockoutDuration:      config.LOGIN_LOCKOUT_DURATION,
+			PasswordMinLength:         config.PASSWORD_MIN_LENGTH,
+			PasswordRequireUpper:      config.PASSWORD_REQUIRE_UPPER,
+			PasswordRequireLower:      config.PASSWORD_REQUIRE_LOWER,
+			PasswordRequireDigit:      config.PASSWORD_REQUIRE_DIGIT,
+			PasswordRequireSymbol:     config.PASSWORD_REQUIRE_SYMBOL,
+			PasswordHistoryCount:      config.PASSWORD_HISTORY_COUNT,
+			PasswordBreachedList:      config.PASSWORD_BREACHED_LIST,
+			ImpersonationDuration:     config.IMPERSONATION_DURATION,
+			LoginOTPDuration:          config.LOGIN_OTP_DURATION,
+			LoginOTPMaxAttempts:       config.LOGIN_OTP_MAX_ATTEMPTS,
+			AccountDeletionGraceDays:  config.ACCOUNT_DELETION_GRACE_DAYS,
 		},
 		Database: Database{
 			DB_HOST:       config.DB_HOST,
@@ -127,5 +190,16 @@ func (config *BaseConfig) SetupConfigurationn() *Configuration {
 			REDIS_HOST: config.REDIS_HOST,
 			REDIS_DB:   config.REDIS_DB,
 		},
+
+		OAuth: OAuth{
+			RedirectURL: config.OAUTH_REDIRECT_URL,
+			Providers:   oauthProviders,
+		},
+
+		WebAuthn: WebAuthn{
+			RPID:          config.WEBAUTHN_RP_ID,
+			RPDisplayName: config.WEBAUTHN_RP_DISPLAY_NAME,
+			RPOrigins:     webAuthnOrigins,
+		},
 	}
 }
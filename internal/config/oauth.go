@@ -0,0 +1,27 @@
+package config
+
+type OAuth struct {
+	RedirectURL string
+	Providers   map[string]OAuthProvider
+}
+
+// OAuthProvider configures one sign in provider. Type defaults to the provider
+// name; DiscoveryURL is required for the oidc type, BaseURL points github and
+// gitlab at a self-hosted instance and Tenant restricts microsoft sign in.
+type OAuthProvider struct {
+	Type         string   `json:"type"`
+	DisplayName  string   `json:"display_name"`
+	ClientID     string   `json:"client_id"`
+	ClientSecret string   `json:"client_secret"`
+	DiscoveryURL string   `json:"discovery_url"`
+	BaseURL      string   `json:"base_url"`
+	Tenant       string   `json:"tenant"`
+	Scopes       []string `json:"scopes"`
+}
+
+const (
+	OAuthProviderGitHub    = "github"
+	OAuthProviderGitLab    = "gitlab"
+	OAuthProviderMicrosoft = "microsoft"
+	OAuthProviderOIDC      = "oidc"
+)
@@ -1,18 +1,51 @@
 package config
 
 type ServerConfiguration struct {
-	Port                      string
-	Secret                    string
-	AccessTokenExpireDuration int
-	RequestPerSecond          float64
-	TrustedProxies            []string
-	ExemptFromThrottle        []string
+	Port                       string
+	Secret                     string
+	AccessTokenExpireDuration  int
+	AccessTokenExpireMinutes   int
+	RefreshTokenExpireDuration int
+	JWTAlgorithm               string
+	JWTActiveKeyID             string
+	JWTActiveKey               string
+	JWTRetiringKeys            map[string]string
+	JWTAcceptLegacyHS256       bool
+	RequestPerSecond           float64
+	TrustedProxies             []string
+	ExemptFromThrottle         []string
 }
 
 type App struct {
-	Name                  string
-	Mode                  string
-	Url                   string
-	MagicLinkDuration     int
-	ResetPasswordDuration int
+	Name                      string
+	Mode                      string
+	Url                       string
+	MagicLinkDuration         int
+	ResetPasswordDuration     int
+	TwoFAChallengeDuration    int
+	EmailVerificationDuration int
+	EmailVerificationRequired string
+	LoginMaxAttempts          int
+	LoginMaxIPAttempts        int
+	LoginLockoutDuration      int
+	PasswordMinLength         int
+	PasswordRequireUpper      bool
+	PasswordRequireLower      bool
+	PasswordRequireDigit      bool
+	PasswordRequireSymbol     bool
+	PasswordHistoryCount      int
+	PasswordBreachedList      string
+	ImpersonationDuration     int
+	LoginOTPDuration          int
+	LoginOTPMaxAttempts       int
+	AccountDeletionGraceDays  int
 }
+
+// Values of App.EmailVerificationRequired. Any other value leaves unverified
+// users unrestricted. Users created before email verification existed are
+// marked verified when the migration adds the column, so only new sign ups
+// are held to it.
+const (
+	EmailVerificationOnLogin  = "login"
+	EmailVerificationOnRoutes = "routes"
+)
@@ -0,0 +1,10 @@
+package config
+
+// WebAuthn configures the relying party passkeys are registered with. RPID is
+// the domain the passkeys are bound to and RPOrigins the exact origins of the
+// frontend pages that run the ceremonies; both default to APP_URL.
+type WebAuthn struct {
+	RPID          string
+	RPDisplayName string
+	RPOrigins     []string
+}
@@ -8,16 +8,55 @@ import (
 	"gorm.io/gorm"
 
 	"github.com/hngprojects/hng_boilerplate_golang_web/pkg/repository/storage/postgresql"
+	"github.com/hngprojects/hng_boilerplate_golang_web/utility"
 )
 
 type AccessToken struct {
-	ID                        string    `gorm:"column:id; type:uuid; not null; primaryKey; unique;" json:"id"`
-	OwnerID                   string    `gorm:"column:owner_id; type:uuid; not null" json:"owner_id"`
-	IsLive                    bool      `gorm:"column:is_live; type:bool; default:false; not null" json:"is_live"`
-	LoginAccessToken          string    `gorm:"column:login_access_token; type:text" json:"-"`
-	LoginAccessTokenExpiresIn string    `gorm:"column:login_access_token_expires_in; type:varchar(250)" json:"-"`
-	CreatedAt                 time.Time `gorm:"column:created_at; autoCreateTime" json:"created_at"`
-	UpdatedAt                 time.Time `gorm:"column:updated_at; autoUpdateTime" json:"updated_at"`
+	ID                        string     `gorm:"column:id; type:uuid; not null; primaryKey; unique;" json:"id"`
+	OwnerID                   string     `gorm:"column:owner_id; type:uuid; not null" json:"owner_id"`
+	IsLive                    bool       `gorm:"column:is_live; type:bool; default:false; not null" json:"is_live"`
+	LoginAccessToken          string     `gorm:"column:login_access_token; type:text" json:"-"`
+	LoginAccessTokenExpiresIn string     `gorm:"column:login_access_token_expires_in; type:varchar(250)" json:"-"`
+	UserAgent                 string     `gorm:"column:user_agent; type:text" json:"user_agent"`
+	IPAddress                 string     `gorm:"column:ip_address; type:varchar(64)" json:"ip_address"`
+	LastUsedAt                *time.Time `gorm:"column:last_used_at" json:"last_used_at"`
+	ImpersonatorID            *string    `gorm:"column:impersonator_id; type:uuid" json:"impersonator_id"`
+	CreatedAt                 time.Time  `gorm:"column:created_at; autoCreateTime" json:"created_at"`
+	UpdatedAt                 time.Time  `gorm:"column:updated_at; autoUpdateTime" json:"updated_at"`
+}
+
+// SessionClient describes the device a session was started from.
+type SessionClient struct {
+	UserAgent string
+	IPAddress string
+}
+
+// RefreshToken is an opaque, single use token bound to an AccessToken session.
+// All refresh tokens of one session form a family that is revoked together.
+type RefreshToken struct {
+	ID            string     `gorm:"column:id; type:uuid; not null; primaryKey; unique;" json:"id"`
+	OwnerID       string     `gorm:"column:owner_id; type:uuid; not null; index" json:"owner_id"`
+	AccessTokenID string     `gorm:"column:access_token_id; type:uuid; not null; index" json:"access_token_id"`
+	ParentID      *string    `gorm:"column:parent_id; type:uuid" json:"parent_id"`
+	TokenHash     string     `gorm:"column:token_hash; type:varchar(64); not null; uniqueIndex" json:"-"`
+	ExpiresAt     time.Time  `gorm:"column:expires_at; not null" json:"expires_at"`
+	UsedAt        *time.Time `gorm:"column:used_at" json:"used_at"`
+	RevokedAt     *time.Time `gorm:"column:revoked_at" json:"revoked_at"`
+	CreatedAt     time.Time  `gorm:"column:created_at; autoCreateTime" json:"created_at"`
+}
+
+type RefreshTokenRequestModel struct {
+	RefreshToken string `json:"refresh_token" validate:"required"`
+}
+
+type SessionResponse struct {
+	ID           string     `json:"id"`
+	UserAgent    string     `json:"user_agent"`
+	IPAddress    string     `json:"ip_address"`
+	Current      bool       `json:"current"`
+	Impersonated bool       `json:"impersonated"`
+	CreatedAt    time.Time  `json:"created_at"`
+	LastUsedAt   *time.Time `json:"last_used_at"`
 }
 
 func (a *AccessToken) GetAccessTokens(db *gorm.DB) error {
@@ -92,7 +131,110 @@ func (a *AccessToken) RevokeAccessToken(db *gorm.DB) error {
 	if a.ID == "" {
 		return fmt.Errorf("access token id not provided to revoke access token")
 	}
+
+	var refreshToken RefreshToken
+	if err := refreshToken.RevokeFamily(db, a.ID); err != nil {
+		return err
+	}
+
 	a.IsLive = false
-	_, err := postgresql.SaveAllFields(db, &a)
-	return err
+	return db.Model(&AccessToken{}).Where("id = ?", a.ID).Update("is_live", false).Error
+}
+
+// GetLiveSessions returns the live sessions of the owner, most recently created first.
+func (a *AccessToken) GetLiveSessions(db *gorm.DB, ownerID string) ([]AccessToken, error) {
+	var sessions []AccessToken
+
+	err := postgresql.SelectAllFromDbOrderBy(db, "created_at", "desc", &sessions, "owner_id = ? AND is_live = ?", ownerID, true)
+	if err != nil {
+		return sessions, err
+	}
+
+	return sessions, nil
+}
+
+// RevokeOwnerSessions revokes every live session of the owner except the ones in keep.
+func (a *AccessToken) RevokeOwnerSessions(db *gorm.DB, ownerID string, keep ...string) (int, error) {
+	sessions, err := a.GetLiveSessions(db, ownerID)
+	if err != nil {
+		return 0, err
+	}
+
+	revoked := 0
+	err = db.Transaction(func(tx *gorm.DB) error {
+		for _, session := range sessions {
+			if utility.InStringSlice(session.ID, keep) {
+				continue
+			}
+			if err := session.RevokeAccessToken(tx); err != nil {
+				return err
+			}
+			revoked++
+		}
+		return nil
+	})
+
+	return revoked, err
+}
+
+// TouchLastUsed records that the session was used, at most once per interval
+// so that authorized requests do not all turn into writes.
+func (a *AccessToken) TouchLastUsed(db *gorm.DB, interval time.Duration) error {
+	now := time.Now()
+	if a.LastUsedAt != nil && now.Sub(*a.LastUsedAt) < interval {
+		return nil
+	}
+
+	a.LastUsedAt = &now
+	return db.Model(&AccessToken{}).Where("id = ?", a.ID).UpdateColumn("last_used_at", now).Error
+}
+
+// RotateLoginAccessToken swaps the stored token of a live session for a freshly signed one.
+func (a *AccessToken) RotateLoginAccessToken(db *gorm.DB, accessToken, exp string) error {
+	a.LoginAccessToken = accessToken
+	a.LoginAccessTokenExpiresIn = exp
+	return db.Model(&AccessToken{}).Where("id = ?", a.ID).Updates(map[string]interface{}{
+		"login_access_token":            accessToken,
+		"login_access_token_expires_in": exp,
+	}).Error
+}
+
+func (r *RefreshToken) CreateRefreshToken(db *gorm.DB) error {
+	err := postgresql.CreateOneRecord(db, &r)
+	if err != nil {
+		return fmt.Errorf("refresh token creation failed: %v", err.Error())
+	}
+	return nil
+}
+
+func (r *RefreshToken) GetByTokenHash(db *gorm.DB, tokenHash string) (int, error) {
+	err, nilErr := postgresql.SelectOneFromDb(db, &r, "token_hash = ?", tokenHash)
+	if nilErr != nil {
+		return http.StatusUnauthorized, nilErr
+	}
+
+	if err != nil {
+		return http.StatusInternalServerError, err
+	}
+	return http.StatusOK, nil
+}
+
+// MarkUsed consumes the refresh token. It reports false if another request used it first.
+func (r *RefreshToken) MarkUsed(db *gorm.DB) (bool, error) {
+	now := time.Now()
+	result := db.Model(&RefreshToken{}).
+		Where("id = ? AND used_at IS NULL AND revoked_at IS NULL", r.ID).
+		Update("used_at", now)
+	if result.Error != nil {
+		return false, result.Error
+	}
+	r.UsedAt = &now
+	return result.RowsAffected == 1, nil
+}
+
+// RevokeFamily revokes every refresh token issued for the session.
+func (r *RefreshToken) RevokeFamily(db *gorm.DB, accessTokenID string) error {
+	return db.Model(&RefreshToken{}).
+		Where("access_token_id = ? AND revoked_at IS NULL", accessTokenID).
+		Update("revoked_at", time.Now()).Error
 }
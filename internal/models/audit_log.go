@@ -0,0 +1,56 @@
+package models
+
+import (
+	"fmt"
+	"time"
+
+	"gorm.io/gorm"
+
+	"github.com/hngprojects/hng_boilerplate_golang_web/pkg/repository/storage/postgresql"
+)
+
+const (
+	AuditActionImpersonationStart = "impersonation.start"
+	AuditActionImpersonationStop  = "impersonation.stop"
+	AuditActionBillingCreate      = "billing_plan.create"
+	AuditActionBillingUpdate      = "billing_plan.update"
+	AuditActionBillingArchive     = "billing_plan.archive"
+	AuditActionBillingRestore     = "billing_plan.restore"
+	AuditActionBillingDelete      = "billing_plan.delete"
+	AuditActionOwnershipForced    = "organisation.ownership_forced"
+	AuditActionMemberRemoved      = "organisation.member_removed"
+	AuditActionMemberLeft         = "organisation.member_left"
+)
+
+// AuditLog records a privileged action: who did it, to what and from where.
+type AuditLog struct {
+	ID         string    `gorm:"column:id; type:uuid; not null; primaryKey; unique;" json:"id"`
+	ActorID    string    `gorm:"column:actor_id; type:uuid; not null; index" json:"actor_id"`
+	Action     string    `gorm:"column:action; type:varchar(100); not null; index" json:"action"`
+	TargetType string    `gorm:"column:target_type; type:varchar(100)" json:"target_type"`
+	TargetID   string    `gorm:"column:target_id; type:varchar(255); index" json:"target_id"`
+	Details    string    `gorm:"column:details; type:text" json:"details"`
+	IPAddress  string    `gorm:"column:ip_address; type:varchar(64)" json:"ip_address"`
+	UserAgent  string    `gorm:"column:user_agent; type:text" json:"user_agent"`
+	CreatedAt  time.Time `gorm:"column:created_at; autoCreateTime" json:"created_at"`
+}
+
+func (a *AuditLog) CreateAuditLog(db *gorm.DB) error {
+	err := postgresql.CreateOneRecord(db, &a)
+	if err != nil {
+		return fmt.Errorf("audit log creation failed: %v", err.Error())
+	}
+	return nil
+}
+
+// GetTargetAuditLogs returns the audit logs of a target, newest first.
+func (a *AuditLog) GetTargetAuditLogs(db *gorm.DB, targetType, targetID string) ([]AuditLog, error) {
+	var logs []AuditLog
+
+	err := postgresql.SelectAllFromDbOrderBy(db, "created_at", "desc", &logs, "target_type = ? AND target_id = ?", targetType, targetID)
+	if err != nil {
+		return logs, err
+	}
+
+	return logs, nil
+}
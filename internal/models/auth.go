@@ -28,6 +28,38 @@ type MagicLink struct {
 	DeletedAt gorm.DeletedAt `gorm:"index" json:"-"`
 }
 
+// EmailVerification holds the pending verification of a user's email address.
+// The link token is stored hashed; the short code is bound to the email and
+// only accepts a limited number of attempts.
+type EmailVerification struct {
+	ID        string         `gorm:"type:uuid;primaryKey;unique;not null" json:"id"`
+	Email     string         `gorm:"index"`
+	TokenHash string         `gorm:"column:token_hash; type:varchar(64); uniqueIndex"`
+	Code      string         `gorm:"column:code; type:varchar(10)"`
+	Attempts  int            `gorm:"column:attempts; default:0; not null"`
+	ExpiresAt time.Time      `gorm:"column:expires_at" json:"expires_at"`
+	CreatedAt time.Time      `gorm:"column:created_at; autoCreateTime" json:"created_at"`
+	DeletedAt gorm.DeletedAt `gorm:"index" json:"-"`
+}
+
+type VerifyEmailRequestModel struct {
+	Token string `json:"token" validate:"required_without=Code"`
+	Email string `json:"email" validate:"required_with=Code,omitempty,email"`
+	Code  string `json:"code" validate:"required_without=Token"`
+}
+
+type ResendEmailVerificationRequestModel struct {
+	Email string `json:"email" validate:"required,email"`
+}
+
+type UnlockAccountRequestModel struct {
+	Token string `json:"token" validate:"required"`
+}
+
+type ImpersonateUserRequestModel struct {
+	Reason string `json:"reason" validate:"required,max=500"`
+}
+
 type MagicLinkRequest struct {
 	Email string `json:"email" validate:"required,email"`
 }
@@ -36,6 +68,15 @@ type VerifyMagicLinkRequest struct {
 	Token string `json:"token" validate:"required"`
 }
 
+type LoginOTPRequestModel struct {
+	Email string `json:"email" validate:"required,email"`
+}
+
+type VerifyLoginOTPRequestModel struct {
+	Email string `json:"email" validate:"required,email"`
+	OTP   string `json:"otp" validate:"required,numeric,max=6"`
+}
+
 type ChangePasswordRequestModel struct {
 	OldPassword string `json:"old_password" validate:""`
 	NewPassword string `json:"new_password" validate:"required,min=7"`
@@ -157,3 +198,50 @@ func (m *MagicLink) DeleteMagicLink(db *gorm.DB) error {
 
 	return nil
 }
+
+func (e *EmailVerification) CreateEmailVerification(db *gorm.DB) error {
+
+	err := postgresql.CreateOneRecord(db, &e)
+
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (e *EmailVerification) GetEmailVerificationByTokenHash(db *gorm.DB, tokenHash string) (EmailVerification, error) {
+	var verification EmailVerification
+	if err := db.Where("token_hash = ? AND expires_at > ?", tokenHash, time.Now()).First(&verification).Error; err != nil {
+		return verification, err
+	}
+	return verification, nil
+}
+
+func (e *EmailVerification) GetEmailVerificationByEmail(db *gorm.DB, email string) (*EmailVerification, error) {
+	var verification EmailVerification
+	if err := db.Where("email = ?", email).First(&verification).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &verification, nil
+}
+
+// IncrementAttempts records a failed code attempt.
+func (e *EmailVerification) IncrementAttempts(db *gorm.DB) error {
+	e.Attempts++
+	return db.Model(&EmailVerification{}).Where("id = ?", e.ID).UpdateColumn("attempts", gorm.Expr("attempts + 1")).Error
+}
+
+func (e *EmailVerification) DeleteEmailVerification(db *gorm.DB) error {
+
+	err := postgresql.DeleteRecordFromDb(db, e)
+
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
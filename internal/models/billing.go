@@ -6,17 +6,36 @@ import (
 
 	"github.com/gin-gonic/gin"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 
 	"github.com/hngprojects/hng_boilerplate_golang_web/pkg/repository/storage/postgresql"
 )
 
+// Billing plans are active while they can be subscribed to. An archived plan
+// keeps its existing subscribers but is hidden from new ones.
+const (
+	BillingStatusActive   = "active"
+	BillingStatusArchived = "archived"
+)
+
 type Billing struct {
-	ID        string         `gorm:"type:uuid;primary_key" json:"id"`
-	Name      string         `gorm:"not null" json:"name"`
-	Price     float64        `gorm:"column:price; type:decimal(10,2);null" json:"price"`
-	CreatedAt time.Time      `gorm:"column:created_at; not null; autoCreateTime" json:"created_at"`
-	UpdatedAt time.Time      `gorm:"column:updated_at; null; autoUpdateTime" json:"updated_at"`
-	DeletedAt gorm.DeletedAt `gorm:"index" json:"-"`
+	ID         string         `gorm:"type:uuid;primary_key" json:"id"`
+	Name       string         `gorm:"not null" json:"name"`
+	Price      float64        `gorm:"column:price; type:decimal(10,2);null" json:"price"`
+	Status     string         `gorm:"column:status; type:varchar(20); not null; default:'active'; index" json:"status"`
+	ArchivedAt *time.Time     `gorm:"column:archived_at" json:"archived_at"`
+	CreatedAt  time.Time      `gorm:"column:created_at; not null; autoCreateTime" json:"created_at"`
+	UpdatedAt  time.Time      `gorm:"column:updated_at; null; autoUpdateTime" json:"updated_at"`
+	DeletedAt  gorm.DeletedAt `gorm:"index" json:"-"`
+}
+
+// Subscription ties an organisation to the billing plan it pays for.
+type Subscription struct {
+	ID             string    `gorm:"type:uuid;primary_key" json:"id"`
+	BillingID      string    `gorm:"type:uuid; not null; index" json:"billing_id"`
+	OrganisationID string    `gorm:"type:uuid; not null; index" json:"organisation_id"`
+	CreatedAt      time.Time `gorm:"column:created_at; not null; autoCreateTime" json:"created_at"`
+	UpdatedAt      time.Time `gorm:"column:updated_at; null; autoUpdateTime" json:"updated_at"`
 }
 
 type CreateBillingRequest struct {
@@ -33,6 +52,7 @@ type BillingResponse struct {
 	BillingID string    `json:"id"`
 	Name      string    `json:"title"`
 	Price     float64   `json:"price"`
+	Status    string    `json:"status"`
 	CreatedAt time.Time `json:"created_at"`
 	UpdatedAt time.Time `json:"updated_at"`
 }
@@ -66,18 +86,30 @@ func (b *Billing) GetBillingById(db *gorm.DB, BillingId string) (Billing, error)
 	return Billing, nil
 }
 
-func (b *Billing) GetAllBillings(db *gorm.DB, c *gin.Context) ([]Billing, postgresql.PaginationResponse, error) {
-	var Billing []Billing
+// GetAllBillings lists the plans with the given status, or every plan when
+// status is empty.
+func (b *Billing) GetAllBillings(db *gorm.DB, c *gin.Context, status string) ([]Billing, postgresql.PaginationResponse, error) {
+	var (
+		Billing []Billing
+		query   interface{}
+		args    []interface{}
+	)
 
 	pagination := postgresql.GetPagination(c)
 
+	if status != "" {
+		query = "status = ?"
+		args = append(args, status)
+	}
+
 	paginationResponse, err := postgresql.SelectAllFromDbOrderByPaginated(
 		db,
 		"created_at",
 		"desc",
 		pagination,
 		&Billing,
-		nil,
+		query,
+		args...,
 	)
 
 	if err != nil {
@@ -101,6 +133,14 @@ func (b *Billing) UpdateBillingById(db *gorm.DB, req UpdateBillingRequest, Billi
 	return b, nil
 }
 
+// LockBillingById loads the plan and locks its row until tx ends.
+func (b *Billing) LockBillingById(tx *gorm.DB, BillingId string) (Billing, error) {
+	var Billing Billing
+
+	err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Where("id = ?", BillingId).First(&Billing).Error
+	return Billing, err
+}
+
 func (b *Billing) CheckBillingExists(BillingId string, db *gorm.DB) (Billing, error) {
 	Billing, err := b.GetBillingById(db, BillingId)
 	if err != nil {
@@ -109,3 +149,28 @@ func (b *Billing) CheckBillingExists(BillingId string, db *gorm.DB) (Billing, er
 
 	return Billing, nil
 }
+
+// SetStatus moves the plan between the active and archived states.
+func (b *Billing) SetStatus(db *gorm.DB, status string) error {
+	b.Status = status
+	b.ArchivedAt = nil
+	if status == BillingStatusArchived {
+		now := time.Now()
+		b.ArchivedAt = &now
+	}
+
+	_, err := postgresql.SaveAllFields(db, b)
+	return err
+}
+
+// CountSubscribers returns how many organisations are subscribed to the plan,
+// leaving out organisations that have been deleted.
+func (b *Billing) CountSubscribers(db *gorm.DB) (int64, error) {
+	var count int64
+
+	err := db.Model(&Subscription{}).
+		Joins("JOIN organisations ON organisations.id = subscriptions.organisation_id AND organisations.deleted_at IS NULL").
+		Where("subscriptions.billing_id = ?", b.ID).
+		Count(&count).Error
+	return count, err
+}
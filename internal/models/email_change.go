@@ -0,0 +1,82 @@
+package models
+
+import (
+	"errors"
+	"time"
+
+	"gorm.io/gorm"
+
+	"github.com/hngprojects/hng_boilerplate_golang_web/pkg/repository/storage/postgresql"
+)
+
+// EmailChange is a pending change of a user's email address. The address is
+// only swapped once the link mailed to NewEmail is confirmed; the link mailed
+// to OldEmail cancels the change.
+type EmailChange struct {
+	ID               string    `gorm:"column:id; type:uuid; not null; primaryKey; unique;" json:"id"`
+	UserID           string    `gorm:"column:user_id; type:uuid; not null; uniqueIndex" json:"user_id"`
+	OldEmail         string    `gorm:"column:old_email; type:varchar(255); not null" json:"old_email"`
+	NewEmail         string    `gorm:"column:new_email; type:varchar(255); not null; index" json:"new_email"`
+	ConfirmTokenHash string    `gorm:"column:confirm_token_hash; type:varchar(64); not null; uniqueIndex" json:"-"`
+	CancelTokenHash  string    `gorm:"column:cancel_token_hash; type:varchar(64); not null; uniqueIndex" json:"-"`
+	SessionID        string    `gorm:"column:session_id; type:varchar(255)" json:"-"`
+	ExpiresAt        time.Time `gorm:"column:expires_at; not null" json:"expires_at"`
+	CreatedAt        time.Time `gorm:"column:created_at; autoCreateTime" json:"created_at"`
+}
+
+type ChangeEmailRequestModel struct {
+	NewEmail string `json:"new_email" validate:"required,email"`
+	Password string `json:"password" validate:"required"`
+}
+
+type EmailChangeTokenRequestModel struct {
+	Token string `json:"token" validate:"required"`
+}
+
+// ReplaceEmailChange stores the change as the only pending one of the user.
+func (e *EmailChange) ReplaceEmailChange(db *gorm.DB) error {
+	return db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("user_id = ?", e.UserID).Delete(&EmailChange{}).Error; err != nil {
+			return err
+		}
+		return postgresql.CreateOneRecord(tx, &e)
+	})
+}
+
+func (e *EmailChange) GetByConfirmTokenHash(db *gorm.DB, tokenHash string) (EmailChange, error) {
+	var change EmailChange
+	if err := db.Where("confirm_token_hash = ? AND expires_at > ?", tokenHash, time.Now()).First(&change).Error; err != nil {
+		return change, err
+	}
+	return change, nil
+}
+
+func (e *EmailChange) GetByCancelTokenHash(db *gorm.DB, tokenHash string) (EmailChange, error) {
+	var change EmailChange
+	if err := db.Where("cancel_token_hash = ?", tokenHash).First(&change).Error; err != nil {
+		return change, err
+	}
+	return change, nil
+}
+
+// GetPendingByEmail returns the unexpired change to email, if any.
+func (e *EmailChange) GetPendingByEmail(db *gorm.DB, email string) (*EmailChange, error) {
+	var change EmailChange
+	if err := db.Where("new_email = ? AND expires_at > ?", email, time.Now()).First(&change).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &change, nil
+}
+
+// DeleteEmailChange removes the change. It reports false if it was already
+// gone, so a link can only be used once.
+func (e *EmailChange) DeleteEmailChange(db *gorm.DB) (bool, error) {
+	result := db.Where("id = ?", e.ID).Delete(&EmailChange{})
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected == 1, nil
+}
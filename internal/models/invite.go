@@ -1,12 +1,27 @@
 package models
 
 import (
+	"errors"
 	"time"
 
 	"github.com/hngprojects/hng_boilerplate_golang_web/pkg/repository/storage/postgresql"
 	"gorm.io/gorm"
 )
 
+const (
+	InvitationStatusPending  = "pending"
+	InvitationStatusExpired  = "expired"
+	InvitationStatusAccepted = "accepted"
+	InvitationStatusDeclined = "declined"
+	InvitationStatusRevoked  = "revoked"
+)
+
+// ErrInvalidInvitationStatus is returned when listing invitations by a status
+// that does not exist.
+var ErrInvalidInvitationStatus = errors.New("status must be one of pending, expired, accepted, declined or revoked")
+
+// Invitation is an offer to join an organisation sent to an email address.
+// UserID is the member who sent it; the invitee may not have an account yet.
 type Invitation struct {
 	ID             string       `gorm:"type:uuid;primaryKey;unique;not null" json:"id"`
 	UserID         string       `gorm:"type:uuid;" json:"user_id"`
@@ -17,6 +32,8 @@ type Invitation struct {
 	ExpiresAt      time.Time    `gorm:"column:expires_at; not null" json:"expires_at"`
 	IsValid        bool         `gorm:"type:boolean;default:true" json:"is_valid"`
 	Email          string       `gorm:"type:varchar(100);" json:"email"`
+	Status         string       `gorm:"type:varchar(20);default:'pending';index" json:"status"`
+	RespondedAt    *time.Time   `gorm:"column:responded_at" json:"responded_at"`
 }
 
 type InvitationRequest struct {
@@ -33,16 +50,36 @@ type InvitationResponse struct {
 	Expires_At  time.Time `json:"expires_at"`
 }
 
+// OrgInvitationResponse is an invitation as listed to the organisation's
+// admins. The token is left out so it only ever reaches the invitee.
+type OrgInvitationResponse struct {
+	ID          string     `json:"id"`
+	Email       string     `json:"email"`
+	Status      string     `json:"status"`
+	InvitedBy   string     `json:"invited_by"`
+	SentAt      time.Time  `json:"sent_at"`
+	ExpiresAt   time.Time  `json:"expires_at"`
+	RespondedAt *time.Time `json:"responded_at"`
+}
+
 type InvitationCreateReq struct {
 	OrganisationID string `json:"organisation_id" validate:"required,uuid"`
 	Email          string `json:"email" validate:"required,email"`
 }
 
 func (i *Invitation) CreateInvitation(db *gorm.DB) error {
-	//set the expiration time to 24 hours
-	i.ExpiresAt = time.Now().Add(24 * time.Hour)
+	var org Organisation
 
-	err := postgresql.CreateOneRecord(db, &i)
+	org, err := org.GetOrgByID(db, i.OrganisationID)
+	if err != nil {
+		return err
+	}
+
+	i.ExpiresAt = time.Now().Add(org.InvitationTTL())
+	i.Status = InvitationStatusPending
+	i.IsValid = true
+
+	err = postgresql.CreateOneRecord(db, &i)
 	if err != nil {
 		return err
 	}
@@ -62,4 +99,118 @@ func (i *Invitation) GetInvitationsByID(db *gorm.DB, user_id string) ([]Invitati
 
 type InvitationAcceptReq struct {
 	InvitationLink string `json:"invitation_link" validate:"required"`
-}
\ No newline at end of file
+}
+
+type InvitationDeclineReq struct {
+	InvitationLink string `json:"invitation_link" validate:"required"`
+}
+
+// CurrentStatus reports the status of the invitation, treating a pending
+// invitation past its expiry as expired.
+func (i *Invitation) CurrentStatus() string {
+	if i.Status == InvitationStatusPending && !i.ExpiresAt.After(time.Now()) {
+		return InvitationStatusExpired
+	}
+	return i.Status
+}
+
+func (i *Invitation) Response() OrgInvitationResponse {
+	return OrgInvitationResponse{
+		ID:          i.ID,
+		Email:       i.Email,
+		Status:      i.CurrentStatus(),
+		InvitedBy:   i.UserID,
+		SentAt:      i.CreatedAt,
+		ExpiresAt:   i.ExpiresAt,
+		RespondedAt: i.RespondedAt,
+	}
+}
+
+func (i *Invitation) GetOrgInvitationByID(db *gorm.DB, orgID, invitationID string) (Invitation, error) {
+	var invitation Invitation
+
+	err, nerr := postgresql.SelectOneFromDb(db, &invitation, "id = ? AND organisation_id = ?", invitationID, orgID)
+	if nerr != nil {
+		return invitation, nerr
+	}
+	if err != nil {
+		return invitation, err
+	}
+	return invitation, nil
+}
+
+// GetOrgInvitations lists the invitations of an organisation, newest first,
+// optionally narrowed to one status.
+func (i *Invitation) GetOrgInvitations(db *gorm.DB, orgID, status string, pagination postgresql.Pagination) ([]Invitation, postgresql.PaginationResponse, error) {
+	var (
+		invitations []Invitation
+		now         = time.Now()
+		query       = "organisation_id = ?"
+		args        = []interface{}{orgID}
+	)
+
+	switch status {
+	case "":
+	case InvitationStatusPending:
+		query += " AND status = ? AND expires_at > ?"
+		args = append(args, InvitationStatusPending, now)
+	case InvitationStatusExpired:
+		query += " AND status = ? AND expires_at <= ?"
+		args = append(args, InvitationStatusPending, now)
+	case InvitationStatusAccepted, InvitationStatusDeclined, InvitationStatusRevoked:
+		query += " AND status = ?"
+		args = append(args, status)
+	default:
+		return nil, postgresql.PaginationResponse{}, ErrInvalidInvitationStatus
+	}
+
+	paginationResponse, err := postgresql.SelectAllFromDbOrderByPaginated(db, "created_at", "desc", pagination, &invitations, query, args...)
+	if err != nil {
+		return nil, paginationResponse, err
+	}
+	return invitations, paginationResponse, nil
+}
+
+// Respond closes a pending invitation with the given status so its link can
+// no longer be used.
+func (i *Invitation) Respond(db *gorm.DB, status string) error {
+	now := time.Now()
+
+	err := db.Model(&Invitation{}).Where("id = ?", i.ID).Updates(map[string]interface{}{
+		"status":       status,
+		"is_valid":     false,
+		"responded_at": now,
+	}).Error
+	if err != nil {
+		return err
+	}
+
+	i.Status = status
+	i.IsValid = false
+	i.RespondedAt = &now
+	return nil
+}
+
+// Reissue reopens the invitation under a fresh token and expiry, which
+// voids the link sent before.
+func (i *Invitation) Reissue(db *gorm.DB, token string, ttl time.Duration) error {
+	expiresAt := time.Now().Add(ttl)
+
+	err := db.Model(&Invitation{}).Where("id = ?", i.ID).Updates(map[string]interface{}{
+		"token":        token,
+		"expires_at":   expiresAt,
+		"status":       InvitationStatusPending,
+		"is_valid":     true,
+		"responded_at": nil,
+	}).Error
+	if err != nil {
+		return err
+	}
+
+	i.Token = token
+	i.ExpiresAt = expiresAt
+	i.Status = InvitationStatusPending
+	i.IsValid = true
+	i.RespondedAt = nil
+	return nil
+}
@@ -1,5 +1,13 @@
 package models
 
+import (
+	"time"
+
+	"gorm.io/gorm"
+
+	"github.com/hngprojects/hng_boilerplate_golang_web/pkg/repository/storage/postgresql"
+)
+
 type Key struct {
 	ID     string `gorm:"type:uuid;primaryKey;unique;not null" json:"id"`
 	UserID string `gorm:"type:uuid;not null" json:"user_id"`
@@ -10,3 +18,78 @@ type Key struct {
 type VerifyKeyRequestModel struct {
 	Key string `json:"key" validate:"required"`
 }
+
+type TwoFALoginRequestModel struct {
+	ChallengeToken string `json:"challenge_token" validate:"required"`
+	Code           string `json:"code" validate:"required_without=RecoveryCode"`
+	RecoveryCode   string `json:"recovery_code" validate:"required_without=Code"`
+}
+
+func (k *Key) GetKeyByUserID(db *gorm.DB, userID string) (Key, error) {
+	var key Key
+
+	err, nerr := postgresql.SelectOneFromDb(db, &key, "user_id = ?", userID)
+	if nerr != nil {
+		return key, nerr
+	}
+
+	if err != nil {
+		return key, err
+	}
+
+	return key, nil
+}
+
+type RecoveryCode struct {
+	ID        string     `gorm:"type:uuid;primaryKey;unique;not null" json:"id"`
+	UserID    string     `gorm:"type:uuid;not null;index" json:"user_id"`
+	CodeHash  string     `gorm:"type:text;not null" json:"-"`
+	UsedAt    *time.Time `gorm:"column:used_at" json:"used_at"`
+	CreatedAt time.Time  `gorm:"column:created_at; not null; autoCreateTime" json:"created_at"`
+}
+
+type TwoFAPasswordRequestModel struct {
+	Password string `json:"password" validate:"required"`
+}
+
+func (r *RecoveryCode) GetUnusedRecoveryCodes(db *gorm.DB, userID string) ([]RecoveryCode, error) {
+	var codes []RecoveryCode
+
+	err := postgresql.SelectAllFromDb(db, "", &codes, "user_id = ? AND used_at IS NULL", userID)
+	if err != nil {
+		return codes, err
+	}
+
+	return codes, nil
+}
+
+// ReplaceRecoveryCodes invalidates every existing code of the user and stores the new set.
+func (r *RecoveryCode) ReplaceRecoveryCodes(db *gorm.DB, userID string, codes []RecoveryCode) error {
+	return db.Transaction(func(tx *gorm.DB) error {
+		if err := r.DeleteRecoveryCodes(tx, userID); err != nil {
+			return err
+		}
+		return postgresql.CreateMultipleRecords(tx, &codes, len(codes))
+	})
+}
+
+func (r *RecoveryCode) DeleteRecoveryCodes(db *gorm.DB, userID string) error {
+	return db.Where("user_id = ?", userID).Delete(&RecoveryCode{}).Error
+}
+
+// MarkUsed consumes the code; it only succeeds once even under concurrent logins.
+func (r *RecoveryCode) MarkUsed(db *gorm.DB) (bool, error) {
+	now := time.Now()
+	result := db.Model(&RecoveryCode{}).
+		Where("id = ? AND used_at IS NULL", r.ID).
+		Update("used_at", now)
+	if result.Error != nil {
+		return false, result.Error
+	}
+	r.UsedAt = &now
+	return result.RowsAffected == 1, nil
+}
+
+func (k *Key) DeleteKeyByUserID(db *gorm.DB, userID string) error {
+	return db.Where("user_id = ?", userID).Delete(&Key{}).Error
+}
@@ -9,9 +9,28 @@ import (
 
 func RunAllMigrations(db *storage.Database) {
 
+	for _, d := range DroppedConstraints() {
+		if err := d.Drop(db.Postgresql); err != nil {
+			fmt.Println("error dropping constraint", d.Name, "on", d.TableName, ": ", err)
+		}
+	}
+
+	var backfills []BackfillColumn
+	for _, b := range BackfilledColumns() {
+		if b.Pending(db.Postgresql) {
+			backfills = append(backfills, b)
+		}
+	}
+
 	// verification migration
 	MigrateModels(db.Postgresql, AuthMigrationModels(), AlterColumnModels())
 
+	for _, b := range backfills {
+		if err := b.Backfill(db.Postgresql); err != nil {
+			fmt.Println("error backfilling column", b.Column, ": ", err)
+		}
+	}
+
 }
 
 func MigrateModels(db *gorm.DB, models []interface{}, AlterColums []AlterColumn) {
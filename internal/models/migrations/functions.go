@@ -25,3 +25,31 @@ func (a *AlterColumn) UpdateColumnType(db *gorm.DB) error {
 
 	return nil
 }
+
+// BackfillColumn sets values on the rows that already exist when AutoMigrate
+// first adds the column, so they are not left with its default.
+type BackfillColumn struct {
+	Model  interface{}
+	Column string
+	Values map[string]interface{}
+}
+
+// Pending reports whether the column has still to be added to an existing table.
+func (b *BackfillColumn) Pending(db *gorm.DB) bool {
+	return db.Migrator().HasTable(b.Model) && !db.Migrator().HasColumn(b.Model, b.Column)
+}
+
+func (b *BackfillColumn) Backfill(db *gorm.DB) error {
+	return db.Unscoped().Model(b.Model).Where("1 = 1").Updates(b.Values).Error
+}
+
+// DropConstraint removes a constraint the models no longer declare, which
+// AutoMigrate leaves in place.
+type DropConstraint struct {
+	TableName string
+	Name      string
+}
+
+func (d *DropConstraint) Drop(db *gorm.DB) error {
+	return db.Exec(fmt.Sprintf("ALTER TABLE IF EXISTS %s DROP CONSTRAINT IF EXISTS %s", d.TableName, d.Name)).Error
+}
@@ -1,6 +1,10 @@
 package migrations
 
-import "github.com/hngprojects/hng_boilerplate_golang_web/internal/models"
+import (
+	"gorm.io/gorm"
+
+	"github.com/hngprojects/hng_boilerplate_golang_web/internal/models"
+)
 
 func AuthMigrationModels() []interface{} {
 	return []interface{}{
@@ -8,6 +12,11 @@ func AuthMigrationModels() []interface{} {
 		models.SqueezeUser{},
 		models.Blog{},
 		models.AccessToken{},
+		models.RefreshToken{},
+		models.PersonalAccessToken{},
+		models.SocialIdentity{},
+		models.WebAuthnCredential{},
+		models.EmailChange{},
 		models.Role{},
 		models.Organisation{},
 		models.OrgRole{},
@@ -15,9 +24,11 @@ func AuthMigrationModels() []interface{} {
 		models.Profile{},
 		models.Product{},
 		models.User{},
+		models.UserOrganisation{},
 		models.Invitation{},
 		models.PasswordReset{},
 		models.MagicLink{},
+		models.EmailVerification{},
 		models.WaitlistUser{},
 		models.NewsLetter{},
 		models.JobPost{},
@@ -32,11 +43,41 @@ func AuthMigrationModels() []interface{} {
 		models.HelpCenter{},
 		models.ContactUs{},
 		models.Billing{},
+		models.Subscription{},
 		models.DataPrivacySettings{},
 		models.Key{},
+		models.RecoveryCode{},
+		models.PasswordHistory{},
+		models.AuditLog{},
+		models.OwnershipTransfer{},
+		models.OrgDomain{},
+		models.OrgJoinRequest{},
+		models.Team{},
+		models.TeamMember{},
+		models.TeamResource{},
 	} // an array of db models, example: User{}
 }
 
 func AlterColumnModels() []AlterColumn {
 	return []AlterColumn{}
 }
+
+func BackfilledColumns() []BackfillColumn {
+	return []BackfillColumn{
+		// users who signed up before email verification are treated as verified,
+		// so turning on EMAIL_VERIFICATION_REQUIRED does not lock them out
+		{
+			Model:  &models.User{},
+			Column: "is_verified",
+			Values: map[string]interface{}{"is_verified": true, "verified_at": gorm.Expr("created_at")},
+		},
+	}
+}
+
+func DroppedConstraints() []DropConstraint {
+	return []DropConstraint{
+		// org role names are unique per organisation rather than globally
+		{TableName: "org_roles", Name: "uni_org_roles_name"},
+		{TableName: "org_roles", Name: "org_roles_name_key"},
+	}
+}
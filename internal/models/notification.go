@@ -3,6 +3,7 @@ package models
 import (
 	"encoding/json"
 	"fmt"
+	"time"
 
 	"github.com/go-redis/redis/v8"
 
@@ -30,6 +31,57 @@ type SendEmailVerificationMail struct {
 	Token string `json:"token"`
 }
 
+type SendEmailVerifiedMail struct {
+	Email string `json:"email"  validate:"required"`
+}
+
+// SendEmailChangeMail is mailed to Email, either the new address with the
+// confirm token or the current address with the cancel token.
+type SendEmailChangeMail struct {
+	Email    string `json:"email"  validate:"required"`
+	Name     string `json:"name"`
+	NewEmail string `json:"new_email"`
+	Token    string `json:"token"  validate:"required"`
+}
+
+type SendAccountDeletionMail struct {
+	Email      string `json:"email"  validate:"required"`
+	DeletionAt string `json:"deletion_at"`
+}
+
+// SendOwnershipTransferMail asks the nominee to accept ownership of an
+// organisation.
+type SendOwnershipTransferMail struct {
+	Email            string `json:"email"  validate:"required"`
+	OrganisationName string `json:"organisation_name"`
+	OwnerName        string `json:"owner_name"`
+	Token            string `json:"token"  validate:"required"`
+}
+
+// SendMemberRemovedMail tells a user they were removed from an organisation.
+type SendMemberRemovedMail struct {
+	Email            string `json:"email"  validate:"required"`
+	OrganisationName string `json:"organisation_name"`
+}
+
+// SendInvitationMail invites an email address, which may not have an
+// account yet, to join an organisation.
+type SendInvitationMail struct {
+	Email            string    `json:"email"  validate:"required"`
+	OrganisationName string    `json:"organisation_name"`
+	InviterName      string    `json:"inviter_name"`
+	Token            string    `json:"token"  validate:"required"`
+	ExpiresAt        time.Time `json:"expires_at"`
+}
+
+type SendSuspiciousLoginMail struct {
+	Email       string `json:"email"  validate:"required"`
+	IPAddress   string `json:"ip_address"`
+	UserAgent   string `json:"user_agent"`
+	LockedUntil string `json:"locked_until"`
+	UnlockLink  string `json:"unlock_link"`
+}
+
 type SendResetPassword struct {
 	Email string `json:"email"`
 	Token int    `json:"token"  validate:"required"`
@@ -0,0 +1,205 @@
+package models
+
+import (
+	"errors"
+	"time"
+
+	"gorm.io/gorm"
+
+	"github.com/hngprojects/hng_boilerplate_golang_web/pkg/repository/storage/postgresql"
+)
+
+// OrgDomainTXTPrefix starts the TXT record an organisation publishes on a
+// domain to prove it controls it.
+const OrgDomainTXTPrefix = "org-domain-verification="
+
+// What happens when someone with a verified email on the domain signs up:
+// auto adds them to the organisation, request asks its admins to let them in.
+const (
+	DomainJoinAuto    = "auto"
+	DomainJoinRequest = "request"
+)
+
+const (
+	JoinRequestPending  = "pending"
+	JoinRequestApproved = "approved"
+	JoinRequestRejected = "rejected"
+)
+
+// OrgDomain is an email domain claimed by an organisation. Only verified
+// domains bring users into the organisation.
+type OrgDomain struct {
+	ID                string     `gorm:"column:id; type:uuid; not null; primaryKey; unique;" json:"id"`
+	OrganisationID    string     `gorm:"column:organisation_id; type:uuid; not null; index" json:"organisation_id"`
+	Domain            string     `gorm:"column:domain; type:varchar(255); not null; index" json:"domain"`
+	VerificationToken string     `gorm:"column:verification_token; type:varchar(64); not null" json:"-"`
+	JoinPolicy        string     `gorm:"column:join_policy; type:varchar(20); not null; default:'auto'" json:"join_policy"`
+	VerifiedAt        *time.Time `gorm:"column:verified_at" json:"verified_at"`
+	CreatedAt         time.Time  `gorm:"column:created_at; autoCreateTime" json:"created_at"`
+}
+
+// OrgJoinRequest is a user waiting to be let into an organisation whose
+// domain their email is on.
+type OrgJoinRequest struct {
+	ID             string     `gorm:"column:id; type:uuid; not null; primaryKey; unique;" json:"id"`
+	OrganisationID string     `gorm:"column:organisation_id; type:uuid; not null; uniqueIndex:idx_org_join_request" json:"organisation_id"`
+	UserID         string     `gorm:"column:user_id; type:uuid; not null; uniqueIndex:idx_org_join_request" json:"user_id"`
+	Email          string     `gorm:"column:email; type:varchar(255)" json:"email"`
+	Status         string     `gorm:"column:status; type:varchar(20); not null; default:'pending'; index" json:"status"`
+	ReviewedBy     *string    `gorm:"column:reviewed_by; type:uuid" json:"reviewed_by"`
+	ReviewedAt     *time.Time `gorm:"column:reviewed_at" json:"reviewed_at"`
+	CreatedAt      time.Time  `gorm:"column:created_at; autoCreateTime" json:"created_at"`
+}
+
+type ClaimOrgDomainRequestModel struct {
+	Domain     string `json:"domain" validate:"required,fqdn"`
+	JoinPolicy string `json:"join_policy" validate:"omitempty,oneof=auto request"`
+}
+
+type UpdateOrgDomainRequestModel struct {
+	JoinPolicy string `json:"join_policy" validate:"required,oneof=auto request"`
+}
+
+// OrgDomainResponse shows a claimed domain with the TXT record that proves
+// it, so admins can publish it.
+type OrgDomainResponse struct {
+	OrgDomain
+	TXTRecord string `json:"txt_record"`
+}
+
+func (d *OrgDomain) TXTRecord() string {
+	return OrgDomainTXTPrefix + d.VerificationToken
+}
+
+func (d *OrgDomain) Response() OrgDomainResponse {
+	return OrgDomainResponse{OrgDomain: *d, TXTRecord: d.TXTRecord()}
+}
+
+func (d *OrgDomain) CreateOrgDomain(db *gorm.DB) error {
+	return postgresql.CreateOneRecord(db, &d)
+}
+
+func (d *OrgDomain) GetOrgDomains(db *gorm.DB, orgID string) ([]OrgDomain, error) {
+	var domains []OrgDomain
+
+	err := db.Where("organisation_id = ?", orgID).Order("created_at asc").Find(&domains).Error
+	if err != nil {
+		return nil, err
+	}
+	return domains, nil
+}
+
+func (d *OrgDomain) GetOrgDomain(db *gorm.DB, orgID, domainID string) (OrgDomain, error) {
+	var domain OrgDomain
+
+	err, nerr := postgresql.SelectOneFromDb(db, &domain, "id = ? AND organisation_id = ?", domainID, orgID)
+	if nerr != nil {
+		return domain, nerr
+	}
+	if err != nil {
+		return domain, err
+	}
+	return domain, nil
+}
+
+// GetVerifiedDomain returns the verified claim on the domain, if an
+// organisation that still exists holds one.
+func (d *OrgDomain) GetVerifiedDomain(db *gorm.DB, domain string) (*OrgDomain, error) {
+	var orgDomain OrgDomain
+
+	err := db.Joins("JOIN organisations ON organisations.id = org_domains.organisation_id AND organisations.deleted_at IS NULL").
+		Where("org_domains.domain = ? AND org_domains.verified_at IS NOT NULL", domain).
+		First(&orgDomain).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &orgDomain, nil
+}
+
+// HasClaim reports whether the organisation already claimed the domain.
+func (d *OrgDomain) HasClaim(db *gorm.DB, orgID, domain string) bool {
+	return postgresql.CheckExists(db, &OrgDomain{}, "organisation_id = ? AND domain = ?", orgID, domain)
+}
+
+func (d *OrgDomain) MarkVerified(db *gorm.DB) error {
+	now := time.Now()
+
+	err := db.Model(&OrgDomain{}).Where("id = ?", d.ID).Update("verified_at", now).Error
+	if err != nil {
+		return err
+	}
+
+	d.VerifiedAt = &now
+	return nil
+}
+
+func (d *OrgDomain) UpdateJoinPolicy(db *gorm.DB, policy string) error {
+	err := db.Model(&OrgDomain{}).Where("id = ?", d.ID).Update("join_policy", policy).Error
+	if err != nil {
+		return err
+	}
+
+	d.JoinPolicy = policy
+	return nil
+}
+
+func (d *OrgDomain) DeleteOrgDomain(db *gorm.DB) error {
+	return db.Where("id = ?", d.ID).Delete(&OrgDomain{}).Error
+}
+
+// RequestToJoin files a pending join request for the user, reopening a
+// previous one that was turned down.
+func (r *OrgJoinRequest) RequestToJoin(db *gorm.DB) error {
+	return db.Where("organisation_id = ? AND user_id = ?", r.OrganisationID, r.UserID).
+		Assign(map[string]interface{}{"status": JoinRequestPending, "reviewed_by": nil, "reviewed_at": nil}).
+		FirstOrCreate(r).Error
+}
+
+func (r *OrgJoinRequest) GetOrgJoinRequests(db *gorm.DB, orgID, status string) ([]OrgJoinRequest, error) {
+	var requests []OrgJoinRequest
+
+	query := db.Where("organisation_id = ?", orgID)
+	if status != "" {
+		query = query.Where("status = ?", status)
+	}
+
+	if err := query.Order("created_at desc").Find(&requests).Error; err != nil {
+		return nil, err
+	}
+	return requests, nil
+}
+
+func (r *OrgJoinRequest) GetOrgJoinRequest(db *gorm.DB, orgID, requestID string) (OrgJoinRequest, error) {
+	var request OrgJoinRequest
+
+	err, nerr := postgresql.SelectOneFromDb(db, &request, "id = ? AND organisation_id = ?", requestID, orgID)
+	if nerr != nil {
+		return request, nerr
+	}
+	if err != nil {
+		return request, err
+	}
+	return request, nil
+}
+
+// Review records the admin's decision on the request.
+func (r *OrgJoinRequest) Review(db *gorm.DB, status, reviewerID string) error {
+	now := time.Now()
+
+	err := db.Model(&OrgJoinRequest{}).Where("id = ?", r.ID).Updates(map[string]interface{}{
+		"status":      status,
+		"reviewed_by": reviewerID,
+		"reviewed_at": now,
+	}).Error
+	if err != nil {
+		return err
+	}
+
+	r.Status = status
+	r.ReviewedBy = &reviewerID
+	r.ReviewedAt = &now
+	return nil
+}
@@ -0,0 +1,85 @@
+package models
+
+import (
+	"gorm.io/gorm"
+)
+
+// UserOrganisation is a membership row of the user_organisations join table
+// behind User.Organisations. OrgRoleID is the org role the member holds; the
+// owner needs none.
+type UserOrganisation struct {
+	UserID         string  `gorm:"column:user_id; type:uuid; primaryKey" json:"user_id"`
+	OrganisationID string  `gorm:"column:organisation_id; type:uuid; primaryKey" json:"organisation_id"`
+	OrgRoleID      *string `gorm:"column:org_role_id; type:uuid; index" json:"org_role_id"`
+}
+
+func (UserOrganisation) TableName() string {
+	return "user_organisations"
+}
+
+type AssignOrgRoleRequestModel struct {
+	RoleID string `json:"role_id" validate:"required,uuid"`
+}
+
+// What happens to the organisation products a member created when they are
+// removed: reassign hands them to another member, keep takes them out of the
+// organisation's catalog and leaves them with the departing user.
+const (
+	MemberResourcesReassign = "reassign"
+	MemberResourcesKeep     = "keep"
+)
+
+type RemoveMemberRequestModel struct {
+	Resources  string `form:"resources" json:"resources" validate:"omitempty,oneof=reassign keep"`
+	ReassignTo string `form:"reassign_to" json:"reassign_to" validate:"omitempty,uuid"`
+}
+
+func (m *UserOrganisation) GetMembership(db *gorm.DB, orgID, userID string) (UserOrganisation, error) {
+	var membership UserOrganisation
+
+	err := db.Where("organisation_id = ? AND user_id = ?", orgID, userID).First(&membership).Error
+	if err != nil {
+		return membership, err
+	}
+
+	return membership, nil
+}
+
+// AssignRole sets the member's org role; a nil roleID clears it.
+func (m *UserOrganisation) AssignRole(db *gorm.DB, roleID *string) error {
+	err := db.Model(&UserOrganisation{}).
+		Where("organisation_id = ? AND user_id = ?", m.OrganisationID, m.UserID).
+		Update("org_role_id", roleID).Error
+	if err != nil {
+		return err
+	}
+
+	m.OrgRoleID = roleID
+	return nil
+}
+
+// CountMembersWithRole counts the members holding the org role.
+func (m *UserOrganisation) CountMembersWithRole(db *gorm.DB, roleID string) (int64, error) {
+	var count int64
+
+	err := db.Model(&UserOrganisation{}).Where("org_role_id = ?", roleID).Count(&count).Error
+	return count, err
+}
+
+// RemoveMember ends the user's membership of the organisation and its teams,
+// along with any ownership transfer offered to them. It should run inside a
+// transaction.
+func (o *Organisation) RemoveMember(db *gorm.DB, userID string) error {
+	var teamMember TeamMember
+
+	err := db.Where("organisation_id = ? AND user_id = ?", o.ID, userID).Delete(&UserOrganisation{}).Error
+	if err != nil {
+		return err
+	}
+
+	if err := teamMember.RemoveFromOrgTeams(db, o.ID, userID); err != nil {
+		return err
+	}
+
+	return db.Where("organisation_id = ? AND to_user_id = ?", o.ID, userID).Delete(&OwnershipTransfer{}).Error
+}
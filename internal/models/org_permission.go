@@ -0,0 +1,124 @@
+package models
+
+import (
+	"errors"
+	"sort"
+
+	"gorm.io/gorm"
+)
+
+// Permission keys checked on organisation routes. The owner of an
+// organisation holds all of them.
+const (
+	PermEditOrganisation   = "can_edit_organisation"
+	PermDeleteOrganisation = "can_delete_organisation"
+	PermViewRoles          = "can_view_roles"
+	PermManageRoles        = "can_manage_roles"
+	PermInviteMembers      = "can_invite_members"
+	PermManageMembers      = "can_manage_members"
+	PermManageTeams        = "can_manage_teams"
+	PermViewTransactions   = "can_view_transactions"
+	PermEditTransactions   = "can_edit_transactions"
+	PermViewRefunds        = "can_view_refunds"
+	PermManageProducts     = "can_manage_products"
+)
+
+var ErrNotOrgMember = errors.New("user is not a member of this organisation")
+
+// HasPermission reports whether the permission is granted in the role's
+// permission list. The Permissions association must be loaded.
+func (r *OrgRole) HasPermission(permission string) bool {
+	return r.Permissions.PermissionList[permission]
+}
+
+// GetMemberRole returns the org role the user holds in the organisation, or
+// nil for a member without one. The owner is reported with isOwner instead,
+// and users outside the organisation get ErrNotOrgMember.
+func (o *Organisation) GetMemberRole(db *gorm.DB, orgID, userID string) (role *OrgRole, isOwner bool, err error) {
+	isOwner, err = o.IsOwnerOfOrganisation(db, userID, orgID)
+	if err != nil || isOwner {
+		return nil, isOwner, err
+	}
+
+	var membership UserOrganisation
+	membership, err = membership.GetMembership(db, orgID, userID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, false, ErrNotOrgMember
+		}
+		return nil, false, err
+	}
+	if membership.OrgRoleID == nil {
+		return nil, false, nil
+	}
+
+	var orgRole OrgRole
+	orgRole, err = orgRole.GetAOrgRole(db, orgID, *membership.OrgRoleID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, false, nil
+		}
+		return nil, false, err
+	}
+
+	return &orgRole, false, nil
+}
+
+// GetMemberPermissions returns the permissions the user holds in the
+// organisation through their own org role and the roles granted to teams they
+// are in. The owner, who holds every permission, is reported with isOwner.
+func (o *Organisation) GetMemberPermissions(db *gorm.DB, orgID, userID string) (permissions PermissionList, isOwner bool, err error) {
+	var team Team
+
+	role, isOwner, err := o.GetMemberRole(db, orgID, userID)
+	if err != nil || isOwner {
+		return nil, isOwner, err
+	}
+
+	teamRoles, err := team.GetMemberTeamRoles(db, orgID, userID)
+	if err != nil {
+		return nil, false, err
+	}
+	if role != nil {
+		teamRoles = append(teamRoles, *role)
+	}
+
+	permissions = PermissionList{}
+	for _, r := range teamRoles {
+		for key, granted := range r.Permissions.PermissionList {
+			if granted {
+				permissions[key] = true
+			}
+		}
+	}
+	return permissions, false, nil
+}
+
+// HasPermission reports whether the user may perform the action guarded by
+// permission in the organisation, through their own org role or one granted
+// to a team they are in.
+func (o *Organisation) HasPermission(db *gorm.DB, orgID, userID, permission string) (bool, error) {
+	permissions, isOwner, err := o.GetMemberPermissions(db, orgID, userID)
+	if err != nil {
+		return false, err
+	}
+	return isOwner || permissions[permission], nil
+}
+
+// MissingPermissions returns the sorted permissions granted in list that the
+// user does not hold in the organisation. It is empty for the owner.
+func (o *Organisation) MissingPermissions(db *gorm.DB, orgID, userID string, list PermissionList) ([]string, error) {
+	permissions, isOwner, err := o.GetMemberPermissions(db, orgID, userID)
+	if err != nil || isOwner {
+		return nil, err
+	}
+
+	var missing []string
+	for key, granted := range list {
+		if granted && !permissions[key] {
+			missing = append(missing, key)
+		}
+	}
+	sort.Strings(missing)
+	return missing, nil
+}
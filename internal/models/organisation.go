@@ -12,21 +12,23 @@ import (
 )
 
 type Organisation struct {
-	ID          string         `gorm:"type:uuid;primaryKey;unique;not null" json:"id"`
-	Name        string         `gorm:"type:varchar(255);not null" json:"name"`
-	Description string         `gorm:"type:text" json:"description"`
-	Email       string         `gorm:"type:varchar(255);unique" json:"email"`
-	State       string         `gorm:"type:varchar(255)" json:"state"`
-	Industry    string         `gorm:"type:varchar(255)" json:"industry"`
-	Type        string         `gorm:"type:varchar(255)" json:"type"`
-	Address     string         `gorm:"type:varchar(255)" json:"address"`
-	Country     string         `gorm:"type:varchar(255)" json:"country"`
-	OwnerID     string         `gorm:"type:uuid;" json:"owner_id"`
-	OrgRoles    []OrgRole      `gorm:"foreignKey:OrganisationID" json:"org_roles"`
-	Users       []User         `gorm:"many2many:user_organisations;foreignKey:ID;joinForeignKey:org_id;References:ID;joinReferences:user_id"`
-	CreatedAt   time.Time      `gorm:"column:created_at; not null; autoCreateTime" json:"created_at"`
-	UpdatedAt   time.Time      `gorm:"column:updated_at; null; autoUpdateTime" json:"updated_at"`
-	DeletedAt   gorm.DeletedAt `gorm:"index" json:"-"`
+	ID                string         `gorm:"type:uuid;primaryKey;unique;not null" json:"id"`
+	Name              string         `gorm:"type:varchar(255);not null" json:"name"`
+	Description       string         `gorm:"type:text" json:"description"`
+	Email             string         `gorm:"type:varchar(255);unique" json:"email"`
+	State             string         `gorm:"type:varchar(255)" json:"state"`
+	Industry          string         `gorm:"type:varchar(255)" json:"industry"`
+	Type              string         `gorm:"type:varchar(255)" json:"type"`
+	Address           string         `gorm:"type:varchar(255)" json:"address"`
+	Country           string         `gorm:"type:varchar(255)" json:"country"`
+	OwnerID           string         `gorm:"type:uuid;" json:"owner_id"`
+	DefaultRoleID     *string        `gorm:"type:uuid;" json:"default_role_id"`
+	InviteExpiryHours int            `gorm:"default:24" json:"invite_expiry_hours"`
+	OrgRoles          []OrgRole      `gorm:"foreignKey:OrganisationID" json:"org_roles"`
+	Users             []User         `gorm:"many2many:user_organisations;foreignKey:ID;joinForeignKey:org_id;References:ID;joinReferences:user_id"`
+	CreatedAt         time.Time      `gorm:"column:created_at; not null; autoCreateTime" json:"created_at"`
+	UpdatedAt         time.Time      `gorm:"column:updated_at; null; autoUpdateTime" json:"updated_at"`
+	DeletedAt         gorm.DeletedAt `gorm:"index" json:"-"`
 }
 
 type CreateOrgRequestModel struct {
@@ -41,21 +43,24 @@ type CreateOrgRequestModel struct {
 }
 
 type UpdateOrgRequestModel struct {
-	Name        string `json:"name"`
-	Description string `json:"description" `
-	Email       string `json:"email"`
-	State       string `json:"state"`
-	Industry    string `json:"industry"`
-	Type        string `json:"type"`
-	Address     string `json:"address"`
-	Country     string `json:"country"`
+	Name              string `json:"name"`
+	Description       string `json:"description" `
+	Email             string `json:"email"`
+	State             string `json:"state"`
+	Industry          string `json:"industry"`
+	Type              string `json:"type"`
+	Address           string `json:"address"`
+	Country           string `json:"country"`
+	InviteExpiryHours int    `json:"invite_expiry_hours" validate:"omitempty,min=1,max=720"`
 }
 
 type UserInOrgResponse struct {
-	ID          string `json:"id"`
-	Email       string `json:"email"`
-	PhoneNumber string `json:"phone_number"`
-	Name        string `json:"name"`
+	ID          string  `json:"id"`
+	Email       string  `json:"email"`
+	PhoneNumber string  `json:"phone_number"`
+	Name        string  `json:"name"`
+	RoleID      *string `json:"role_id"`
+	Role        *string `json:"role"`
 }
 
 type AddUserToOrgRequestModel struct {
@@ -182,9 +187,10 @@ func (o *Organisation) GetUsersInOrganisation(c *gin.Context, db *gorm.DB, orgId
 	offset := (pagination.Page - 1) * pagination.Limit
 
 	if err := db.Table("users").
-		Select("users.id, users.email, profiles.phone as phone_number , users.name").
+		Select("users.id, users.email, profiles.phone as phone_number , users.name, org_roles.id as role_id, org_roles.name as role").
 		Joins("JOIN user_organisations ON user_organisations.user_id = users.id").
 		Joins("JOIN profiles ON profiles.userid = users.id").
+		Joins("LEFT JOIN org_roles ON org_roles.id = user_organisations.org_role_id AND org_roles.deleted_at IS NULL").
 		Where("user_organisations.organisation_id = ?", orgId).
 		Offset(offset).
 		Limit(pagination.Limit).
@@ -254,3 +260,63 @@ func (o *Organisation) IsOwnerOfOrganisation(db *gorm.DB, requesterID, organisat
 
 	return count > 0, nil
 }
+
+// GetOwnedOrganisations returns the organisations owned by ownerID, split by
+// whether anyone else is still a member.
+func (o *Organisation) GetOwnedOrganisations(db *gorm.DB, ownerID string) (shared []Organisation, solo []Organisation, err error) {
+	var owned []Organisation
+
+	if err := db.Where("owner_id = ?", ownerID).Find(&owned).Error; err != nil {
+		return nil, nil, err
+	}
+
+	for _, org := range owned {
+		var others int64
+
+		err := db.Table("user_organisations").
+			Where("organisation_id = ? AND user_id <> ?", org.ID, ownerID).
+			Count(&others).Error
+		if err != nil {
+			return nil, nil, err
+		}
+
+		if others > 0 {
+			shared = append(shared, org)
+		} else {
+			solo = append(solo, org)
+		}
+	}
+
+	return shared, solo, nil
+}
+
+// SetDefaultRole sets the org role new members receive when they join; a nil
+// roleID clears it.
+func (o *Organisation) SetDefaultRole(db *gorm.DB, roleID *string) error {
+	err := db.Model(&Organisation{}).Where("id = ?", o.ID).Update("default_role_id", roleID).Error
+	if err != nil {
+		return err
+	}
+
+	o.DefaultRoleID = roleID
+	return nil
+}
+
+// InvitationTTL is how long invitations to the organisation stay open.
+func (o *Organisation) InvitationTTL() time.Duration {
+	if o.InviteExpiryHours <= 0 {
+		return 24 * time.Hour
+	}
+	return time.Duration(o.InviteExpiryHours) * time.Hour
+}
+
+// GrantDefaultRole gives a newly joined member the organisation's default
+// role, if it has one.
+func (o *Organisation) GrantDefaultRole(db *gorm.DB, userID string) error {
+	if o.DefaultRoleID == nil {
+		return nil
+	}
+
+	membership := UserOrganisation{UserID: userID, OrganisationID: o.ID}
+	return membership.AssignRole(db, o.DefaultRoleID)
+}
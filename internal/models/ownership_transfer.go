@@ -0,0 +1,114 @@
+package models
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+
+	"github.com/hngprojects/hng_boilerplate_golang_web/pkg/repository/storage/postgresql"
+)
+
+// OwnershipTransfer is a pending hand over of an organisation from its owner
+// to a member. Ownership only moves once the nominee follows the link mailed
+// to them.
+type OwnershipTransfer struct {
+	ID             string    `gorm:"column:id; type:uuid; not null; primaryKey; unique;" json:"id"`
+	OrganisationID string    `gorm:"column:organisation_id; type:uuid; not null; uniqueIndex" json:"organisation_id"`
+	FromUserID     string    `gorm:"column:from_user_id; type:uuid; not null" json:"from_user_id"`
+	ToUserID       string    `gorm:"column:to_user_id; type:uuid; not null; index" json:"to_user_id"`
+	TokenHash      string    `gorm:"column:token_hash; type:varchar(64); not null; uniqueIndex" json:"-"`
+	ExpiresAt      time.Time `gorm:"column:expires_at; not null" json:"expires_at"`
+	CreatedAt      time.Time `gorm:"column:created_at; autoCreateTime" json:"created_at"`
+}
+
+type TransferOwnershipRequestModel struct {
+	UserID string `json:"user_id" validate:"required,uuid"`
+}
+
+type OwnershipTransferTokenRequestModel struct {
+	Token string `json:"token" validate:"required"`
+}
+
+// ReplaceOwnershipTransfer stores the transfer as the only pending one of the
+// organisation.
+func (t *OwnershipTransfer) ReplaceOwnershipTransfer(db *gorm.DB) error {
+	return db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("organisation_id = ?", t.OrganisationID).Delete(&OwnershipTransfer{}).Error; err != nil {
+			return err
+		}
+		return postgresql.CreateOneRecord(tx, &t)
+	})
+}
+
+func (t *OwnershipTransfer) GetByTokenHash(db *gorm.DB, tokenHash string) (OwnershipTransfer, error) {
+	var transfer OwnershipTransfer
+	if err := db.Where("token_hash = ? AND expires_at > ?", tokenHash, time.Now()).First(&transfer).Error; err != nil {
+		return transfer, err
+	}
+	return transfer, nil
+}
+
+// DeleteOrgTransfers discards any pending transfer of the organisation and
+// reports whether there was one.
+func (t *OwnershipTransfer) DeleteOrgTransfers(db *gorm.DB, orgID string) (bool, error) {
+	result := db.Where("organisation_id = ?", orgID).Delete(&OwnershipTransfer{})
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected > 0, nil
+}
+
+// DeleteOwnershipTransfer removes the transfer. It reports false if it was
+// already gone, so a link can only be used once.
+func (t *OwnershipTransfer) DeleteOwnershipTransfer(db *gorm.DB) (bool, error) {
+	result := db.Where("id = ?", t.ID).Delete(&OwnershipTransfer{})
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected == 1, nil
+}
+
+// TransferOwnership makes newOwnerID the owner of the organisation. The new
+// owner takes the owner role and the previous owner stays on as an admin. It
+// should run inside a transaction.
+func (o *Organisation) TransferOwnership(db *gorm.DB, newOwnerID string) error {
+	previousOwnerID := o.OwnerID
+
+	ownerRole, err := o.GetTemplateRole(db, OrgRoleOwner)
+	if err != nil {
+		return err
+	}
+
+	adminRole, err := o.GetTemplateRole(db, OrgRoleAdmin)
+	if err != nil {
+		return err
+	}
+
+	err = db.Model(&Organisation{}).Where("id = ?", o.ID).Update("owner_id", newOwnerID).Error
+	if err != nil {
+		return err
+	}
+
+	// organisations created before memberships were tracked may not list
+	// their owner as a member
+	previousOwner := UserOrganisation{UserID: previousOwnerID, OrganisationID: o.ID, OrgRoleID: &adminRole.ID}
+	err = db.Where("organisation_id = ? AND user_id = ?", o.ID, previousOwnerID).
+		Assign(UserOrganisation{OrgRoleID: &adminRole.ID}).
+		FirstOrCreate(&previousOwner).Error
+	if err != nil {
+		return err
+	}
+
+	newOwner := UserOrganisation{UserID: newOwnerID, OrganisationID: o.ID}
+	if err := newOwner.AssignRole(db, &ownerRole.ID); err != nil {
+		return err
+	}
+
+	var transfer OwnershipTransfer
+	if _, err := transfer.DeleteOrgTransfers(db, o.ID); err != nil {
+		return err
+	}
+
+	o.OwnerID = newOwnerID
+	return nil
+}
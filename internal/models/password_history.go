@@ -0,0 +1,42 @@
+package models
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+
+	"github.com/hngprojects/hng_boilerplate_golang_web/pkg/repository/storage/postgresql"
+)
+
+// PasswordHistory keeps the hashes of the passwords a user has set, newest
+// first, so the password policy can refuse reusing them.
+type PasswordHistory struct {
+	ID        string    `gorm:"type:uuid;primaryKey;unique;not null" json:"id"`
+	UserID    string    `gorm:"type:uuid;not null;index" json:"user_id"`
+	Password  string    `gorm:"type:text;not null" json:"-"`
+	CreatedAt time.Time `gorm:"column:created_at; not null; autoCreateTime" json:"created_at"`
+}
+
+func (p *PasswordHistory) CreatePasswordHistory(db *gorm.DB) error {
+	return postgresql.CreateOneRecord(db, p)
+}
+
+// GetRecentPasswords returns the last limit password hashes of the user.
+func (p *PasswordHistory) GetRecentPasswords(db *gorm.DB, userID string, limit int) ([]PasswordHistory, error) {
+	var history []PasswordHistory
+
+	err := db.Where("user_id = ?", userID).Order("created_at desc").Limit(limit).Find(&history).Error
+	if err != nil {
+		return history, err
+	}
+
+	return history, nil
+}
+
+// PrunePasswordHistory deletes all but the newest keep entries of the user.
+func (p *PasswordHistory) PrunePasswordHistory(db *gorm.DB, userID string, keep int) error {
+	recent := db.Model(&PasswordHistory{}).Select("id").
+		Where("user_id = ?", userID).Order("created_at desc").Limit(keep)
+
+	return db.Where("user_id = ? AND id NOT IN (?)", userID, recent).Delete(&PasswordHistory{}).Error
+}
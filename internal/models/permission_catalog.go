@@ -0,0 +1,203 @@
+package models
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+
+	"gorm.io/gorm"
+
+	"github.com/hngprojects/hng_boilerplate_golang_web/utility"
+)
+
+type PermissionDefinition struct {
+	Key         string `json:"key"`
+	Description string `json:"description"`
+}
+
+type PermissionCategory struct {
+	Name        string                 `json:"name"`
+	Permissions []PermissionDefinition `json:"permissions"`
+}
+
+// PermissionCatalog lists every permission key an org role may hold. Role and
+// permission writes are checked against it so misspelt keys are rejected.
+var PermissionCatalog = []PermissionCategory{
+	{
+		Name: "Organisation",
+		Permissions: []PermissionDefinition{
+			{Key: PermEditOrganisation, Description: "Edit the organisation's details"},
+			{Key: PermDeleteOrganisation, Description: "Delete the organisation"},
+		},
+	},
+	{
+		Name: "Members",
+		Permissions: []PermissionDefinition{
+			{Key: PermInviteMembers, Description: "Invite people to the organisation"},
+			{Key: PermManageMembers, Description: "Change the roles of members and remove them"},
+			{Key: PermManageTeams, Description: "Create, edit and delete teams and manage their members"},
+		},
+	},
+	{
+		Name: "Roles",
+		Permissions: []PermissionDefinition{
+			{Key: PermViewRoles, Description: "View the organisation's roles"},
+			{Key: PermManageRoles, Description: "Create, edit and delete roles and their permissions"},
+		},
+	},
+	{
+		Name: "Products",
+		Permissions: []PermissionDefinition{
+			{Key: PermManageProducts, Description: "Create, edit and delete the organisation's products"},
+		},
+	},
+	{
+		Name: "Transactions",
+		Permissions: []PermissionDefinition{
+			{Key: PermViewTransactions, Description: "View transactions"},
+			{Key: PermEditTransactions, Description: "Edit transactions"},
+		},
+	},
+	{
+		Name: "Refunds",
+		Permissions: []PermissionDefinition{
+			{Key: PermViewRefunds, Description: "View refunds"},
+		},
+	},
+}
+
+// IsKnownPermission reports whether key is in the catalog.
+func IsKnownPermission(key string) bool {
+	for _, category := range PermissionCatalog {
+		for _, permission := range category.Permissions {
+			if permission.Key == key {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// UnknownKeys returns the sorted keys of the list that are not in the catalog.
+func (p PermissionList) UnknownKeys() []string {
+	var unknown []string
+	for key := range p {
+		if !IsKnownPermission(key) {
+			unknown = append(unknown, key)
+		}
+	}
+	sort.Strings(unknown)
+	return unknown
+}
+
+// Names of the built-in roles every organisation starts with.
+const (
+	OrgRoleOwner  = "owner"
+	OrgRoleAdmin  = "admin"
+	OrgRoleMember = "member"
+	OrgRoleViewer = "viewer"
+)
+
+type OrgRoleTemplate struct {
+	Name        string
+	Description string
+	Permissions []string
+}
+
+// OrgRoleTemplates are created for every new organisation. The member role
+// becomes the default role for people who join.
+var OrgRoleTemplates = []OrgRoleTemplate{
+	{
+		Name:        OrgRoleOwner,
+		Description: "Owner of the organisation",
+		Permissions: []string{
+			PermEditOrganisation, PermDeleteOrganisation, PermInviteMembers, PermManageMembers, PermManageTeams,
+			PermViewRoles, PermManageRoles, PermManageProducts, PermViewTransactions, PermEditTransactions, PermViewRefunds,
+		},
+	},
+	{
+		Name:        OrgRoleAdmin,
+		Description: "Manages the organisation and its members",
+		Permissions: []string{
+			PermEditOrganisation, PermInviteMembers, PermManageMembers, PermManageTeams,
+			PermViewRoles, PermManageRoles, PermManageProducts, PermViewTransactions, PermEditTransactions, PermViewRefunds,
+		},
+	},
+	{
+		Name:        OrgRoleMember,
+		Description: "Works within the organisation",
+		Permissions: []string{PermViewRoles, PermManageProducts, PermViewTransactions, PermEditTransactions, PermViewRefunds},
+	},
+	{
+		Name:        OrgRoleViewer,
+		Description: "Read only access to the organisation",
+		Permissions: []string{PermViewRoles, PermViewTransactions, PermViewRefunds},
+	},
+}
+
+// CreateRoleTemplates creates the built-in roles for the organisation and
+// returns them by name.
+func (o *Organisation) CreateRoleTemplates(db *gorm.DB) (map[string]OrgRole, error) {
+	roles := make(map[string]OrgRole, len(OrgRoleTemplates))
+
+	for _, template := range OrgRoleTemplates {
+		role := template.newRole(o.ID)
+		if err := role.CreateOrgRole(db); err != nil {
+			return nil, err
+		}
+
+		roles[template.Name] = role
+	}
+
+	return roles, nil
+}
+
+// IsTemplate reports whether the role is one of the built-in roles, which are
+// looked up by name and so cannot be renamed or deleted.
+func (r *OrgRole) IsTemplate() bool {
+	for _, template := range OrgRoleTemplates {
+		if template.Name == r.Name {
+			return true
+		}
+	}
+	return false
+}
+
+// GetTemplateRole returns the organisation's built-in role with the given
+// name, creating it for organisations made before role templates existed.
+func (o *Organisation) GetTemplateRole(db *gorm.DB, name string) (OrgRole, error) {
+	var role OrgRole
+
+	err := db.Where("organisation_id = ? AND name = ?", o.ID, name).First(&role).Error
+	if err == nil || !errors.Is(err, gorm.ErrRecordNotFound) {
+		return role, err
+	}
+
+	for _, template := range OrgRoleTemplates {
+		if template.Name == name {
+			role = template.newRole(o.ID)
+			return role, role.CreateOrgRole(db)
+		}
+	}
+
+	return role, fmt.Errorf("no role template named %v", name)
+}
+
+func (t OrgRoleTemplate) newRole(orgID string) OrgRole {
+	permissions := PermissionList{}
+	for _, key := range t.Permissions {
+		permissions[key] = true
+	}
+
+	return OrgRole{
+		ID:             utility.GenerateUUID(),
+		Name:           t.Name,
+		Description:    t.Description,
+		OrganisationID: orgID,
+		Permissions: Permission{
+			ID:             utility.GenerateUUID(),
+			Category:       t.Name,
+			PermissionList: permissions,
+		},
+	}
+}
@@ -0,0 +1,113 @@
+package models
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/lib/pq"
+	"gorm.io/gorm"
+
+	"github.com/hngprojects/hng_boilerplate_golang_web/pkg/repository/storage/postgresql"
+)
+
+// PersonalAccessTokenPrefix marks a bearer token as a personal access token
+// rather than a session JWT.
+const PersonalAccessTokenPrefix = "pat_"
+
+// Scopes a personal access token can be granted. Read only allows safe
+// requests, write allows every request the owner could make.
+const (
+	PersonalAccessTokenScopeRead  = "read"
+	PersonalAccessTokenScopeWrite = "write"
+)
+
+// PersonalAccessToken is a long lived credential for scripts and integrations.
+// Only the sha256 digest of the token is stored.
+type PersonalAccessToken struct {
+	ID         string         `gorm:"column:id; type:uuid; not null; primaryKey; unique;" json:"id"`
+	UserID     string         `gorm:"column:user_id; type:uuid; not null; index" json:"user_id"`
+	Name       string         `gorm:"column:name; type:varchar(100); not null" json:"name"`
+	TokenHash  string         `gorm:"column:token_hash; type:varchar(64); not null; uniqueIndex" json:"-"`
+	Hint       string         `gorm:"column:hint; type:varchar(20)" json:"hint"`
+	Scopes     pq.StringArray `gorm:"column:scopes; type:text[]" json:"scopes"`
+	ExpiresAt  time.Time      `gorm:"column:expires_at; not null" json:"expires_at"`
+	LastUsedAt *time.Time     `gorm:"column:last_used_at" json:"last_used_at"`
+	RevokedAt  *time.Time     `gorm:"column:revoked_at" json:"revoked_at"`
+	CreatedAt  time.Time      `gorm:"column:created_at; autoCreateTime" json:"created_at"`
+}
+
+type CreatePersonalAccessTokenRequestModel struct {
+	Name          string   `json:"name" validate:"required,max=100"`
+	Scopes        []string `json:"scopes" validate:"required,min=1,dive,oneof=read write"`
+	ExpiresInDays int      `json:"expires_in_days" validate:"omitempty,min=1,max=365"`
+}
+
+func (p *PersonalAccessToken) CreatePersonalAccessToken(db *gorm.DB) error {
+	err := postgresql.CreateOneRecord(db, &p)
+	if err != nil {
+		return fmt.Errorf("personal access token creation failed: %v", err.Error())
+	}
+	return nil
+}
+
+func (p *PersonalAccessToken) GetByTokenHash(db *gorm.DB, tokenHash string) (int, error) {
+	err, nilErr := postgresql.SelectOneFromDb(db, &p, "token_hash = ?", tokenHash)
+	if nilErr != nil {
+		return http.StatusUnauthorized, nilErr
+	}
+
+	if err != nil {
+		return http.StatusInternalServerError, err
+	}
+	return http.StatusOK, nil
+}
+
+func (p *PersonalAccessToken) GetUserTokens(db *gorm.DB, userID string) ([]PersonalAccessToken, error) {
+	var tokens []PersonalAccessToken
+
+	err := postgresql.SelectAllFromDbOrderBy(db, "created_at", "desc", &tokens, "user_id = ? AND revoked_at IS NULL", userID)
+	if err != nil {
+		return tokens, err
+	}
+
+	return tokens, nil
+}
+
+// IsActive reports whether the token is neither revoked nor expired.
+func (p *PersonalAccessToken) IsActive() bool {
+	return p.RevokedAt == nil && time.Now().Before(p.ExpiresAt)
+}
+
+// HasScope reports whether the token was granted the scope. Write implies read.
+func (p *PersonalAccessToken) HasScope(scope string) bool {
+	for _, granted := range p.Scopes {
+		if granted == scope || granted == PersonalAccessTokenScopeWrite {
+			return true
+		}
+	}
+	return false
+}
+
+// Revoke revokes the token of the user. It reports false if there was no
+// active token with that id.
+func (p *PersonalAccessToken) Revoke(db *gorm.DB, userID, tokenID string) (bool, error) {
+	result := db.Model(&PersonalAccessToken{}).
+		Where("id = ? AND user_id = ? AND revoked_at IS NULL", tokenID, userID).
+		Update("revoked_at", time.Now())
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected == 1, nil
+}
+
+// TouchLastUsed records that the token was used, at most once per interval.
+func (p *PersonalAccessToken) TouchLastUsed(db *gorm.DB, interval time.Duration) error {
+	now := time.Now()
+	if p.LastUsedAt != nil && now.Sub(*p.LastUsedAt) < interval {
+		return nil
+	}
+
+	p.LastUsedAt = &now
+	return db.Model(&PersonalAccessToken{}).Where("id = ?", p.ID).UpdateColumn("last_used_at", now).Error
+}
@@ -1,6 +1,7 @@
 package models
 
 import (
+	"errors"
 	"time"
 
 	"gorm.io/gorm"
@@ -9,15 +10,17 @@ import (
 )
 
 type Product struct {
-	ID          string     `gorm:"type:uuid;primaryKey" json:"product_id"`
-	Name        string     `gorm:"column:name; type:varchar(255); not null" json:"name"`
-	Price       float64    `gorm:"column:price; type:decimal(10,2);null" json:"price"`
-	Description string     `gorm:"column:description; type:text" json:"description"`
-	OwnerID     string     `gorm:"type:uuid;" json:"owner_id"`
-	Image       string     `gorm:"column:image; type:text" json:"image"`
-	Category    []Category `gorm:"many2many:product_categories;;constraint:OnUpdate:CASCADE,OnDelete:SET NULL;" json:"category"`
-	CreatedAt   time.Time  `gorm:"column:created_at; not null; autoCreateTime" json:"created_at"`
-	UpdatedAt   time.Time  `gorm:"column:updated_at; null; autoUpdateTime" json:"updated_at"`
+	ID          string  `gorm:"type:uuid;primaryKey" json:"product_id"`
+	Name        string  `gorm:"column:name; type:varchar(255); not null" json:"name"`
+	Price       float64 `gorm:"column:price; type:decimal(10,2);null" json:"price"`
+	Description string  `gorm:"column:description; type:text" json:"description"`
+	OwnerID     string  `gorm:"type:uuid;" json:"owner_id"`
+	// OrganisationID is set for products in an organisation's catalog.
+	OrganisationID *string    `gorm:"type:uuid;index" json:"organisation_id"`
+	Image          string     `gorm:"column:image; type:text" json:"image"`
+	Category       []Category `gorm:"many2many:product_categories;;constraint:OnUpdate:CASCADE,OnDelete:SET NULL;" json:"category"`
+	CreatedAt      time.Time  `gorm:"column:created_at; not null; autoCreateTime" json:"created_at"`
+	UpdatedAt      time.Time  `gorm:"column:updated_at; null; autoUpdateTime" json:"updated_at"`
 }
 
 type CreateProductRequestModel struct {
@@ -26,6 +29,8 @@ type CreateProductRequestModel struct {
 	Description string  `json:"description" validate:"required"`
 	Price       float64 `json:"price" validate:"required"`
 	Category    string  `json:"category" validate:"required"`
+	// OrganisationID adds the product to an organisation's catalog.
+	OrganisationID string `json:"organisation_id" validate:"omitempty,uuid"`
 }
 
 type DeleteProductRequestModel struct {
@@ -79,3 +84,100 @@ func (p *Product) GetProduct(db *gorm.DB, id string) (Product, error) {
 
 	return product, nil
 }
+
+// Product listings can be narrowed to the user's own products or to the
+// catalogs of their organisations.
+const (
+	ProductScopeMine         = "mine"
+	ProductScopeOrganisation = "organisation"
+)
+
+var ErrInvalidProductScope = errors.New("scope must be one of mine or organisation")
+
+// VisibleProducts limits a product query to the catalog the user may see:
+// the products they own and those of the organisations they belong to. scope
+// narrows it to one of the two and orgID to a single organisation.
+func VisibleProducts(userID, scope, orgID string) func(db *gorm.DB) *gorm.DB {
+	return func(db *gorm.DB) *gorm.DB {
+		conditions := db.Session(&gorm.Session{NewDB: true})
+		mine := conditions.Where("products.owner_id = ?", userID)
+		organisations := conditions.Where(
+			"(products.organisation_id IN (SELECT organisation_id FROM user_organisations WHERE user_id = ?) OR products.organisation_id IN (SELECT id FROM organisations WHERE owner_id = ?))",
+			userID, userID,
+		)
+
+		switch scope {
+		case ProductScopeMine:
+			db = db.Where(mine)
+		case ProductScopeOrganisation:
+			db = db.Where(organisations)
+		default:
+			db = db.Where(mine.Or(organisations))
+		}
+
+		if orgID != "" {
+			db = db.Where("products.organisation_id = ?", orgID)
+		}
+
+		return db
+	}
+}
+
+// CanBeManagedBy reports whether the user may change the product: its owner
+// can, and so can members of its organisation granted PermManageProducts or
+// in a team the product is shared with.
+func (p *Product) CanBeManagedBy(db *gorm.DB, userID string) (bool, error) {
+	var share TeamResource
+
+	if p.OwnerID == userID {
+		return true, nil
+	}
+	if p.OrganisationID == nil {
+		return false, nil
+	}
+	if share.IsSharedWithUser(db, TeamResourceProduct, p.ID, userID) {
+		return true, nil
+	}
+
+	var org Organisation
+	allowed, err := org.HasPermission(db, *p.OrganisationID, userID, PermManageProducts)
+	if errors.Is(err, ErrNotOrgMember) {
+		return false, nil
+	}
+	return allowed, err
+}
+
+// IsVisibleTo reports whether the product is in the catalog the user may see.
+func (p *Product) IsVisibleTo(db *gorm.DB, userID string) (bool, error) {
+	if p.OwnerID == userID {
+		return true, nil
+	}
+	if p.OrganisationID == nil {
+		return false, nil
+	}
+
+	var org Organisation
+	_, _, err := org.GetMemberRole(db, *p.OrganisationID, userID)
+	if errors.Is(err, ErrNotOrgMember) {
+		return false, nil
+	}
+	return err == nil, err
+}
+
+// ReassignOrgProducts hands the products a user created in the organisation's
+// catalog to another user and reports how many moved.
+func (p *Product) ReassignOrgProducts(db *gorm.DB, orgID, fromUserID, toUserID string) (int64, error) {
+	result := db.Model(&Product{}).
+		Where("organisation_id = ? AND owner_id = ?", orgID, fromUserID).
+		Update("owner_id", toUserID)
+	return result.RowsAffected, result.Error
+}
+
+// ReleaseOrgProducts takes the products a user created out of the
+// organisation's catalog, leaving them as the user's own.
+func (p *Product) ReleaseOrgProducts(db *gorm.DB, orgID, userID string) (int64, error) {
+	result := db.Model(&Product{}).
+		Where("organisation_id = ? AND owner_id = ?", orgID, userID).
+		Update("organisation_id", nil)
+	return result.RowsAffected, result.Error
+}
@@ -41,9 +41,9 @@ type Role struct {
 
 type OrgRole struct {
 	ID             string         `gorm:"type:uuid;primaryKey;unique;not null" json:"id"`
-	Name           string         `gorm:"unique;not null;type:varchar(20)" json:"name" validate:"required"`
+	Name           string         `gorm:"not null;type:varchar(20);uniqueIndex:idx_org_roles_org_name" json:"name" validate:"required"`
 	Description    string         `gorm:"not null" json:"description" validate:"required"`
-	OrganisationID string         `gorm:"not null" json:"-"`
+	OrganisationID string         `gorm:"not null;uniqueIndex:idx_org_roles_org_name" json:"-"`
 	Permissions    Permission     `gorm:"foreignKey:RoleID;constraint:OnDelete:CASCADE;" json:"permissions"`
 	CreatedAt      time.Time      `gorm:"column:created_at; not null; autoCreateTime" json:"-"`
 	UpdatedAt      time.Time      `gorm:"column:updated_at; null; autoUpdateTime" json:"-"`
@@ -83,8 +83,9 @@ func (r *OrgRole) CreateOrgRole(db *gorm.DB) error {
 	return nil
 }
 
+// DeleteOrgRole removes the role for good, so its name can be used again.
 func (r *OrgRole) DeleteOrgRole(db *gorm.DB) error {
-	err := postgresql.DeleteRecordFromDb(db, &r)
+	err := postgresql.HardDeleteRecordFromDb(db, &r)
 	if err != nil {
 		return err
 	}
@@ -96,8 +97,21 @@ func (r *OrgRole) UpdateOrgRole(db *gorm.DB) error {
 	return err
 }
 
+// UpdateOrgPermissions replaces the permissions of the role, creating its
+// permission record on first use.
 func (rp *Permission) UpdateOrgPermissions(db *gorm.DB) error {
-	_, err := postgresql.SaveAllFields(db, &rp)
+	var existing Permission
+
+	err := db.Where("role_id = ?", rp.RoleID).First(&existing).Error
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		return err
+	}
+	if err == nil {
+		rp.ID = existing.ID
+		rp.CreatedAt = existing.CreatedAt
+	}
+
+	_, err = postgresql.SaveAllFields(db, &rp)
 	return err
 }
 
@@ -0,0 +1,69 @@
+package models
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+
+	"gorm.io/gorm"
+
+	"github.com/hngprojects/hng_boilerplate_golang_web/pkg/repository/storage/postgresql"
+)
+
+// SocialIdentity links an account at a sign in provider to a user. A user can
+// link several providers, but a provider account belongs to one user.
+type SocialIdentity struct {
+	ID          string     `gorm:"column:id; type:uuid; not null; primaryKey; unique;" json:"id"`
+	UserID      string     `gorm:"column:user_id; type:uuid; not null; index" json:"user_id"`
+	Provider    string     `gorm:"column:provider; type:varchar(100); not null; uniqueIndex:idx_social_identity_subject" json:"provider"`
+	Subject     string     `gorm:"column:subject; type:varchar(255); not null; uniqueIndex:idx_social_identity_subject" json:"-"`
+	Email       string     `gorm:"column:email; type:varchar(255)" json:"email"`
+	LastLoginAt *time.Time `gorm:"column:last_login_at" json:"last_login_at"`
+	CreatedAt   time.Time  `gorm:"column:created_at; autoCreateTime" json:"created_at"`
+}
+
+type OAuthCallbackRequestModel struct {
+	Code  string `json:"code" validate:"required"`
+	State string `json:"state" validate:"required"`
+}
+
+func (s *SocialIdentity) CreateSocialIdentity(db *gorm.DB) error {
+	err := postgresql.CreateOneRecord(db, &s)
+	if err != nil {
+		return fmt.Errorf("social identity creation failed: %v", err.Error())
+	}
+	return nil
+}
+
+func (s *SocialIdentity) GetByProviderSubject(db *gorm.DB, provider, subject string) (int, error) {
+	err, nilErr := postgresql.SelectOneFromDb(db, &s, "provider = ? AND subject = ?", provider, subject)
+	if nilErr != nil {
+		return http.StatusNotFound, nilErr
+	}
+
+	if err != nil {
+		return http.StatusInternalServerError, err
+	}
+	return http.StatusOK, nil
+}
+
+func (s *SocialIdentity) GetUserIdentities(db *gorm.DB, userID string) ([]SocialIdentity, error) {
+	var identities []SocialIdentity
+
+	err := postgresql.SelectAllFromDbOrderBy(db, "created_at", "asc", &identities, "user_id = ?", userID)
+	if err != nil {
+		return identities, err
+	}
+
+	return identities, nil
+}
+
+func (s *SocialIdentity) TouchLastLogin(db *gorm.DB) error {
+	now := time.Now()
+	s.LastLoginAt = &now
+	return db.Model(&SocialIdentity{}).Where("id = ?", s.ID).UpdateColumn("last_login_at", now).Error
+}
+
+func (s *SocialIdentity) DeleteSocialIdentity(db *gorm.DB) error {
+	return db.Where("id = ?", s.ID).Delete(&SocialIdentity{}).Error
+}
@@ -0,0 +1,260 @@
+package models
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+
+	"github.com/hngprojects/hng_boilerplate_golang_web/pkg/repository/storage/postgresql"
+)
+
+// Kinds of resources a team can be given. Sharing an organisation product
+// lets the team's members manage it; templates are listed with the team.
+const (
+	TeamResourceProduct  = "product"
+	TeamResourceTemplate = "template"
+)
+
+// Team is a group of an organisation's members. OrgRoleID grants the team's
+// members that org role's permissions on top of their own.
+type Team struct {
+	ID             string    `gorm:"column:id; type:uuid; not null; primaryKey; unique;" json:"id"`
+	OrganisationID string    `gorm:"column:organisation_id; type:uuid; not null; uniqueIndex:idx_teams_org_name" json:"organisation_id"`
+	Name           string    `gorm:"column:name; type:varchar(100); not null; uniqueIndex:idx_teams_org_name" json:"name"`
+	Description    string    `gorm:"column:description; type:text" json:"description"`
+	OrgRoleID      *string   `gorm:"column:org_role_id; type:uuid; index" json:"org_role_id"`
+	CreatedAt      time.Time `gorm:"column:created_at; autoCreateTime" json:"created_at"`
+	UpdatedAt      time.Time `gorm:"column:updated_at; autoUpdateTime" json:"updated_at"`
+}
+
+// TeamMember is a member of the organisation in one of its teams. Leads may
+// manage the team's members and shared resources.
+type TeamMember struct {
+	TeamID    string    `gorm:"column:team_id; type:uuid; primaryKey" json:"team_id"`
+	UserID    string    `gorm:"column:user_id; type:uuid; primaryKey" json:"user_id"`
+	IsLead    bool      `gorm:"column:is_lead; not null; default:false" json:"is_lead"`
+	CreatedAt time.Time `gorm:"column:created_at; autoCreateTime" json:"created_at"`
+}
+
+// TeamResource is a resource shared with a team.
+type TeamResource struct {
+	ID           string    `gorm:"column:id; type:uuid; not null; primaryKey; unique;" json:"id"`
+	TeamID       string    `gorm:"column:team_id; type:uuid; not null; uniqueIndex:idx_team_resource" json:"team_id"`
+	ResourceType string    `gorm:"column:resource_type; type:varchar(20); not null; uniqueIndex:idx_team_resource" json:"resource_type"`
+	ResourceID   string    `gorm:"column:resource_id; type:uuid; not null; uniqueIndex:idx_team_resource" json:"resource_id"`
+	SharedBy     string    `gorm:"column:shared_by; type:uuid" json:"shared_by"`
+	CreatedAt    time.Time `gorm:"column:created_at; autoCreateTime" json:"created_at"`
+}
+
+type CreateTeamRequestModel struct {
+	Name        string `json:"name" validate:"required,max=100"`
+	Description string `json:"description"`
+}
+
+type UpdateTeamRequestModel struct {
+	Name        *string `json:"name" validate:"omitempty,min=1,max=100"`
+	Description *string `json:"description"`
+}
+
+type AddTeamMemberRequestModel struct {
+	UserID string `json:"user_id" validate:"required,uuid"`
+	IsLead bool   `json:"is_lead"`
+}
+
+type UpdateTeamMemberRequestModel struct {
+	IsLead bool `json:"is_lead"`
+}
+
+type ShareTeamResourceRequestModel struct {
+	ResourceType string `json:"resource_type" validate:"required,oneof=product template"`
+	ResourceID   string `json:"resource_id" validate:"required,uuid"`
+}
+
+type TeamMemberResponse struct {
+	ID     string `json:"id"`
+	Name   string `json:"name"`
+	Email  string `json:"email"`
+	IsLead bool   `json:"is_lead"`
+}
+
+func (t *Team) CreateTeam(db *gorm.DB) error {
+	return postgresql.CreateOneRecord(db, &t)
+}
+
+func (t *Team) GetTeams(db *gorm.DB, orgID string) ([]Team, error) {
+	var teams []Team
+
+	err := db.Where("organisation_id = ?", orgID).Order("name asc").Find(&teams).Error
+	if err != nil {
+		return nil, err
+	}
+	return teams, nil
+}
+
+func (t *Team) GetTeam(db *gorm.DB, orgID, teamID string) (Team, error) {
+	var team Team
+
+	err, nerr := postgresql.SelectOneFromDb(db, &team, "id = ? AND organisation_id = ?", teamID, orgID)
+	if nerr != nil {
+		return team, nerr
+	}
+	if err != nil {
+		return team, err
+	}
+	return team, nil
+}
+
+// NameTaken reports whether another team of the organisation has the name.
+func (t *Team) NameTaken(db *gorm.DB, orgID, name string) bool {
+	var count int64
+
+	query := db.Model(&Team{}).Where("organisation_id = ? AND LOWER(name) = LOWER(?)", orgID, name)
+	if t.ID != "" {
+		query = query.Where("id <> ?", t.ID)
+	}
+	query.Count(&count)
+	return count > 0
+}
+
+func (t *Team) UpdateTeam(db *gorm.DB, updates map[string]interface{}) error {
+	return db.Model(&Team{}).Where("id = ?", t.ID).Updates(updates).Error
+}
+
+// SetRole grants the team an org role; a nil roleID clears it.
+func (t *Team) SetRole(db *gorm.DB, roleID *string) error {
+	err := db.Model(&Team{}).Where("id = ?", t.ID).Update("org_role_id", roleID).Error
+	if err != nil {
+		return err
+	}
+
+	t.OrgRoleID = roleID
+	return nil
+}
+
+// DeleteTeam removes the team with its memberships and shares. It should run
+// inside a transaction.
+func (t *Team) DeleteTeam(db *gorm.DB) error {
+	if err := db.Where("team_id = ?", t.ID).Delete(&TeamMember{}).Error; err != nil {
+		return err
+	}
+	if err := db.Where("team_id = ?", t.ID).Delete(&TeamResource{}).Error; err != nil {
+		return err
+	}
+	return db.Where("id = ?", t.ID).Delete(&Team{}).Error
+}
+
+// CountTeamsWithRole counts the teams granted the org role.
+func (t *Team) CountTeamsWithRole(db *gorm.DB, roleID string) (int64, error) {
+	var count int64
+
+	err := db.Model(&Team{}).Where("org_role_id = ?", roleID).Count(&count).Error
+	return count, err
+}
+
+// GetMemberTeamRoles returns the org roles granted to the teams the user is
+// in within the organisation, with their permissions loaded.
+func (t *Team) GetMemberTeamRoles(db *gorm.DB, orgID, userID string) ([]OrgRole, error) {
+	var roles []OrgRole
+
+	err := db.Preload("Permissions").
+		Joins("JOIN teams ON teams.org_role_id = org_roles.id").
+		Joins("JOIN team_members ON team_members.team_id = teams.id").
+		Where("teams.organisation_id = ? AND team_members.user_id = ?", orgID, userID).
+		Find(&roles).Error
+	if err != nil {
+		return nil, err
+	}
+	return roles, nil
+}
+
+func (m *TeamMember) GetTeamMember(db *gorm.DB, teamID, userID string) (TeamMember, error) {
+	var member TeamMember
+
+	err := db.Where("team_id = ? AND user_id = ?", teamID, userID).First(&member).Error
+	if err != nil {
+		return member, err
+	}
+	return member, nil
+}
+
+func (m *TeamMember) GetTeamMembers(db *gorm.DB, teamID string) ([]TeamMemberResponse, error) {
+	members := []TeamMemberResponse{}
+
+	err := db.Table("users").
+		Select("users.id, users.name, users.email, team_members.is_lead").
+		Joins("JOIN team_members ON team_members.user_id = users.id").
+		Where("team_members.team_id = ?", teamID).
+		Order("team_members.is_lead desc, users.name asc").
+		Find(&members).Error
+	if err != nil {
+		return nil, err
+	}
+	return members, nil
+}
+
+func (m *TeamMember) AddTeamMember(db *gorm.DB) error {
+	return postgresql.CreateOneRecord(db, &m)
+}
+
+func (m *TeamMember) SetLead(db *gorm.DB, isLead bool) error {
+	err := db.Model(&TeamMember{}).Where("team_id = ? AND user_id = ?", m.TeamID, m.UserID).Update("is_lead", isLead).Error
+	if err != nil {
+		return err
+	}
+
+	m.IsLead = isLead
+	return nil
+}
+
+func (m *TeamMember) RemoveTeamMember(db *gorm.DB) error {
+	return db.Where("team_id = ? AND user_id = ?", m.TeamID, m.UserID).Delete(&TeamMember{}).Error
+}
+
+// RemoveFromOrgTeams takes the user out of every team of the organisation.
+func (m *TeamMember) RemoveFromOrgTeams(db *gorm.DB, orgID, userID string) error {
+	return db.Where("user_id = ? AND team_id IN (SELECT id FROM teams WHERE organisation_id = ?)", userID, orgID).
+		Delete(&TeamMember{}).Error
+}
+
+func (r *TeamResource) ShareResource(db *gorm.DB) error {
+	return postgresql.CreateOneRecord(db, &r)
+}
+
+func (r *TeamResource) IsShared(db *gorm.DB, teamID, resourceType, resourceID string) bool {
+	return postgresql.CheckExists(db, &TeamResource{}, "team_id = ? AND resource_type = ? AND resource_id = ?", teamID, resourceType, resourceID)
+}
+
+func (r *TeamResource) GetTeamResources(db *gorm.DB, teamID string) ([]TeamResource, error) {
+	resources := []TeamResource{}
+
+	err := db.Where("team_id = ?", teamID).Order("created_at desc").Find(&resources).Error
+	if err != nil {
+		return nil, err
+	}
+	return resources, nil
+}
+
+func (r *TeamResource) GetTeamResource(db *gorm.DB, teamID, shareID string) (TeamResource, error) {
+	var resource TeamResource
+
+	err, nerr := postgresql.SelectOneFromDb(db, &resource, "id = ? AND team_id = ?", shareID, teamID)
+	if nerr != nil {
+		return resource, nerr
+	}
+	if err != nil {
+		return resource, err
+	}
+	return resource, nil
+}
+
+func (r *TeamResource) UnshareResource(db *gorm.DB) error {
+	return db.Where("id = ?", r.ID).Delete(&TeamResource{}).Error
+}
+
+// IsSharedWithUser reports whether the resource is shared with a team the
+// user is in.
+func (r *TeamResource) IsSharedWithUser(db *gorm.DB, resourceType, resourceID, userID string) bool {
+	return postgresql.CheckExists(db, &TeamResource{},
+		"resource_type = ? AND resource_id = ? AND team_id IN (SELECT team_id FROM team_members WHERE user_id = ?)",
+		resourceType, resourceID, userID)
+}
@@ -14,6 +14,8 @@ type User struct {
 	Name          string                     `gorm:"column:name; type:varchar(255)" json:"name"`
 	Email         string                     `gorm:"column:email; type:varchar(255)" json:"email"`
 	Password      string                     `gorm:"column:password; type:text; not null" json:"-"`
+	IsVerified    bool                       `gorm:"column:is_verified; type:bool; default:false; not null" json:"is_verified"`
+	VerifiedAt    *time.Time                 `gorm:"column:verified_at" json:"verified_at"`
 	Profile       Profile                    `gorm:"foreignKey:Userid;constraint:OnUpdate:CASCADE,OnDelete:SET NULL;" json:"profile"`
 	Key           Key                        `gorm:"foreignKey:UserID;constraint:OnUpdate:CASCADE,OnDelete:SET NULL;" json:"key"`
 	Region        UserRegionTimezoneLanguage `gorm:"foreignKey:UserID;constraint:OnUpdate:CASCADE,OnDelete:SET NULL;" json:"region"`
@@ -24,6 +26,7 @@ type User struct {
 	CreatedAt     time.Time                  `gorm:"column:created_at; not null; autoCreateTime" json:"created_at"`
 	UpdatedAt     time.Time                  `gorm:"column:updated_at; null; autoUpdateTime" json:"updated_at"`
 	Role          int                        `gorm:"foreignKey:RoleID;references:ID;constraint:OnUpdate:CASCADE,OnDelete:SET NULL;" json:"role"`
+	DeletionAt    *time.Time                 `gorm:"column:deletion_at; index" json:"deletion_at"`
 	DeletedAt     gorm.DeletedAt             `gorm:"index" json:"-"`
 }
 
@@ -34,6 +37,7 @@ type CreateUserRequestModel struct {
 	LastName    string `json:"last_name" validate:"required"`
 	UserName    string `json:"username"`
 	PhoneNumber string `json:"phone_number"`
+	InviteToken string `json:"invite_token"`
 }
 
 type UpdateUserRequestModel struct {
@@ -114,6 +118,22 @@ func (u *User) Update(db *gorm.DB) error {
 	return err
 }
 
+// MarkEmailVerified flags the user's email address as verified.
+func (u *User) MarkEmailVerified(db *gorm.DB) error {
+	now := time.Now()
+	err := db.Model(&User{}).Where("id = ?", u.ID).Updates(map[string]interface{}{
+		"is_verified": true,
+		"verified_at": now,
+	}).Error
+	if err != nil {
+		return err
+	}
+
+	u.IsVerified = true
+	u.VerifiedAt = &now
+	return nil
+}
+
 func (u *User) CheckUserIsAdmin(db *gorm.DB) bool {
 	return u.Role == int(RoleIdentity.SuperAdmin)
 }
@@ -165,6 +185,39 @@ func (u *User) GetUserByIDsAdmin(db *gorm.DB, userID, requesterID string) (User,
 	return user, nil
 }
 
+// ScheduleDeletion marks the account to be purged at the given time.
+func (u *User) ScheduleDeletion(db *gorm.DB, at time.Time) error {
+	if err := db.Model(&User{}).Where("id = ?", u.ID).Update("deletion_at", at).Error; err != nil {
+		return err
+	}
+
+	u.DeletionAt = &at
+	return nil
+}
+
+// CancelDeletion clears a scheduled deletion. It reports false if none was scheduled.
+func (u *User) CancelDeletion(db *gorm.DB) (bool, error) {
+	result := db.Model(&User{}).Where("id = ? AND deletion_at IS NOT NULL", u.ID).Update("deletion_at", nil)
+	if result.Error != nil {
+		return false, result.Error
+	}
+
+	u.DeletionAt = nil
+	return result.RowsAffected == 1, nil
+}
+
+// GetUsersDueForDeletion returns the accounts whose grace period has ended.
+func (u *User) GetUsersDueForDeletion(db *gorm.DB) ([]User, error) {
+	var users []User
+
+	err := db.Where("deletion_at IS NOT NULL AND deletion_at <= ?", time.Now()).Find(&users).Error
+	if err != nil {
+		return users, err
+	}
+
+	return users, nil
+}
+
 func (u *User) DeleteAUser(db *gorm.DB) error {
 
 	err := postgresql.DeleteRecordFromDb(db, u)
@@ -0,0 +1,137 @@
+package models
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/lib/pq"
+	"gorm.io/gorm"
+
+	"github.com/hngprojects/hng_boilerplate_golang_web/pkg/repository/storage/postgresql"
+)
+
+// WebAuthnCredential is a passkey or security key registered by a user. The
+// sign count reported by the authenticator is kept to detect cloned keys.
+type WebAuthnCredential struct {
+	ID              string         `gorm:"column:id; type:uuid; not null; primaryKey; unique;" json:"id"`
+	UserID          string         `gorm:"column:user_id; type:uuid; not null; index" json:"user_id"`
+	Name            string         `gorm:"column:name; type:varchar(100); not null" json:"name"`
+	CredentialID    []byte         `gorm:"column:credential_id; type:bytea; not null; uniqueIndex" json:"-"`
+	PublicKey       []byte         `gorm:"column:public_key; type:bytea; not null" json:"-"`
+	AttestationType string         `gorm:"column:attestation_type; type:varchar(50)" json:"-"`
+	AAGUID          []byte         `gorm:"column:aaguid; type:bytea" json:"-"`
+	Transports      pq.StringArray `gorm:"column:transports; type:text[]" json:"transports"`
+	SignCount       int64          `gorm:"column:sign_count; not null; default:0" json:"sign_count"`
+	BackupEligible  bool           `gorm:"column:backup_eligible; not null; default:false" json:"backup_eligible"`
+	BackupState     bool           `gorm:"column:backup_state; not null; default:false" json:"backup_state"`
+	LastUsedAt      *time.Time     `gorm:"column:last_used_at" json:"last_used_at"`
+	CreatedAt       time.Time      `gorm:"column:created_at; autoCreateTime" json:"created_at"`
+}
+
+type BeginWebAuthnLoginRequestModel struct {
+	Email string `json:"email" validate:"omitempty,email"`
+}
+
+type FinishWebAuthnRegistrationRequestModel struct {
+	SessionID    string          `json:"session_id" validate:"required"`
+	Name         string          `json:"name" validate:"required,max=100"`
+	SecondFactor bool            `json:"second_factor"`
+	Credential   json.RawMessage `json:"credential" validate:"required"`
+}
+
+type FinishWebAuthnLoginRequestModel struct {
+	SessionID  string          `json:"session_id" validate:"required"`
+	Credential json.RawMessage `json:"credential" validate:"required"`
+}
+
+type BeginWebAuthnTwoFARequestModel struct {
+	ChallengeToken string `json:"challenge_token" validate:"required"`
+}
+
+type FinishWebAuthnTwoFARequestModel struct {
+	ChallengeToken string          `json:"challenge_token" validate:"required"`
+	SessionID      string          `json:"session_id" validate:"required"`
+	Credential     json.RawMessage `json:"credential" validate:"required"`
+}
+
+type RenameWebAuthnCredentialRequestModel struct {
+	Name string `json:"name" validate:"required,max=100"`
+}
+
+func (w *WebAuthnCredential) CreateWebAuthnCredential(db *gorm.DB) error {
+	err := postgresql.CreateOneRecord(db, &w)
+	if err != nil {
+		return fmt.Errorf("webauthn credential creation failed: %v", err.Error())
+	}
+	return nil
+}
+
+func (w *WebAuthnCredential) GetUserCredentials(db *gorm.DB, userID string) ([]WebAuthnCredential, error) {
+	var credentials []WebAuthnCredential
+
+	err := postgresql.SelectAllFromDbOrderBy(db, "created_at", "desc", &credentials, "user_id = ?", userID)
+	if err != nil {
+		return credentials, err
+	}
+
+	return credentials, nil
+}
+
+func (w *WebAuthnCredential) GetByCredentialID(db *gorm.DB, credentialID []byte) (int, error) {
+	err, nilErr := postgresql.SelectOneFromDb(db, &w, "credential_id = ?", credentialID)
+	if nilErr != nil {
+		return http.StatusNotFound, nilErr
+	}
+
+	if err != nil {
+		return http.StatusInternalServerError, err
+	}
+	return http.StatusOK, nil
+}
+
+// HasCredentials reports whether the user registered at least one credential.
+func (w *WebAuthnCredential) HasCredentials(db *gorm.DB, userID string) (bool, error) {
+	var count int64
+
+	err := db.Model(&WebAuthnCredential{}).Where("user_id = ?", userID).Count(&count).Error
+	if err != nil {
+		return false, err
+	}
+
+	return count > 0, nil
+}
+
+// RecordLogin stores the sign count and backup state the authenticator
+// reported with a successful assertion.
+func (w *WebAuthnCredential) RecordLogin(db *gorm.DB, signCount uint32, backupState bool) error {
+	now := time.Now()
+
+	w.SignCount, w.BackupState, w.LastUsedAt = int64(signCount), backupState, &now
+	return db.Model(&WebAuthnCredential{}).Where("id = ?", w.ID).Updates(map[string]interface{}{
+		"sign_count":   w.SignCount,
+		"backup_state": backupState,
+		"last_used_at": now,
+	}).Error
+}
+
+// Rename renames the credential of the user. It reports false if the user has
+// no credential with that id.
+func (w *WebAuthnCredential) Rename(db *gorm.DB, userID, id, name string) (bool, error) {
+	result := db.Model(&WebAuthnCredential{}).Where("id = ? AND user_id = ?", id, userID).Update("name", name)
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected == 1, nil
+}
+
+// Delete removes the credential of the user. It reports false if the user has
+// no credential with that id.
+func (w *WebAuthnCredential) Delete(db *gorm.DB, userID, id string) (bool, error) {
+	result := db.Where("id = ? AND user_id = ?", id, userID).Delete(&WebAuthnCredential{})
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected == 1, nil
+}
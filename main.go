@@ -11,6 +11,7 @@ import (
 	"github.com/hngprojects/hng_boilerplate_golang_web/internal/config"
 	"github.com/hngprojects/hng_boilerplate_golang_web/internal/models/migrations"
 	"github.com/hngprojects/hng_boilerplate_golang_web/internal/models/seed"
+	"github.com/hngprojects/hng_boilerplate_golang_web/pkg/middleware"
 	"github.com/hngprojects/hng_boilerplate_golang_web/pkg/repository/storage"
 	"github.com/hngprojects/hng_boilerplate_golang_web/pkg/repository/storage/postgresql"
 	"github.com/hngprojects/hng_boilerplate_golang_web/pkg/repository/storage/redis"
@@ -25,9 +26,14 @@ func main() {
 	redis.ConnectToRedis(logger, configuration.Redis)
 	validatorRef := validator.New()
 
+	if err := middleware.LoadSigningKeys(configuration.Server); err != nil {
+		log.Fatal(err)
+	}
+
 	db := storage.Connection()
 
 	cronjobs.StartCronJob(request.ExternalRequest{Logger: logger}, *storage.DB, "send-notifications")
+	cronjobs.StartCronJob(request.ExternalRequest{Logger: logger}, *storage.DB, "purge-deleted-users")
 
 	if configuration.Database.Migrate {
 		migrations.RunAllMigrations(db)
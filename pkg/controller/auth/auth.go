@@ -9,6 +9,7 @@ import (
 
 	"github.com/hngprojects/hng_boilerplate_golang_web/external/request"
 	"github.com/hngprojects/hng_boilerplate_golang_web/internal/models"
+	"github.com/hngprojects/hng_boilerplate_golang_web/pkg/middleware"
 	"github.com/hngprojects/hng_boilerplate_golang_web/pkg/repository/storage"
 	"github.com/hngprojects/hng_boilerplate_golang_web/services/auth"
 	"github.com/hngprojects/hng_boilerplate_golang_web/utility"
@@ -45,10 +46,10 @@ func (base *Controller) CreateUser(c *gin.Context) {
 		return
 	}
 
-	respData, code, err := auth.CreateUser(reqData, base.Db.Postgresql)
+	respData, code, err := auth.CreateUser(reqData, base.Db.Postgresql, sessionClient(c))
 	if err != nil {
-		rd := utility.BuildErrorResponse(http.StatusBadRequest, "error", err.Error(), err, nil)
-		c.JSON(http.StatusBadRequest, rd)
+		code, rd := base.passwordErrorResponse(http.StatusBadRequest, err)
+		c.JSON(code, rd)
 		return
 	}
 
@@ -81,10 +82,10 @@ func (base *Controller) CreateAdmin(c *gin.Context) {
 		return
 	}
 
-	respData, code, err := auth.CreateAdmin(reqData, base.Db.Postgresql)
+	respData, code, err := auth.CreateAdmin(reqData, base.Db.Postgresql, sessionClient(c))
 	if err != nil {
-		rd := utility.BuildErrorResponse(http.StatusBadRequest, "error", err.Error(), err, nil)
-		c.JSON(http.StatusBadRequest, rd)
+		code, rd := base.passwordErrorResponse(http.StatusBadRequest, err)
+		c.JSON(code, rd)
 		return
 	}
 
@@ -110,10 +111,17 @@ func (base *Controller) LoginUser(c *gin.Context) {
 		return
 	}
 
-	respData, code, err := auth.LoginUser(req, base.Db.Postgresql)
+	respData, code, err := auth.LoginUser(req, base.Db.Postgresql, sessionClient(c))
 	if err != nil {
 		rd := utility.BuildErrorResponse(code, "error", err.Error(), err, nil)
-		c.JSON(http.StatusBadRequest, rd)
+		c.JSON(code, rd)
+		return
+	}
+
+	if _, ok := respData["two_factor_required"]; ok {
+		base.Logger.Info("2fa verification required")
+		rd := utility.BuildSuccessResponse(http.StatusOK, "2fa verification required", respData)
+		c.JSON(http.StatusOK, rd)
 		return
 	}
 
@@ -141,6 +149,12 @@ func (base *Controller) LogoutUser(c *gin.Context) {
 		return
 	}
 
+	// logging out of an impersonation ends it, which is audited
+	if _, impersonated := middleware.ImpersonatorID(userClaims); impersonated {
+		base.StopImpersonation(c)
+		return
+	}
+
 	respData, code, err := auth.LogoutUser(access_uuid, owner_id, base.Db.Postgresql)
 	if err != nil {
 		rd := utility.BuildErrorResponse(code, "error", err.Error(), err, nil)
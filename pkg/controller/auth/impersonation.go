@@ -0,0 +1,72 @@
+package auth
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/hngprojects/hng_boilerplate_golang_web/internal/models"
+	"github.com/hngprojects/hng_boilerplate_golang_web/pkg/middleware"
+	"github.com/hngprojects/hng_boilerplate_golang_web/services/auth"
+	"github.com/hngprojects/hng_boilerplate_golang_web/utility"
+)
+
+func (base *Controller) StartImpersonation(c *gin.Context) {
+	var req models.ImpersonateUserRequestModel
+
+	err := c.ShouldBind(&req)
+	if err != nil {
+		rd := utility.BuildErrorResponse(http.StatusBadRequest, "error", "Failed to parse request body", err, nil)
+		c.JSON(http.StatusBadRequest, rd)
+		return
+	}
+
+	err = base.Validator.Struct(&req)
+	if err != nil {
+		rd := utility.BuildErrorResponse(http.StatusUnprocessableEntity, "error", "Validation failed", utility.ValidationResponse(err, base.Validator), nil)
+		c.JSON(http.StatusUnprocessableEntity, rd)
+		return
+	}
+
+	adminID, errResp := middleware.GetIdFromToken(c)
+	if adminID == "" {
+		c.JSON(http.StatusUnauthorized, errResp)
+		return
+	}
+
+	userID := c.Param("user_id")
+
+	respData, code, err := auth.StartImpersonation(adminID, userID, req, base.Db.Postgresql, sessionClient(c))
+	if err != nil {
+		rd := utility.BuildErrorResponse(code, "error", err.Error(), err, nil)
+		c.JSON(code, rd)
+		return
+	}
+
+	base.Logger.Info(fmt.Sprintf("superadmin %v started impersonating user %v", adminID, userID))
+
+	rd := utility.BuildSuccessResponse(http.StatusCreated, "impersonation started successfully", respData)
+	c.JSON(http.StatusCreated, rd)
+}
+
+func (base *Controller) StopImpersonation(c *gin.Context) {
+	userID, accessUuid, ok := sessionClaims(c)
+	if !ok {
+		rd := utility.BuildErrorResponse(http.StatusBadRequest, "error", "unable to get user claims", nil, nil)
+		c.JSON(http.StatusBadRequest, rd)
+		return
+	}
+
+	respData, code, err := auth.StopImpersonation(accessUuid, userID, base.Db.Postgresql, sessionClient(c))
+	if err != nil {
+		rd := utility.BuildErrorResponse(code, "error", err.Error(), err, nil)
+		c.JSON(code, rd)
+		return
+	}
+
+	base.Logger.Info(fmt.Sprintf("impersonation of user %v stopped", userID))
+
+	rd := utility.BuildSuccessResponse(http.StatusOK, "impersonation stopped successfully", respData)
+	c.JSON(http.StatusOK, rd)
+}
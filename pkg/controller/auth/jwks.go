@@ -0,0 +1,24 @@
+package auth
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/hngprojects/hng_boilerplate_golang_web/pkg/middleware"
+	"github.com/hngprojects/hng_boilerplate_golang_web/utility"
+)
+
+// JWKS publishes the token verification keys in the standard JWK Set format,
+// so it is not wrapped in the usual response envelope.
+func (base *Controller) JWKS(c *gin.Context) {
+	jwks, err := middleware.GetJWKS()
+	if err != nil {
+		rd := utility.BuildErrorResponse(http.StatusInternalServerError, "error", "unable to load signing keys", err, nil)
+		c.JSON(http.StatusInternalServerError, rd)
+		return
+	}
+
+	c.Header("Cache-Control", "public, max-age=300")
+	c.JSON(http.StatusOK, jwks)
+}
@@ -0,0 +1,55 @@
+package auth
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/hngprojects/hng_boilerplate_golang_web/internal/models"
+	"github.com/hngprojects/hng_boilerplate_golang_web/services/auth"
+	"github.com/hngprojects/hng_boilerplate_golang_web/utility"
+)
+
+func (base *Controller) UnlockAccount(c *gin.Context) {
+	var req models.UnlockAccountRequestModel
+
+	err := c.ShouldBind(&req)
+	if err != nil {
+		rd := utility.BuildErrorResponse(http.StatusBadRequest, "error", "Failed to parse request body", err, nil)
+		c.JSON(http.StatusBadRequest, rd)
+		return
+	}
+
+	err = base.Validator.Struct(&req)
+	if err != nil {
+		rd := utility.BuildErrorResponse(http.StatusUnprocessableEntity, "error", "Validation failed", utility.ValidationResponse(err, base.Validator), nil)
+		c.JSON(http.StatusUnprocessableEntity, rd)
+		return
+	}
+
+	respData, code, err := auth.UnlockAccount(req, base.Db.Postgresql)
+	if err != nil {
+		rd := utility.BuildErrorResponse(code, "error", err.Error(), err, nil)
+		c.JSON(code, rd)
+		return
+	}
+
+	base.Logger.Info("account unlocked successfully")
+
+	rd := utility.BuildSuccessResponse(http.StatusOK, "account unlocked successfully", respData)
+	c.JSON(http.StatusOK, rd)
+}
+
+func (base *Controller) UnlockUserAccount(c *gin.Context) {
+	respData, code, err := auth.UnlockUserAccount(c.Param("user_id"), base.Db.Postgresql)
+	if err != nil {
+		rd := utility.BuildErrorResponse(code, "error", err.Error(), err, nil)
+		c.JSON(code, rd)
+		return
+	}
+
+	base.Logger.Info("user account unlocked successfully")
+
+	rd := utility.BuildSuccessResponse(http.StatusOK, "user account unlocked successfully", respData)
+	c.JSON(http.StatusOK, rd)
+}
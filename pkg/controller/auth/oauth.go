@@ -0,0 +1,147 @@
+package auth
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/hngprojects/hng_boilerplate_golang_web/internal/models"
+	"github.com/hngprojects/hng_boilerplate_golang_web/pkg/middleware"
+	"github.com/hngprojects/hng_boilerplate_golang_web/services/auth"
+	"github.com/hngprojects/hng_boilerplate_golang_web/utility"
+)
+
+func (base *Controller) GetOAuthProviders(c *gin.Context) {
+	respData := auth.GetOAuthProviders()
+
+	base.Logger.Info("oauth providers retrieved successfully")
+
+	rd := utility.BuildSuccessResponse(http.StatusOK, "oauth providers retrieved successfully", respData)
+	c.JSON(http.StatusOK, rd)
+}
+
+func (base *Controller) AuthorizeOAuth(c *gin.Context) {
+	respData, code, err := auth.BeginOAuth(c.Param("provider"), "")
+	if err != nil {
+		rd := utility.BuildErrorResponse(code, "error", err.Error(), err, nil)
+		c.JSON(code, rd)
+		return
+	}
+
+	base.Logger.Info("oauth authorization url created successfully")
+
+	rd := utility.BuildSuccessResponse(http.StatusOK, "oauth authorization url created successfully", respData)
+	c.JSON(http.StatusOK, rd)
+}
+
+func (base *Controller) LinkOAuth(c *gin.Context) {
+	userID, errResp := middleware.GetIdFromToken(c)
+	if userID == "" {
+		c.JSON(http.StatusUnauthorized, errResp)
+		return
+	}
+
+	respData, code, err := auth.BeginOAuth(c.Param("provider"), userID)
+	if err != nil {
+		rd := utility.BuildErrorResponse(code, "error", err.Error(), err, nil)
+		c.JSON(code, rd)
+		return
+	}
+
+	base.Logger.Info("oauth authorization url created successfully")
+
+	rd := utility.BuildSuccessResponse(http.StatusOK, "oauth authorization url created successfully", respData)
+	c.JSON(http.StatusOK, rd)
+}
+
+func (base *Controller) OAuthCallback(c *gin.Context) {
+	base.completeOAuth(c, "")
+}
+
+func (base *Controller) LinkOAuthCallback(c *gin.Context) {
+	userID, errResp := middleware.GetIdFromToken(c)
+	if userID == "" {
+		c.JSON(http.StatusUnauthorized, errResp)
+		return
+	}
+
+	base.completeOAuth(c, userID)
+}
+
+func (base *Controller) completeOAuth(c *gin.Context, linkUserID string) {
+	var req models.OAuthCallbackRequestModel
+
+	err := c.ShouldBind(&req)
+	if err != nil {
+		rd := utility.BuildErrorResponse(http.StatusBadRequest, "error", "Failed to parse request body", err, nil)
+		c.JSON(http.StatusBadRequest, rd)
+		return
+	}
+
+	err = base.Validator.Struct(&req)
+	if err != nil {
+		rd := utility.BuildErrorResponse(http.StatusUnprocessableEntity, "error", "Validation failed", utility.ValidationResponse(err, base.Validator), nil)
+		c.JSON(http.StatusUnprocessableEntity, rd)
+		return
+	}
+
+	respData, code, err := auth.CompleteOAuth(req, linkUserID, base.Db.Postgresql, sessionClient(c))
+	if err != nil {
+		rd := utility.BuildErrorResponse(code, "error", err.Error(), err, nil)
+		c.JSON(code, rd)
+		return
+	}
+
+	message := "user login successfully"
+	if _, linked := respData["identity"]; linked {
+		message = "identity linked successfully"
+	}
+	if _, ok := respData["two_factor_required"]; ok {
+		message = "2fa verification required"
+	}
+
+	base.Logger.Info(message)
+
+	rd := utility.BuildSuccessResponse(code, message, respData)
+	c.JSON(code, rd)
+}
+
+func (base *Controller) GetSocialIdentities(c *gin.Context) {
+	userID, errResp := middleware.GetIdFromToken(c)
+	if userID == "" {
+		c.JSON(http.StatusUnauthorized, errResp)
+		return
+	}
+
+	respData, code, err := auth.GetSocialIdentities(userID, base.Db.Postgresql)
+	if err != nil {
+		rd := utility.BuildErrorResponse(code, "error", err.Error(), err, nil)
+		c.JSON(code, rd)
+		return
+	}
+
+	base.Logger.Info("identities retrieved successfully")
+
+	rd := utility.BuildSuccessResponse(http.StatusOK, "identities retrieved successfully", respData)
+	c.JSON(http.StatusOK, rd)
+}
+
+func (base *Controller) UnlinkSocialIdentity(c *gin.Context) {
+	userID, errResp := middleware.GetIdFromToken(c)
+	if userID == "" {
+		c.JSON(http.StatusUnauthorized, errResp)
+		return
+	}
+
+	respData, code, err := auth.UnlinkSocialIdentity(userID, c.Param("identity_id"), base.Db.Postgresql)
+	if err != nil {
+		rd := utility.BuildErrorResponse(code, "error", err.Error(), err, nil)
+		c.JSON(code, rd)
+		return
+	}
+
+	base.Logger.Info("identity unlinked successfully")
+
+	rd := utility.BuildSuccessResponse(http.StatusOK, "identity unlinked successfully", respData)
+	c.JSON(http.StatusOK, rd)
+}
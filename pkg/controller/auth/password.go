@@ -1,6 +1,7 @@
 package auth
 
 import (
+	"errors"
 	"net/http"
 
 	"github.com/gin-gonic/gin"
@@ -10,6 +11,18 @@ import (
 	"github.com/hngprojects/hng_boilerplate_golang_web/utility"
 )
 
+// passwordErrorResponse reports a password rejected by the password policy like
+// any other validation failure.
+func (base *Controller) passwordErrorResponse(code int, err error) (int, utility.Response) {
+	var policyErr *utility.FieldValidationError
+	if errors.As(err, &policyErr) {
+		return http.StatusUnprocessableEntity, utility.BuildErrorResponse(http.StatusUnprocessableEntity, "error", "Validation failed",
+			utility.ValidationResponse(err, base.Validator), nil)
+	}
+
+	return code, utility.BuildErrorResponse(code, "error", err.Error(), err, nil)
+}
+
 func (base *Controller) ChangePassword(c *gin.Context) {
 	var (
 		req = models.ChangePasswordRequestModel{}
@@ -32,7 +45,7 @@ func (base *Controller) ChangePassword(c *gin.Context) {
 
 	respData, code, err := service.UpdateUserPassword(c, req, base.Db.Postgresql)
 	if err != nil {
-		rd := utility.BuildErrorResponse(code, "error", err.Error(), err, nil)
+		code, rd := base.passwordErrorResponse(code, err)
 		c.JSON(code, rd)
 		return
 	}
@@ -100,7 +113,7 @@ func (base *Controller) VerifyResetToken(c *gin.Context) {
 
 	respData, code, err := service.VerifyPasswordResetToken(req, base.Db.Postgresql)
 	if err != nil {
-		rd := utility.BuildErrorResponse(code, "error", err.Error(), err, nil)
+		code, rd := base.passwordErrorResponse(code, err)
 		c.JSON(code, rd)
 		return
 	}
@@ -0,0 +1,88 @@
+package auth
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/hngprojects/hng_boilerplate_golang_web/internal/models"
+	"github.com/hngprojects/hng_boilerplate_golang_web/pkg/middleware"
+	"github.com/hngprojects/hng_boilerplate_golang_web/services/auth"
+	"github.com/hngprojects/hng_boilerplate_golang_web/utility"
+)
+
+func (base *Controller) CreatePersonalAccessToken(c *gin.Context) {
+	var req models.CreatePersonalAccessTokenRequestModel
+
+	err := c.ShouldBind(&req)
+	if err != nil {
+		rd := utility.BuildErrorResponse(http.StatusBadRequest, "error", "Failed to parse request body", err, nil)
+		c.JSON(http.StatusBadRequest, rd)
+		return
+	}
+
+	err = base.Validator.Struct(&req)
+	if err != nil {
+		rd := utility.BuildErrorResponse(http.StatusUnprocessableEntity, "error", "Validation failed", utility.ValidationResponse(err, base.Validator), nil)
+		c.JSON(http.StatusUnprocessableEntity, rd)
+		return
+	}
+
+	userID, errResp := middleware.GetIdFromToken(c)
+	if userID == "" {
+		c.JSON(http.StatusUnauthorized, errResp)
+		return
+	}
+
+	respData, code, err := auth.CreatePersonalAccessToken(userID, req, base.Db.Postgresql)
+	if err != nil {
+		rd := utility.BuildErrorResponse(code, "error", err.Error(), err, nil)
+		c.JSON(code, rd)
+		return
+	}
+
+	base.Logger.Info("personal access token created successfully")
+
+	rd := utility.BuildSuccessResponse(http.StatusCreated, "personal access token created successfully", respData)
+	c.JSON(http.StatusCreated, rd)
+}
+
+func (base *Controller) GetPersonalAccessTokens(c *gin.Context) {
+	userID, errResp := middleware.GetIdFromToken(c)
+	if userID == "" {
+		c.JSON(http.StatusUnauthorized, errResp)
+		return
+	}
+
+	respData, code, err := auth.GetPersonalAccessTokens(userID, base.Db.Postgresql)
+	if err != nil {
+		rd := utility.BuildErrorResponse(code, "error", err.Error(), err, nil)
+		c.JSON(code, rd)
+		return
+	}
+
+	base.Logger.Info("personal access tokens retrieved successfully")
+
+	rd := utility.BuildSuccessResponse(http.StatusOK, "personal access tokens retrieved successfully", respData)
+	c.JSON(http.StatusOK, rd)
+}
+
+func (base *Controller) RevokePersonalAccessToken(c *gin.Context) {
+	userID, errResp := middleware.GetIdFromToken(c)
+	if userID == "" {
+		c.JSON(http.StatusUnauthorized, errResp)
+		return
+	}
+
+	respData, code, err := auth.RevokePersonalAccessToken(userID, c.Param("token_id"), base.Db.Postgresql)
+	if err != nil {
+		rd := utility.BuildErrorResponse(code, "error", err.Error(), err, nil)
+		c.JSON(code, rd)
+		return
+	}
+
+	base.Logger.Info("personal access token revoked successfully")
+
+	rd := utility.BuildSuccessResponse(http.StatusOK, "personal access token revoked successfully", respData)
+	c.JSON(http.StatusOK, rd)
+}
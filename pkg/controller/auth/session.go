@@ -0,0 +1,149 @@
+package auth
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang-jwt/jwt"
+
+	"github.com/hngprojects/hng_boilerplate_golang_web/internal/models"
+	"github.com/hngprojects/hng_boilerplate_golang_web/services/auth"
+	"github.com/hngprojects/hng_boilerplate_golang_web/utility"
+)
+
+// sessionClient captures the device details stored with a new session.
+func sessionClient(c *gin.Context) models.SessionClient {
+	return models.SessionClient{
+		UserAgent: c.Request.UserAgent(),
+		IPAddress: c.ClientIP(),
+	}
+}
+
+// sessionClaims returns the user id and session id of the authorized request.
+func sessionClaims(c *gin.Context) (string, string, bool) {
+	claims, exists := c.Get("userClaims")
+	if !exists {
+		return "", "", false
+	}
+
+	userClaims := claims.(jwt.MapClaims)
+
+	userID, ok := userClaims["user_id"].(string)
+	if !ok {
+		return "", "", false
+	}
+
+	accessUuid, ok := userClaims["access_uuid"].(string)
+	if !ok {
+		return "", "", false
+	}
+
+	return userID, accessUuid, true
+}
+
+func (base *Controller) GetSessions(c *gin.Context) {
+	userID, accessUuid, ok := sessionClaims(c)
+	if !ok {
+		rd := utility.BuildErrorResponse(http.StatusBadRequest, "error", "unable to get user claims", nil, nil)
+		c.JSON(http.StatusBadRequest, rd)
+		return
+	}
+
+	respData, code, err := auth.GetUserSessions(userID, accessUuid, base.Db.Postgresql)
+	if err != nil {
+		rd := utility.BuildErrorResponse(code, "error", err.Error(), err, nil)
+		c.JSON(code, rd)
+		return
+	}
+
+	base.Logger.Info("sessions retrieved successfully")
+
+	rd := utility.BuildSuccessResponse(http.StatusOK, "sessions retrieved successfully", respData)
+	c.JSON(http.StatusOK, rd)
+}
+
+func (base *Controller) RevokeSession(c *gin.Context) {
+	userID, _, ok := sessionClaims(c)
+	if !ok {
+		rd := utility.BuildErrorResponse(http.StatusBadRequest, "error", "unable to get user claims", nil, nil)
+		c.JSON(http.StatusBadRequest, rd)
+		return
+	}
+
+	respData, code, err := auth.RevokeUserSession(userID, c.Param("session_id"), base.Db.Postgresql)
+	if err != nil {
+		rd := utility.BuildErrorResponse(code, "error", err.Error(), err, nil)
+		c.JSON(code, rd)
+		return
+	}
+
+	base.Logger.Info("session revoked successfully")
+
+	rd := utility.BuildSuccessResponse(http.StatusOK, "session revoked successfully", respData)
+	c.JSON(http.StatusOK, rd)
+}
+
+func (base *Controller) RevokeOtherSessions(c *gin.Context) {
+	userID, accessUuid, ok := sessionClaims(c)
+	if !ok {
+		rd := utility.BuildErrorResponse(http.StatusBadRequest, "error", "unable to get user claims", nil, nil)
+		c.JSON(http.StatusBadRequest, rd)
+		return
+	}
+
+	respData, code, err := auth.RevokeOtherSessions(userID, accessUuid, base.Db.Postgresql)
+	if err != nil {
+		rd := utility.BuildErrorResponse(code, "error", err.Error(), err, nil)
+		c.JSON(code, rd)
+		return
+	}
+
+	base.Logger.Info("other sessions revoked successfully")
+
+	rd := utility.BuildSuccessResponse(http.StatusOK, "other sessions revoked successfully", respData)
+	c.JSON(http.StatusOK, rd)
+}
+
+func (base *Controller) GetUserSessions(c *gin.Context) {
+	_, accessUuid, _ := sessionClaims(c)
+
+	respData, code, err := auth.GetUserSessions(c.Param("user_id"), accessUuid, base.Db.Postgresql)
+	if err != nil {
+		rd := utility.BuildErrorResponse(code, "error", err.Error(), err, nil)
+		c.JSON(code, rd)
+		return
+	}
+
+	base.Logger.Info("user sessions retrieved successfully")
+
+	rd := utility.BuildSuccessResponse(http.StatusOK, "user sessions retrieved successfully", respData)
+	c.JSON(http.StatusOK, rd)
+}
+
+func (base *Controller) RevokeUserSession(c *gin.Context) {
+	respData, code, err := auth.RevokeUserSession(c.Param("user_id"), c.Param("session_id"), base.Db.Postgresql)
+	if err != nil {
+		rd := utility.BuildErrorResponse(code, "error", err.Error(), err, nil)
+		c.JSON(code, rd)
+		return
+	}
+
+	base.Logger.Info("user session revoked successfully")
+
+	rd := utility.BuildSuccessResponse(http.StatusOK, "user session revoked successfully", respData)
+	c.JSON(http.StatusOK, rd)
+}
+
+func (base *Controller) RevokeAllUserSessions(c *gin.Context) {
+	respData, code, err := auth.RevokeOtherSessions(c.Param("user_id"), "", base.Db.Postgresql)
+	if err != nil {
+		rd := utility.BuildErrorResponse(code, "error", err.Error(), err, nil)
+		c.JSON(code, rd)
+		return
+	}
+
+	base.Logger.Info("user sessions revoked successfully")
+
+	rd := utility.BuildSuccessResponse(http.StatusOK, "user sessions revoked successfully", respData)
+	c.JSON(http.StatusOK, rd)
+}
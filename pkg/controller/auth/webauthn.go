@@ -0,0 +1,264 @@
+package auth
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/hngprojects/hng_boilerplate_golang_web/internal/models"
+	"github.com/hngprojects/hng_boilerplate_golang_web/pkg/middleware"
+	"github.com/hngprojects/hng_boilerplate_golang_web/services/auth"
+	"github.com/hngprojects/hng_boilerplate_golang_web/utility"
+)
+
+func (base *Controller) BeginWebAuthnRegistration(c *gin.Context) {
+	userID, errResp := middleware.GetIdFromToken(c)
+	if userID == "" {
+		c.JSON(http.StatusUnauthorized, errResp)
+		return
+	}
+
+	respData, code, err := auth.BeginWebAuthnRegistration(userID, base.Db.Postgresql)
+	if err != nil {
+		rd := utility.BuildErrorResponse(code, "error", err.Error(), err, nil)
+		c.JSON(code, rd)
+		return
+	}
+
+	base.Logger.Info("passkey registration started successfully")
+
+	rd := utility.BuildSuccessResponse(http.StatusOK, "passkey registration started successfully", respData)
+	c.JSON(http.StatusOK, rd)
+}
+
+func (base *Controller) FinishWebAuthnRegistration(c *gin.Context) {
+	var req models.FinishWebAuthnRegistrationRequestModel
+
+	userID, errResp := middleware.GetIdFromToken(c)
+	if userID == "" {
+		c.JSON(http.StatusUnauthorized, errResp)
+		return
+	}
+
+	err := c.ShouldBind(&req)
+	if err != nil {
+		rd := utility.BuildErrorResponse(http.StatusBadRequest, "error", "Failed to parse request body", err, nil)
+		c.JSON(http.StatusBadRequest, rd)
+		return
+	}
+
+	err = base.Validator.Struct(&req)
+	if err != nil {
+		rd := utility.BuildErrorResponse(http.StatusUnprocessableEntity, "error", "Validation failed", utility.ValidationResponse(err, base.Validator), nil)
+		c.JSON(http.StatusUnprocessableEntity, rd)
+		return
+	}
+
+	respData, code, err := auth.FinishWebAuthnRegistration(userID, req, base.Db.Postgresql)
+	if err != nil {
+		rd := utility.BuildErrorResponse(code, "error", err.Error(), err, nil)
+		c.JSON(code, rd)
+		return
+	}
+
+	base.Logger.Info("passkey registered successfully")
+
+	rd := utility.BuildSuccessResponse(code, "passkey registered successfully", respData)
+	c.JSON(code, rd)
+}
+
+func (base *Controller) GetWebAuthnCredentials(c *gin.Context) {
+	userID, errResp := middleware.GetIdFromToken(c)
+	if userID == "" {
+		c.JSON(http.StatusUnauthorized, errResp)
+		return
+	}
+
+	respData, code, err := auth.GetWebAuthnCredentials(userID, base.Db.Postgresql)
+	if err != nil {
+		rd := utility.BuildErrorResponse(code, "error", err.Error(), err, nil)
+		c.JSON(code, rd)
+		return
+	}
+
+	base.Logger.Info("passkeys retrieved successfully")
+
+	rd := utility.BuildSuccessResponse(http.StatusOK, "passkeys retrieved successfully", respData)
+	c.JSON(http.StatusOK, rd)
+}
+
+func (base *Controller) RenameWebAuthnCredential(c *gin.Context) {
+	var req models.RenameWebAuthnCredentialRequestModel
+
+	userID, errResp := middleware.GetIdFromToken(c)
+	if userID == "" {
+		c.JSON(http.StatusUnauthorized, errResp)
+		return
+	}
+
+	err := c.ShouldBind(&req)
+	if err != nil {
+		rd := utility.BuildErrorResponse(http.StatusBadRequest, "error", "Failed to parse request body", err, nil)
+		c.JSON(http.StatusBadRequest, rd)
+		return
+	}
+
+	err = base.Validator.Struct(&req)
+	if err != nil {
+		rd := utility.BuildErrorResponse(http.StatusUnprocessableEntity, "error", "Validation failed", utility.ValidationResponse(err, base.Validator), nil)
+		c.JSON(http.StatusUnprocessableEntity, rd)
+		return
+	}
+
+	respData, code, err := auth.RenameWebAuthnCredential(userID, c.Param("credential_id"), req, base.Db.Postgresql)
+	if err != nil {
+		rd := utility.BuildErrorResponse(code, "error", err.Error(), err, nil)
+		c.JSON(code, rd)
+		return
+	}
+
+	base.Logger.Info("passkey renamed successfully")
+
+	rd := utility.BuildSuccessResponse(http.StatusOK, "passkey renamed successfully", respData)
+	c.JSON(http.StatusOK, rd)
+}
+
+func (base *Controller) DeleteWebAuthnCredential(c *gin.Context) {
+	userID, errResp := middleware.GetIdFromToken(c)
+	if userID == "" {
+		c.JSON(http.StatusUnauthorized, errResp)
+		return
+	}
+
+	respData, code, err := auth.DeleteWebAuthnCredential(userID, c.Param("credential_id"), base.Db.Postgresql)
+	if err != nil {
+		rd := utility.BuildErrorResponse(code, "error", err.Error(), err, nil)
+		c.JSON(code, rd)
+		return
+	}
+
+	base.Logger.Info("passkey deleted successfully")
+
+	rd := utility.BuildSuccessResponse(http.StatusOK, "passkey deleted successfully", respData)
+	c.JSON(http.StatusOK, rd)
+}
+
+func (base *Controller) BeginWebAuthnLogin(c *gin.Context) {
+	var req models.BeginWebAuthnLoginRequestModel
+
+	err := c.ShouldBind(&req)
+	if err != nil {
+		rd := utility.BuildErrorResponse(http.StatusBadRequest, "error", "Failed to parse request body", err, nil)
+		c.JSON(http.StatusBadRequest, rd)
+		return
+	}
+
+	err = base.Validator.Struct(&req)
+	if err != nil {
+		rd := utility.BuildErrorResponse(http.StatusUnprocessableEntity, "error", "Validation failed", utility.ValidationResponse(err, base.Validator), nil)
+		c.JSON(http.StatusUnprocessableEntity, rd)
+		return
+	}
+
+	respData, code, err := auth.BeginWebAuthnLogin(req, base.Db.Postgresql)
+	if err != nil {
+		rd := utility.BuildErrorResponse(code, "error", err.Error(), err, nil)
+		c.JSON(code, rd)
+		return
+	}
+
+	base.Logger.Info("passkey login started successfully")
+
+	rd := utility.BuildSuccessResponse(http.StatusOK, "passkey login started successfully", respData)
+	c.JSON(http.StatusOK, rd)
+}
+
+func (base *Controller) FinishWebAuthnLogin(c *gin.Context) {
+	var req models.FinishWebAuthnLoginRequestModel
+
+	err := c.ShouldBind(&req)
+	if err != nil {
+		rd := utility.BuildErrorResponse(http.StatusBadRequest, "error", "Failed to parse request body", err, nil)
+		c.JSON(http.StatusBadRequest, rd)
+		return
+	}
+
+	err = base.Validator.Struct(&req)
+	if err != nil {
+		rd := utility.BuildErrorResponse(http.StatusUnprocessableEntity, "error", "Validation failed", utility.ValidationResponse(err, base.Validator), nil)
+		c.JSON(http.StatusUnprocessableEntity, rd)
+		return
+	}
+
+	respData, code, err := auth.FinishWebAuthnLogin(req, base.Db.Postgresql, sessionClient(c))
+	if err != nil {
+		rd := utility.BuildErrorResponse(code, "error", err.Error(), err, nil)
+		c.JSON(code, rd)
+		return
+	}
+
+	base.Logger.Info("user login successfully")
+
+	rd := utility.BuildSuccessResponse(http.StatusOK, "user login successfully", respData)
+	c.JSON(http.StatusOK, rd)
+}
+
+func (base *Controller) BeginWebAuthnTwoFA(c *gin.Context) {
+	var req models.BeginWebAuthnTwoFARequestModel
+
+	err := c.ShouldBind(&req)
+	if err != nil {
+		rd := utility.BuildErrorResponse(http.StatusBadRequest, "error", "Failed to parse request body", err, nil)
+		c.JSON(http.StatusBadRequest, rd)
+		return
+	}
+
+	err = base.Validator.Struct(&req)
+	if err != nil {
+		rd := utility.BuildErrorResponse(http.StatusUnprocessableEntity, "error", "Validation failed", utility.ValidationResponse(err, base.Validator), nil)
+		c.JSON(http.StatusUnprocessableEntity, rd)
+		return
+	}
+
+	respData, code, err := auth.BeginWebAuthnTwoFA(req, base.Db.Postgresql)
+	if err != nil {
+		rd := utility.BuildErrorResponse(code, "error", err.Error(), err, nil)
+		c.JSON(code, rd)
+		return
+	}
+
+	base.Logger.Info("passkey verification started successfully")
+
+	rd := utility.BuildSuccessResponse(http.StatusOK, "passkey verification started successfully", respData)
+	c.JSON(http.StatusOK, rd)
+}
+
+func (base *Controller) FinishWebAuthnTwoFA(c *gin.Context) {
+	var req models.FinishWebAuthnTwoFARequestModel
+
+	err := c.ShouldBind(&req)
+	if err != nil {
+		rd := utility.BuildErrorResponse(http.StatusBadRequest, "error", "Failed to parse request body", err, nil)
+		c.JSON(http.StatusBadRequest, rd)
+		return
+	}
+
+	err = base.Validator.Struct(&req)
+	if err != nil {
+		rd := utility.BuildErrorResponse(http.StatusUnprocessableEntity, "error", "Validation failed", utility.ValidationResponse(err, base.Validator), nil)
+		c.JSON(http.StatusUnprocessableEntity, rd)
+		return
+	}
+
+	respData, code, err := auth.FinishWebAuthnTwoFA(req, base.Db.Postgresql, sessionClient(c))
+	if err != nil {
+		rd := utility.BuildErrorResponse(code, "error", err.Error(), err, nil)
+		c.JSON(code, rd)
+		return
+	}
+
+	base.Logger.Info("user login successfully")
+
+	rd := utility.BuildSuccessResponse(http.StatusOK, "user login successfully", respData)
+	c.JSON(http.StatusOK, rd)
+}
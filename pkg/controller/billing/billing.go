@@ -1,6 +1,7 @@
 package billing
 
 import (
+	"errors"
 	"net/http"
 
 	"github.com/gin-gonic/gin"
@@ -23,6 +24,14 @@ type Controller struct {
 	ExtReq    request.ExternalRequest
 }
 
+// auditClient captures the request details stored with an audit log.
+func auditClient(c *gin.Context) models.SessionClient {
+	return models.SessionClient{
+		UserAgent: c.Request.UserAgent(),
+		IPAddress: c.ClientIP(),
+	}
+}
+
 func (base *Controller) CreateBilling(c *gin.Context) {
 	var (
 		billingReq models.CreateBillingRequest
@@ -50,7 +59,7 @@ func (base *Controller) CreateBilling(c *gin.Context) {
 	userClaims := claims.(jwt.MapClaims)
 	userId := userClaims["user_id"].(string)
 
-	respData, err := billing.CreateBilling(billingReq, base.Db.Postgresql, userId)
+	respData, err := billing.CreateBilling(billingReq, base.Db.Postgresql, userId, auditClient(c))
 
 	if err != nil {
 		rd := utility.BuildErrorResponse(http.StatusBadRequest, "error", err.Error(), err, nil)
@@ -84,9 +93,17 @@ func (base *Controller) DeleteBilling(c *gin.Context) {
 	userClaims := claims.(jwt.MapClaims)
 	userId := userClaims["user_id"].(string)
 
-	if err := billing.DeleteBilling(billingID, userId, base.Db.Postgresql); err != nil {
-		rd := utility.BuildErrorResponse(http.StatusNotFound, "error", "billing not found", err.Error(), nil)
-		c.JSON(http.StatusNotFound, rd)
+	plan, code, err := billing.DeleteBilling(billingID, userId, base.Db.Postgresql, auditClient(c))
+	if err != nil {
+		rd := utility.BuildErrorResponse(code, "error", err.Error(), err, nil)
+		c.JSON(code, rd)
+		return
+	}
+
+	if code == http.StatusOK {
+		base.Logger.Info("billing archived as it has subscribers")
+		rd := utility.BuildSuccessResponse(http.StatusOK, "billing has subscribers and was archived", plan)
+		c.JSON(http.StatusOK, rd)
 		return
 	}
 
@@ -98,6 +115,11 @@ func (base *Controller) DeleteBilling(c *gin.Context) {
 
 func (base *Controller) GetBillings(c *gin.Context) {
 	billings_len, paginationResponse, err := billing.GetBillings(base.Db.Postgresql, c)
+	if errors.Is(err, billing.ErrInvalidBillingStatus) {
+		rd := utility.BuildErrorResponse(http.StatusBadRequest, "error", err.Error(), err, nil)
+		c.JSON(http.StatusBadRequest, rd)
+		return
+	}
 	if err != nil {
 		rd := utility.BuildErrorResponse(http.StatusNotFound, "error", "failed to fetch billings", err, nil)
 		c.JSON(http.StatusNotFound, rd)
@@ -167,7 +189,7 @@ func (base *Controller) UpdateBillingById(c *gin.Context) {
 	}
 	userId := userID.(string)
 
-	billing, err := billing.UpdateBillingById(billingID, userId, req, base.Db.Postgresql)
+	billing, err := billing.UpdateBillingById(billingID, userId, req, base.Db.Postgresql, auditClient(c))
 
 	if err != nil {
 		rd := utility.BuildErrorResponse(http.StatusNotFound, "error", "billing not found", err.Error(), nil)
@@ -179,3 +201,61 @@ func (base *Controller) UpdateBillingById(c *gin.Context) {
 	rd := utility.BuildSuccessResponse(http.StatusOK, "billing updated successfully", billing)
 	c.JSON(http.StatusOK, rd)
 }
+
+func (base *Controller) ArchiveBilling(c *gin.Context) {
+	base.setBillingStatus(c, models.BillingStatusArchived, "billing archived successfully")
+}
+
+func (base *Controller) RestoreBilling(c *gin.Context) {
+	base.setBillingStatus(c, models.BillingStatusActive, "billing restored successfully")
+}
+
+func (base *Controller) setBillingStatus(c *gin.Context, status, message string) {
+	billingID := c.Param("id")
+
+	if _, err := uuid.Parse(billingID); err != nil {
+		rd := utility.BuildErrorResponse(http.StatusBadRequest, "error", "invalid billing id format", "Bad Request", nil)
+		c.JSON(http.StatusBadRequest, rd)
+		return
+	}
+
+	userID, err := middleware.GetUserClaims(c, base.Db.Postgresql, "user_id")
+	if err != nil {
+		rd := utility.BuildErrorResponse(http.StatusBadRequest, "error", err.Error(), "Bad Request", nil)
+		c.JSON(http.StatusBadRequest, rd)
+		return
+	}
+	userId := userID.(string)
+
+	plan, code, err := billing.SetBillingStatus(billingID, userId, status, base.Db.Postgresql, auditClient(c))
+	if err != nil {
+		rd := utility.BuildErrorResponse(code, "error", err.Error(), err, nil)
+		c.JSON(code, rd)
+		return
+	}
+
+	base.Logger.Info(message)
+	rd := utility.BuildSuccessResponse(http.StatusOK, message, plan)
+	c.JSON(http.StatusOK, rd)
+}
+
+func (base *Controller) GetBillingAuditLogs(c *gin.Context) {
+	billingID := c.Param("id")
+
+	if _, err := uuid.Parse(billingID); err != nil {
+		rd := utility.BuildErrorResponse(http.StatusBadRequest, "error", "invalid billing id format", "Bad Request", nil)
+		c.JSON(http.StatusBadRequest, rd)
+		return
+	}
+
+	logs, code, err := billing.GetBillingAuditLogs(billingID, base.Db.Postgresql)
+	if err != nil {
+		rd := utility.BuildErrorResponse(code, "error", err.Error(), err, nil)
+		c.JSON(code, rd)
+		return
+	}
+
+	base.Logger.Info("billing audit logs retrieved successfully")
+	rd := utility.BuildSuccessResponse(http.StatusOK, "billing audit logs retrieved successfully", logs)
+	c.JSON(http.StatusOK, rd)
+}
@@ -0,0 +1,105 @@
+package invite
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang-jwt/jwt"
+
+	"github.com/hngprojects/hng_boilerplate_golang_web/internal/models"
+	"github.com/hngprojects/hng_boilerplate_golang_web/pkg/repository/storage/postgresql"
+	"github.com/hngprojects/hng_boilerplate_golang_web/services/invite"
+	"github.com/hngprojects/hng_boilerplate_golang_web/utility"
+)
+
+func (base *Controller) GetOrgInvitations(c *gin.Context) {
+	var (
+		orgId  = c.Param("org_id")
+		status = c.Query("status")
+	)
+
+	respData, code, err := invite.ListOrgInvitations(orgId, status, postgresql.GetPagination(c), base.Db.Postgresql)
+	if err != nil {
+		rd := utility.BuildErrorResponse(code, "error", err.Error(), err, nil)
+		c.JSON(code, rd)
+		return
+	}
+
+	base.Logger.Info("organisation invitations fetched successfully")
+	rd := utility.BuildSuccessResponse(http.StatusOK, "Invitations Successfully retrieved", respData)
+	c.JSON(http.StatusOK, rd)
+}
+
+func (base *Controller) RevokeInvitation(c *gin.Context) {
+	var (
+		orgId        = c.Param("org_id")
+		invitationId = c.Param("invitation_id")
+	)
+
+	respData, code, err := invite.RevokeInvitation(orgId, invitationId, base.Db.Postgresql)
+	if err != nil {
+		rd := utility.BuildErrorResponse(code, "error", err.Error(), err, nil)
+		c.JSON(code, rd)
+		return
+	}
+
+	base.Logger.Info("invitation revoked successfully")
+	rd := utility.BuildSuccessResponse(http.StatusOK, "Invitation revoked successfully", respData)
+	c.JSON(http.StatusOK, rd)
+}
+
+func (base *Controller) ResendInvitation(c *gin.Context) {
+	var (
+		orgId        = c.Param("org_id")
+		invitationId = c.Param("invitation_id")
+	)
+
+	claims, exists := c.Get("userClaims")
+	if !exists {
+		rd := utility.BuildErrorResponse(http.StatusBadRequest, "error", "unable to get user claims", nil, nil)
+		c.JSON(http.StatusBadRequest, rd)
+		return
+	}
+	userClaims := claims.(jwt.MapClaims)
+	userId := userClaims["user_id"].(string)
+
+	respData, code, err := invite.ResendInvitation(orgId, invitationId, userId, base.Db.Postgresql)
+	if err != nil {
+		rd := utility.BuildErrorResponse(code, "error", err.Error(), err, nil)
+		c.JSON(code, rd)
+		return
+	}
+
+	base.Logger.Info("invitation resent successfully")
+	rd := utility.BuildSuccessResponse(http.StatusOK, "Invitation resent successfully", respData)
+	c.JSON(http.StatusOK, rd)
+}
+
+func (base *Controller) DeclineInvite(c *gin.Context) {
+	var inviteReq models.InvitationDeclineReq
+
+	err := c.ShouldBind(&inviteReq)
+	if err != nil {
+		rd := utility.BuildErrorResponse(http.StatusBadRequest, "error", "Failed to parse request body", err, nil)
+		c.JSON(http.StatusBadRequest, rd)
+		return
+	}
+
+	err = base.Validator.Struct(&inviteReq)
+	if err != nil {
+		rd := utility.BuildErrorResponse(http.StatusUnprocessableEntity, "error", "Validation failed", utility.ValidationResponse(err, base.Validator), nil)
+		c.JSON(http.StatusUnprocessableEntity, rd)
+		return
+	}
+
+	respData, code, err := invite.DeclineInvitation(inviteReq, base.Db.Postgresql)
+	if err != nil {
+		rd := utility.BuildErrorResponse(code, "error", err.Error(), err, nil)
+		c.JSON(code, rd)
+		return
+	}
+
+	base.Logger.Info("invitation declined successfully")
+	rd := utility.BuildSuccessResponse(http.StatusOK, "Invitation declined", respData)
+	c.JSON(http.StatusOK, rd)
+}
@@ -53,7 +53,7 @@ func (base *Controller) PostInvite(c *gin.Context) {
 		return
 	}
 
-	statusCode, msg, invitations := invite.IteratorPostInvite(c, inviteReq, base.Db, base.Logger, org)
+	statusCode, msg, invitations := invite.IteratorPostInvite(c, inviteReq, base.Db, base.Logger, org, userId)
 	if statusCode != http.StatusCreated {
 		rd := utility.BuildErrorResponse(statusCode, "error", msg, nil, invitations)
 		c.JSON(statusCode, rd)
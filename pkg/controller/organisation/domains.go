@@ -0,0 +1,183 @@
+package organisation
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/hngprojects/hng_boilerplate_golang_web/internal/models"
+	service "github.com/hngprojects/hng_boilerplate_golang_web/services/organisation"
+	"github.com/hngprojects/hng_boilerplate_golang_web/utility"
+)
+
+func (base *Controller) ClaimOrgDomain(c *gin.Context) {
+	var (
+		orgId = c.Param("org_id")
+		req   = models.ClaimOrgDomainRequestModel{}
+	)
+
+	err := c.ShouldBind(&req)
+	if err != nil {
+		rd := utility.BuildErrorResponse(http.StatusBadRequest, "error", "Failed to parse request body", err, nil)
+		c.JSON(http.StatusBadRequest, rd)
+		return
+	}
+
+	err = base.Validator.Struct(&req)
+	if err != nil {
+		rd := utility.BuildErrorResponse(http.StatusUnprocessableEntity, "error", "Validation failed", utility.ValidationResponse(err, base.Validator), nil)
+		c.JSON(http.StatusUnprocessableEntity, rd)
+		return
+	}
+
+	respData, code, err := service.ClaimOrgDomain(req, orgId, base.Db.Postgresql)
+	if err != nil {
+		rd := utility.BuildErrorResponse(code, "error", err.Error(), err, nil)
+		c.JSON(code, rd)
+		return
+	}
+
+	base.Logger.Info("organisation domain claimed successfully")
+	rd := utility.BuildSuccessResponse(http.StatusCreated, "Domain claimed, publish the TXT record and verify it", respData)
+	c.JSON(http.StatusCreated, rd)
+}
+
+func (base *Controller) GetOrgDomains(c *gin.Context) {
+	orgId := c.Param("org_id")
+
+	respData, code, err := service.GetOrgDomains(orgId, base.Db.Postgresql)
+	if err != nil {
+		rd := utility.BuildErrorResponse(code, "error", err.Error(), err, nil)
+		c.JSON(code, rd)
+		return
+	}
+
+	base.Logger.Info("organisation domains fetched successfully")
+	rd := utility.BuildSuccessResponse(http.StatusOK, "Domains retrieved successfully", respData)
+	c.JSON(http.StatusOK, rd)
+}
+
+func (base *Controller) VerifyOrgDomain(c *gin.Context) {
+	var (
+		orgId    = c.Param("org_id")
+		domainId = c.Param("domain_id")
+	)
+
+	respData, code, err := service.VerifyOrgDomain(orgId, domainId, base.Db.Postgresql)
+	if err != nil {
+		rd := utility.BuildErrorResponse(code, "error", err.Error(), err, nil)
+		c.JSON(code, rd)
+		return
+	}
+
+	base.Logger.Info("organisation domain verified successfully")
+	rd := utility.BuildSuccessResponse(http.StatusOK, "Domain verified successfully", respData)
+	c.JSON(http.StatusOK, rd)
+}
+
+func (base *Controller) UpdateOrgDomain(c *gin.Context) {
+	var (
+		orgId    = c.Param("org_id")
+		domainId = c.Param("domain_id")
+		req      = models.UpdateOrgDomainRequestModel{}
+	)
+
+	err := c.ShouldBind(&req)
+	if err != nil {
+		rd := utility.BuildErrorResponse(http.StatusBadRequest, "error", "Failed to parse request body", err, nil)
+		c.JSON(http.StatusBadRequest, rd)
+		return
+	}
+
+	err = base.Validator.Struct(&req)
+	if err != nil {
+		rd := utility.BuildErrorResponse(http.StatusUnprocessableEntity, "error", "Validation failed", utility.ValidationResponse(err, base.Validator), nil)
+		c.JSON(http.StatusUnprocessableEntity, rd)
+		return
+	}
+
+	respData, code, err := service.UpdateOrgDomain(req, orgId, domainId, base.Db.Postgresql)
+	if err != nil {
+		rd := utility.BuildErrorResponse(code, "error", err.Error(), err, nil)
+		c.JSON(code, rd)
+		return
+	}
+
+	base.Logger.Info("organisation domain updated successfully")
+	rd := utility.BuildSuccessResponse(http.StatusOK, "Domain updated successfully", respData)
+	c.JSON(http.StatusOK, rd)
+}
+
+func (base *Controller) DeleteOrgDomain(c *gin.Context) {
+	var (
+		orgId    = c.Param("org_id")
+		domainId = c.Param("domain_id")
+	)
+
+	respData, code, err := service.DeleteOrgDomain(orgId, domainId, base.Db.Postgresql)
+	if err != nil {
+		rd := utility.BuildErrorResponse(code, "error", err.Error(), err, nil)
+		c.JSON(code, rd)
+		return
+	}
+
+	base.Logger.Info("organisation domain deleted successfully")
+	rd := utility.BuildSuccessResponse(http.StatusOK, "Domain deleted successfully", respData)
+	c.JSON(http.StatusOK, rd)
+}
+
+func (base *Controller) GetJoinRequests(c *gin.Context) {
+	var (
+		orgId  = c.Param("org_id")
+		status = c.Query("status")
+	)
+
+	respData, code, err := service.GetJoinRequests(orgId, status, base.Db.Postgresql)
+	if err != nil {
+		rd := utility.BuildErrorResponse(code, "error", err.Error(), err, nil)
+		c.JSON(code, rd)
+		return
+	}
+
+	base.Logger.Info("join requests fetched successfully")
+	rd := utility.BuildSuccessResponse(http.StatusOK, "Join requests retrieved successfully", respData)
+	c.JSON(http.StatusOK, rd)
+}
+
+func (base *Controller) ApproveJoinRequest(c *gin.Context) {
+	base.reviewJoinRequest(c, true)
+}
+
+func (base *Controller) RejectJoinRequest(c *gin.Context) {
+	base.reviewJoinRequest(c, false)
+}
+
+func (base *Controller) reviewJoinRequest(c *gin.Context, approve bool) {
+	var (
+		orgId     = c.Param("org_id")
+		requestId = c.Param("request_id")
+	)
+
+	userId, ok := claimsUserID(c)
+	if !ok {
+		rd := utility.BuildErrorResponse(http.StatusBadRequest, "error", "unable to get user claims", nil, nil)
+		c.JSON(http.StatusBadRequest, rd)
+		return
+	}
+
+	respData, code, err := service.ReviewJoinRequest(orgId, requestId, userId, approve, base.Db.Postgresql)
+	if err != nil {
+		rd := utility.BuildErrorResponse(code, "error", err.Error(), err, nil)
+		c.JSON(code, rd)
+		return
+	}
+
+	message := "Join request rejected"
+	if approve {
+		message = "Join request approved"
+	}
+
+	base.Logger.Info("join request reviewed successfully")
+	rd := utility.BuildSuccessResponse(http.StatusOK, message, respData)
+	c.JSON(http.StatusOK, rd)
+}
@@ -0,0 +1,173 @@
+package organisation
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/hngprojects/hng_boilerplate_golang_web/internal/models"
+	service "github.com/hngprojects/hng_boilerplate_golang_web/services/organisation"
+	"github.com/hngprojects/hng_boilerplate_golang_web/utility"
+)
+
+func (base *Controller) AssignMemberRole(c *gin.Context) {
+	var (
+		orgId  = c.Param("org_id")
+		userId = c.Param("user_id")
+		req    = models.AssignOrgRoleRequestModel{}
+	)
+
+	err := c.ShouldBind(&req)
+	if err != nil {
+		rd := utility.BuildErrorResponse(http.StatusBadRequest, "error", "Failed to parse request body", err, nil)
+		c.JSON(http.StatusBadRequest, rd)
+		return
+	}
+
+	err = base.Validator.Struct(&req)
+	if err != nil {
+		rd := utility.BuildErrorResponse(http.StatusUnprocessableEntity, "error", "Validation failed", utility.ValidationResponse(err, base.Validator), nil)
+		c.JSON(http.StatusUnprocessableEntity, rd)
+		return
+	}
+
+	adminId, ok := claimsUserID(c)
+	if !ok {
+		rd := utility.BuildErrorResponse(http.StatusBadRequest, "error", "unable to get user claims", nil, nil)
+		c.JSON(http.StatusBadRequest, rd)
+		return
+	}
+
+	respData, code, err := service.AssignMemberRole(req, orgId, userId, adminId, base.Db.Postgresql)
+	if err != nil {
+		rd := utility.BuildErrorResponse(code, "error", err.Error(), err, nil)
+		c.JSON(code, rd)
+		return
+	}
+
+	base.Logger.Info("member role assigned successfully")
+	rd := utility.BuildSuccessResponse(http.StatusOK, "Member role assigned successfully", respData)
+	c.JSON(http.StatusOK, rd)
+}
+
+func (base *Controller) RemoveMemberRole(c *gin.Context) {
+	var (
+		orgId  = c.Param("org_id")
+		userId = c.Param("user_id")
+	)
+
+	adminId, ok := claimsUserID(c)
+	if !ok {
+		rd := utility.BuildErrorResponse(http.StatusBadRequest, "error", "unable to get user claims", nil, nil)
+		c.JSON(http.StatusBadRequest, rd)
+		return
+	}
+
+	respData, code, err := service.RemoveMemberRole(orgId, userId, adminId, base.Db.Postgresql)
+	if err != nil {
+		rd := utility.BuildErrorResponse(code, "error", err.Error(), err, nil)
+		c.JSON(code, rd)
+		return
+	}
+
+	base.Logger.Info("member role removed successfully")
+	rd := utility.BuildSuccessResponse(http.StatusOK, "Member role removed successfully", respData)
+	c.JSON(http.StatusOK, rd)
+}
+
+func (base *Controller) SetDefaultOrgRole(c *gin.Context) {
+	var (
+		orgId  = c.Param("org_id")
+		roleId = c.Param("role_id")
+	)
+
+	adminId, ok := claimsUserID(c)
+	if !ok {
+		rd := utility.BuildErrorResponse(http.StatusBadRequest, "error", "unable to get user claims", nil, nil)
+		c.JSON(http.StatusBadRequest, rd)
+		return
+	}
+
+	respData, code, err := service.SetDefaultOrgRole(orgId, roleId, adminId, base.Db.Postgresql)
+	if err != nil {
+		rd := utility.BuildErrorResponse(code, "error", err.Error(), err, nil)
+		c.JSON(code, rd)
+		return
+	}
+
+	base.Logger.Info("default org role set successfully")
+	rd := utility.BuildSuccessResponse(http.StatusOK, "Default role set successfully", respData)
+	c.JSON(http.StatusOK, rd)
+}
+
+func (base *Controller) RemoveMember(c *gin.Context) {
+	var (
+		orgId  = c.Param("org_id")
+		userId = c.Param("user_id")
+		req    = models.RemoveMemberRequestModel{}
+	)
+
+	err := c.ShouldBindQuery(&req)
+	if err != nil {
+		rd := utility.BuildErrorResponse(http.StatusBadRequest, "error", "Failed to parse request query", err, nil)
+		c.JSON(http.StatusBadRequest, rd)
+		return
+	}
+
+	err = base.Validator.Struct(&req)
+	if err != nil {
+		rd := utility.BuildErrorResponse(http.StatusUnprocessableEntity, "error", "Validation failed", utility.ValidationResponse(err, base.Validator), nil)
+		c.JSON(http.StatusUnprocessableEntity, rd)
+		return
+	}
+
+	adminId, ok := claimsUserID(c)
+	if !ok {
+		rd := utility.BuildErrorResponse(http.StatusBadRequest, "error", "unable to get user claims", nil, nil)
+		c.JSON(http.StatusBadRequest, rd)
+		return
+	}
+
+	client := models.SessionClient{
+		UserAgent: c.Request.UserAgent(),
+		IPAddress: c.ClientIP(),
+	}
+
+	respData, code, err := service.RemoveMember(req, orgId, userId, adminId, base.Db.Postgresql, client)
+	if err != nil {
+		rd := utility.BuildErrorResponse(code, "error", err.Error(), err, nil)
+		c.JSON(code, rd)
+		return
+	}
+
+	base.Logger.Info("member removed successfully")
+	rd := utility.BuildSuccessResponse(http.StatusOK, "Member removed successfully", respData)
+	c.JSON(http.StatusOK, rd)
+}
+
+func (base *Controller) LeaveOrganisation(c *gin.Context) {
+	orgId := c.Param("org_id")
+
+	userId, ok := claimsUserID(c)
+	if !ok {
+		rd := utility.BuildErrorResponse(http.StatusBadRequest, "error", "unable to get user claims", nil, nil)
+		c.JSON(http.StatusBadRequest, rd)
+		return
+	}
+
+	client := models.SessionClient{
+		UserAgent: c.Request.UserAgent(),
+		IPAddress: c.ClientIP(),
+	}
+
+	respData, code, err := service.LeaveOrganisation(orgId, userId, base.Db.Postgresql, client)
+	if err != nil {
+		rd := utility.BuildErrorResponse(code, "error", err.Error(), err, nil)
+		c.JSON(code, rd)
+		return
+	}
+
+	base.Logger.Info("member left organisation successfully")
+	rd := utility.BuildSuccessResponse(http.StatusOK, "You have left the organisation", respData)
+	c.JSON(http.StatusOK, rd)
+}
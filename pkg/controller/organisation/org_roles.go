@@ -66,7 +66,14 @@ func (base *Controller) CreateOrgRole(c *gin.Context) {
 		return
 	}
 
-	respData, code, err := service.CreateOrgRoles(req, orgId, base.Db.Postgresql, c)
+	userId, ok := claimsUserID(c)
+	if !ok {
+		rd := utility.BuildErrorResponse(http.StatusBadRequest, "error", "unable to get user claims", nil, nil)
+		c.JSON(http.StatusBadRequest, rd)
+		return
+	}
+
+	respData, code, err := service.CreateOrgRoles(req, orgId, userId, base.Db.Postgresql, c)
 
 	if err != nil {
 		rd := utility.BuildErrorResponse(code, "error", err.Error(), nil, nil)
@@ -156,7 +163,14 @@ func (base *Controller) UpdateOrgPermissions(c *gin.Context) {
 		return
 	}
 
-	code, err := service.UpdateOrgPermissions(req, orgId, roleId, base.Db.Postgresql, c)
+	userId, ok := claimsUserID(c)
+	if !ok {
+		rd := utility.BuildErrorResponse(http.StatusBadRequest, "error", "unable to get user claims", nil, nil)
+		c.JSON(http.StatusBadRequest, rd)
+		return
+	}
+
+	code, err := service.UpdateOrgPermissions(req, orgId, roleId, userId, base.Db.Postgresql, c)
 
 	if err != nil {
 		rd := utility.BuildErrorResponse(code, "error", err.Error(), nil, nil)
@@ -169,3 +183,15 @@ func (base *Controller) UpdateOrgPermissions(c *gin.Context) {
 
 	c.JSON(http.StatusOK, rd)
 }
+
+func (base *Controller) GetPermissionCatalog(c *gin.Context) {
+	respData, code, err := service.GetPermissionCatalog()
+	if err != nil {
+		rd := utility.BuildErrorResponse(code, "error", err.Error(), nil, nil)
+		c.JSON(code, rd)
+		return
+	}
+
+	rd := utility.BuildSuccessResponse(http.StatusOK, "Permissions retrieved successfully", respData)
+	c.JSON(http.StatusOK, rd)
+}
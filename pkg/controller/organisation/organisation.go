@@ -143,6 +143,12 @@ func (base *Controller) UpdateOrganisation(c *gin.Context) {
 		return
 	}
 
+	if err := base.Validator.Struct(&updateReq); err != nil {
+		rd := utility.BuildErrorResponse(http.StatusUnprocessableEntity, "error", "Validation failed", utility.ValidationResponse(err, base.Validator), nil)
+		c.JSON(http.StatusUnprocessableEntity, rd)
+		return
+	}
+
 	updatedOrg, err := service.UpdateOrganisation(orgId, userId, updateReq, base.Db.Postgresql)
 
 	if err != nil {
@@ -0,0 +1,164 @@
+package organisation
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang-jwt/jwt"
+
+	"github.com/hngprojects/hng_boilerplate_golang_web/internal/models"
+	service "github.com/hngprojects/hng_boilerplate_golang_web/services/organisation"
+	"github.com/hngprojects/hng_boilerplate_golang_web/utility"
+)
+
+// claimsUserID returns the id of the user making the request.
+func claimsUserID(c *gin.Context) (string, bool) {
+	claims, exists := c.Get("userClaims")
+	if !exists {
+		return "", false
+	}
+
+	userID, ok := claims.(jwt.MapClaims)["user_id"].(string)
+	return userID, ok
+}
+
+func (base *Controller) RequestOwnershipTransfer(c *gin.Context) {
+	var (
+		orgId = c.Param("org_id")
+		req   = models.TransferOwnershipRequestModel{}
+	)
+
+	err := c.ShouldBind(&req)
+	if err != nil {
+		rd := utility.BuildErrorResponse(http.StatusBadRequest, "error", "Failed to parse request body", err, nil)
+		c.JSON(http.StatusBadRequest, rd)
+		return
+	}
+
+	err = base.Validator.Struct(&req)
+	if err != nil {
+		rd := utility.BuildErrorResponse(http.StatusUnprocessableEntity, "error", "Validation failed", utility.ValidationResponse(err, base.Validator), nil)
+		c.JSON(http.StatusUnprocessableEntity, rd)
+		return
+	}
+
+	userId, ok := claimsUserID(c)
+	if !ok {
+		rd := utility.BuildErrorResponse(http.StatusBadRequest, "error", "unable to get user claims", nil, nil)
+		c.JSON(http.StatusBadRequest, rd)
+		return
+	}
+
+	respData, code, err := service.RequestOwnershipTransfer(req, orgId, userId, base.Db.Postgresql)
+	if err != nil {
+		rd := utility.BuildErrorResponse(code, "error", err.Error(), err, nil)
+		c.JSON(code, rd)
+		return
+	}
+
+	base.Logger.Info("ownership transfer requested successfully")
+	rd := utility.BuildSuccessResponse(http.StatusOK, "Ownership transfer requested, the new owner has been emailed", respData)
+	c.JSON(http.StatusOK, rd)
+}
+
+func (base *Controller) CancelOwnershipTransfer(c *gin.Context) {
+	orgId := c.Param("org_id")
+
+	userId, ok := claimsUserID(c)
+	if !ok {
+		rd := utility.BuildErrorResponse(http.StatusBadRequest, "error", "unable to get user claims", nil, nil)
+		c.JSON(http.StatusBadRequest, rd)
+		return
+	}
+
+	respData, code, err := service.CancelOwnershipTransfer(orgId, userId, base.Db.Postgresql)
+	if err != nil {
+		rd := utility.BuildErrorResponse(code, "error", err.Error(), err, nil)
+		c.JSON(code, rd)
+		return
+	}
+
+	base.Logger.Info("ownership transfer cancelled successfully")
+	rd := utility.BuildSuccessResponse(http.StatusOK, "Ownership transfer cancelled", respData)
+	c.JSON(http.StatusOK, rd)
+}
+
+func (base *Controller) AcceptOwnershipTransfer(c *gin.Context) {
+	req := models.OwnershipTransferTokenRequestModel{}
+
+	err := c.ShouldBind(&req)
+	if err != nil {
+		rd := utility.BuildErrorResponse(http.StatusBadRequest, "error", "Failed to parse request body", err, nil)
+		c.JSON(http.StatusBadRequest, rd)
+		return
+	}
+
+	err = base.Validator.Struct(&req)
+	if err != nil {
+		rd := utility.BuildErrorResponse(http.StatusUnprocessableEntity, "error", "Validation failed", utility.ValidationResponse(err, base.Validator), nil)
+		c.JSON(http.StatusUnprocessableEntity, rd)
+		return
+	}
+
+	userId, ok := claimsUserID(c)
+	if !ok {
+		rd := utility.BuildErrorResponse(http.StatusBadRequest, "error", "unable to get user claims", nil, nil)
+		c.JSON(http.StatusBadRequest, rd)
+		return
+	}
+
+	respData, code, err := service.AcceptOwnershipTransfer(req, userId, base.Db.Postgresql)
+	if err != nil {
+		rd := utility.BuildErrorResponse(code, "error", err.Error(), err, nil)
+		c.JSON(code, rd)
+		return
+	}
+
+	base.Logger.Info("ownership transferred successfully")
+	rd := utility.BuildSuccessResponse(http.StatusOK, "Ownership transferred successfully", respData)
+	c.JSON(http.StatusOK, rd)
+}
+
+func (base *Controller) ForceOwnershipTransfer(c *gin.Context) {
+	var (
+		orgId = c.Param("org_id")
+		req   = models.TransferOwnershipRequestModel{}
+	)
+
+	err := c.ShouldBind(&req)
+	if err != nil {
+		rd := utility.BuildErrorResponse(http.StatusBadRequest, "error", "Failed to parse request body", err, nil)
+		c.JSON(http.StatusBadRequest, rd)
+		return
+	}
+
+	err = base.Validator.Struct(&req)
+	if err != nil {
+		rd := utility.BuildErrorResponse(http.StatusUnprocessableEntity, "error", "Validation failed", utility.ValidationResponse(err, base.Validator), nil)
+		c.JSON(http.StatusUnprocessableEntity, rd)
+		return
+	}
+
+	adminId, ok := claimsUserID(c)
+	if !ok {
+		rd := utility.BuildErrorResponse(http.StatusBadRequest, "error", "unable to get user claims", nil, nil)
+		c.JSON(http.StatusBadRequest, rd)
+		return
+	}
+
+	client := models.SessionClient{
+		UserAgent: c.Request.UserAgent(),
+		IPAddress: c.ClientIP(),
+	}
+
+	respData, code, err := service.ForceOwnershipTransfer(req, orgId, adminId, base.Db.Postgresql, client)
+	if err != nil {
+		rd := utility.BuildErrorResponse(code, "error", err.Error(), err, nil)
+		c.JSON(code, rd)
+		return
+	}
+
+	base.Logger.Info("ownership transferred by superadmin")
+	rd := utility.BuildSuccessResponse(http.StatusOK, "Ownership transferred successfully", respData)
+	c.JSON(http.StatusOK, rd)
+}
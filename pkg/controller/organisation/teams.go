@@ -0,0 +1,408 @@
+package organisation
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/hngprojects/hng_boilerplate_golang_web/internal/models"
+	service "github.com/hngprojects/hng_boilerplate_golang_web/services/organisation"
+	"github.com/hngprojects/hng_boilerplate_golang_web/utility"
+)
+
+func (base *Controller) CreateTeam(c *gin.Context) {
+	var (
+		orgId = c.Param("org_id")
+		req   = models.CreateTeamRequestModel{}
+	)
+
+	err := c.ShouldBind(&req)
+	if err != nil {
+		rd := utility.BuildErrorResponse(http.StatusBadRequest, "error", "Failed to parse request body", err, nil)
+		c.JSON(http.StatusBadRequest, rd)
+		return
+	}
+
+	err = base.Validator.Struct(&req)
+	if err != nil {
+		rd := utility.BuildErrorResponse(http.StatusUnprocessableEntity, "error", "Validation failed", utility.ValidationResponse(err, base.Validator), nil)
+		c.JSON(http.StatusUnprocessableEntity, rd)
+		return
+	}
+
+	respData, code, err := service.CreateTeam(req, orgId, base.Db.Postgresql)
+	if err != nil {
+		rd := utility.BuildErrorResponse(code, "error", err.Error(), err, nil)
+		c.JSON(code, rd)
+		return
+	}
+
+	base.Logger.Info("team created successfully")
+	rd := utility.BuildSuccessResponse(http.StatusCreated, "Team created successfully", respData)
+	c.JSON(http.StatusCreated, rd)
+}
+
+func (base *Controller) GetTeams(c *gin.Context) {
+	orgId := c.Param("org_id")
+
+	userId, ok := claimsUserID(c)
+	if !ok {
+		rd := utility.BuildErrorResponse(http.StatusBadRequest, "error", "unable to get user claims", nil, nil)
+		c.JSON(http.StatusBadRequest, rd)
+		return
+	}
+
+	respData, code, err := service.GetTeams(orgId, userId, base.Db.Postgresql)
+	if err != nil {
+		rd := utility.BuildErrorResponse(code, "error", err.Error(), err, nil)
+		c.JSON(code, rd)
+		return
+	}
+
+	base.Logger.Info("teams fetched successfully")
+	rd := utility.BuildSuccessResponse(http.StatusOK, "Teams retrieved successfully", respData)
+	c.JSON(http.StatusOK, rd)
+}
+
+func (base *Controller) GetTeam(c *gin.Context) {
+	var (
+		orgId  = c.Param("org_id")
+		teamId = c.Param("team_id")
+	)
+
+	userId, ok := claimsUserID(c)
+	if !ok {
+		rd := utility.BuildErrorResponse(http.StatusBadRequest, "error", "unable to get user claims", nil, nil)
+		c.JSON(http.StatusBadRequest, rd)
+		return
+	}
+
+	respData, code, err := service.GetTeam(orgId, teamId, userId, base.Db.Postgresql)
+	if err != nil {
+		rd := utility.BuildErrorResponse(code, "error", err.Error(), err, nil)
+		c.JSON(code, rd)
+		return
+	}
+
+	base.Logger.Info("team fetched successfully")
+	rd := utility.BuildSuccessResponse(http.StatusOK, "Team retrieved successfully", respData)
+	c.JSON(http.StatusOK, rd)
+}
+
+func (base *Controller) UpdateTeam(c *gin.Context) {
+	var (
+		orgId  = c.Param("org_id")
+		teamId = c.Param("team_id")
+		req    = models.UpdateTeamRequestModel{}
+	)
+
+	err := c.ShouldBind(&req)
+	if err != nil {
+		rd := utility.BuildErrorResponse(http.StatusBadRequest, "error", "Failed to parse request body", err, nil)
+		c.JSON(http.StatusBadRequest, rd)
+		return
+	}
+
+	err = base.Validator.Struct(&req)
+	if err != nil {
+		rd := utility.BuildErrorResponse(http.StatusUnprocessableEntity, "error", "Validation failed", utility.ValidationResponse(err, base.Validator), nil)
+		c.JSON(http.StatusUnprocessableEntity, rd)
+		return
+	}
+
+	respData, code, err := service.UpdateTeam(req, orgId, teamId, base.Db.Postgresql)
+	if err != nil {
+		rd := utility.BuildErrorResponse(code, "error", err.Error(), err, nil)
+		c.JSON(code, rd)
+		return
+	}
+
+	base.Logger.Info("team updated successfully")
+	rd := utility.BuildSuccessResponse(http.StatusOK, "Team updated successfully", respData)
+	c.JSON(http.StatusOK, rd)
+}
+
+func (base *Controller) DeleteTeam(c *gin.Context) {
+	var (
+		orgId  = c.Param("org_id")
+		teamId = c.Param("team_id")
+	)
+
+	respData, code, err := service.DeleteTeam(orgId, teamId, base.Db.Postgresql)
+	if err != nil {
+		rd := utility.BuildErrorResponse(code, "error", err.Error(), err, nil)
+		c.JSON(code, rd)
+		return
+	}
+
+	base.Logger.Info("team deleted successfully")
+	rd := utility.BuildSuccessResponse(http.StatusOK, "Team deleted successfully", respData)
+	c.JSON(http.StatusOK, rd)
+}
+
+func (base *Controller) SetTeamRole(c *gin.Context) {
+	var (
+		orgId  = c.Param("org_id")
+		teamId = c.Param("team_id")
+		req    = models.AssignOrgRoleRequestModel{}
+	)
+
+	err := c.ShouldBind(&req)
+	if err != nil {
+		rd := utility.BuildErrorResponse(http.StatusBadRequest, "error", "Failed to parse request body", err, nil)
+		c.JSON(http.StatusBadRequest, rd)
+		return
+	}
+
+	err = base.Validator.Struct(&req)
+	if err != nil {
+		rd := utility.BuildErrorResponse(http.StatusUnprocessableEntity, "error", "Validation failed", utility.ValidationResponse(err, base.Validator), nil)
+		c.JSON(http.StatusUnprocessableEntity, rd)
+		return
+	}
+
+	userId, ok := claimsUserID(c)
+	if !ok {
+		rd := utility.BuildErrorResponse(http.StatusBadRequest, "error", "unable to get user claims", nil, nil)
+		c.JSON(http.StatusBadRequest, rd)
+		return
+	}
+
+	respData, code, err := service.SetTeamRole(req, orgId, teamId, userId, base.Db.Postgresql)
+	if err != nil {
+		rd := utility.BuildErrorResponse(code, "error", err.Error(), err, nil)
+		c.JSON(code, rd)
+		return
+	}
+
+	base.Logger.Info("team role set successfully")
+	rd := utility.BuildSuccessResponse(http.StatusOK, "Role granted to team successfully", respData)
+	c.JSON(http.StatusOK, rd)
+}
+
+func (base *Controller) RemoveTeamRole(c *gin.Context) {
+	var (
+		orgId  = c.Param("org_id")
+		teamId = c.Param("team_id")
+	)
+
+	respData, code, err := service.RemoveTeamRole(orgId, teamId, base.Db.Postgresql)
+	if err != nil {
+		rd := utility.BuildErrorResponse(code, "error", err.Error(), err, nil)
+		c.JSON(code, rd)
+		return
+	}
+
+	base.Logger.Info("team role removed successfully")
+	rd := utility.BuildSuccessResponse(http.StatusOK, "Role removed from team successfully", respData)
+	c.JSON(http.StatusOK, rd)
+}
+
+func (base *Controller) GetTeamMembers(c *gin.Context) {
+	var (
+		orgId  = c.Param("org_id")
+		teamId = c.Param("team_id")
+	)
+
+	userId, ok := claimsUserID(c)
+	if !ok {
+		rd := utility.BuildErrorResponse(http.StatusBadRequest, "error", "unable to get user claims", nil, nil)
+		c.JSON(http.StatusBadRequest, rd)
+		return
+	}
+
+	respData, code, err := service.GetTeamMembers(orgId, teamId, userId, base.Db.Postgresql)
+	if err != nil {
+		rd := utility.BuildErrorResponse(code, "error", err.Error(), err, nil)
+		c.JSON(code, rd)
+		return
+	}
+
+	base.Logger.Info("team members fetched successfully")
+	rd := utility.BuildSuccessResponse(http.StatusOK, "Team members retrieved successfully", respData)
+	c.JSON(http.StatusOK, rd)
+}
+
+func (base *Controller) AddTeamMember(c *gin.Context) {
+	var (
+		orgId  = c.Param("org_id")
+		teamId = c.Param("team_id")
+		req    = models.AddTeamMemberRequestModel{}
+	)
+
+	err := c.ShouldBind(&req)
+	if err != nil {
+		rd := utility.BuildErrorResponse(http.StatusBadRequest, "error", "Failed to parse request body", err, nil)
+		c.JSON(http.StatusBadRequest, rd)
+		return
+	}
+
+	err = base.Validator.Struct(&req)
+	if err != nil {
+		rd := utility.BuildErrorResponse(http.StatusUnprocessableEntity, "error", "Validation failed", utility.ValidationResponse(err, base.Validator), nil)
+		c.JSON(http.StatusUnprocessableEntity, rd)
+		return
+	}
+
+	userId, ok := claimsUserID(c)
+	if !ok {
+		rd := utility.BuildErrorResponse(http.StatusBadRequest, "error", "unable to get user claims", nil, nil)
+		c.JSON(http.StatusBadRequest, rd)
+		return
+	}
+
+	respData, code, err := service.AddTeamMember(req, orgId, teamId, userId, base.Db.Postgresql)
+	if err != nil {
+		rd := utility.BuildErrorResponse(code, "error", err.Error(), err, nil)
+		c.JSON(code, rd)
+		return
+	}
+
+	base.Logger.Info("team member added successfully")
+	rd := utility.BuildSuccessResponse(http.StatusCreated, "Member added to team successfully", respData)
+	c.JSON(http.StatusCreated, rd)
+}
+
+func (base *Controller) UpdateTeamMember(c *gin.Context) {
+	var (
+		orgId  = c.Param("org_id")
+		teamId = c.Param("team_id")
+		userId = c.Param("user_id")
+		req    = models.UpdateTeamMemberRequestModel{}
+	)
+
+	err := c.ShouldBind(&req)
+	if err != nil {
+		rd := utility.BuildErrorResponse(http.StatusBadRequest, "error", "Failed to parse request body", err, nil)
+		c.JSON(http.StatusBadRequest, rd)
+		return
+	}
+
+	respData, code, err := service.UpdateTeamMember(req, orgId, teamId, userId, base.Db.Postgresql)
+	if err != nil {
+		rd := utility.BuildErrorResponse(code, "error", err.Error(), err, nil)
+		c.JSON(code, rd)
+		return
+	}
+
+	base.Logger.Info("team member updated successfully")
+	rd := utility.BuildSuccessResponse(http.StatusOK, "Team member updated successfully", respData)
+	c.JSON(http.StatusOK, rd)
+}
+
+func (base *Controller) RemoveTeamMember(c *gin.Context) {
+	var (
+		orgId  = c.Param("org_id")
+		teamId = c.Param("team_id")
+		userId = c.Param("user_id")
+	)
+
+	actorId, ok := claimsUserID(c)
+	if !ok {
+		rd := utility.BuildErrorResponse(http.StatusBadRequest, "error", "unable to get user claims", nil, nil)
+		c.JSON(http.StatusBadRequest, rd)
+		return
+	}
+
+	respData, code, err := service.RemoveTeamMember(orgId, teamId, userId, actorId, base.Db.Postgresql)
+	if err != nil {
+		rd := utility.BuildErrorResponse(code, "error", err.Error(), err, nil)
+		c.JSON(code, rd)
+		return
+	}
+
+	base.Logger.Info("team member removed successfully")
+	rd := utility.BuildSuccessResponse(http.StatusOK, "Member removed from team successfully", respData)
+	c.JSON(http.StatusOK, rd)
+}
+
+func (base *Controller) GetTeamResources(c *gin.Context) {
+	var (
+		orgId  = c.Param("org_id")
+		teamId = c.Param("team_id")
+	)
+
+	userId, ok := claimsUserID(c)
+	if !ok {
+		rd := utility.BuildErrorResponse(http.StatusBadRequest, "error", "unable to get user claims", nil, nil)
+		c.JSON(http.StatusBadRequest, rd)
+		return
+	}
+
+	respData, code, err := service.GetTeamResources(orgId, teamId, userId, base.Db.Postgresql)
+	if err != nil {
+		rd := utility.BuildErrorResponse(code, "error", err.Error(), err, nil)
+		c.JSON(code, rd)
+		return
+	}
+
+	base.Logger.Info("team resources fetched successfully")
+	rd := utility.BuildSuccessResponse(http.StatusOK, "Team resources retrieved successfully", respData)
+	c.JSON(http.StatusOK, rd)
+}
+
+func (base *Controller) ShareTeamResource(c *gin.Context) {
+	var (
+		orgId  = c.Param("org_id")
+		teamId = c.Param("team_id")
+		req    = models.ShareTeamResourceRequestModel{}
+	)
+
+	err := c.ShouldBind(&req)
+	if err != nil {
+		rd := utility.BuildErrorResponse(http.StatusBadRequest, "error", "Failed to parse request body", err, nil)
+		c.JSON(http.StatusBadRequest, rd)
+		return
+	}
+
+	err = base.Validator.Struct(&req)
+	if err != nil {
+		rd := utility.BuildErrorResponse(http.StatusUnprocessableEntity, "error", "Validation failed", utility.ValidationResponse(err, base.Validator), nil)
+		c.JSON(http.StatusUnprocessableEntity, rd)
+		return
+	}
+
+	userId, ok := claimsUserID(c)
+	if !ok {
+		rd := utility.BuildErrorResponse(http.StatusBadRequest, "error", "unable to get user claims", nil, nil)
+		c.JSON(http.StatusBadRequest, rd)
+		return
+	}
+
+	respData, code, err := service.ShareTeamResource(req, orgId, teamId, userId, base.Db.Postgresql)
+	if err != nil {
+		rd := utility.BuildErrorResponse(code, "error", err.Error(), err, nil)
+		c.JSON(code, rd)
+		return
+	}
+
+	base.Logger.Info("resource shared with team successfully")
+	rd := utility.BuildSuccessResponse(http.StatusCreated, "Resource shared with team successfully", respData)
+	c.JSON(http.StatusCreated, rd)
+}
+
+func (base *Controller) UnshareTeamResource(c *gin.Context) {
+	var (
+		orgId   = c.Param("org_id")
+		teamId  = c.Param("team_id")
+		shareId = c.Param("share_id")
+	)
+
+	userId, ok := claimsUserID(c)
+	if !ok {
+		rd := utility.BuildErrorResponse(http.StatusBadRequest, "error", "unable to get user claims", nil, nil)
+		c.JSON(http.StatusBadRequest, rd)
+		return
+	}
+
+	respData, code, err := service.UnshareTeamResource(orgId, teamId, shareId, userId, base.Db.Postgresql)
+	if err != nil {
+		rd := utility.BuildErrorResponse(code, "error", err.Error(), err, nil)
+		c.JSON(code, rd)
+		return
+	}
+
+	base.Logger.Info("resource unshared from team successfully")
+	rd := utility.BuildSuccessResponse(http.StatusOK, "Resource no longer shared with team", respData)
+	c.JSON(http.StatusOK, rd)
+}
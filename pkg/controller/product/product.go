@@ -45,8 +45,8 @@ func (base *Controller) CreateProduct(c *gin.Context) {
 
 	respData, code, err := product.CreateProduct(req, base.Db.Postgresql, c)
 	if err != nil {
-		rd := utility.BuildErrorResponse(http.StatusBadRequest, "error", err.Error(), err, nil)
-		c.JSON(http.StatusBadRequest, rd)
+		rd := utility.BuildErrorResponse(code, "error", err.Error(), err, nil)
+		c.JSON(code, rd)
 		return
 	}
 
@@ -77,8 +77,8 @@ func (base *Controller) DeleteProductController(ctx *gin.Context) {
 
 	respData, code, err := product.DeleteProduct(req, base.Db.Postgresql, ctx)
 	if err != nil {
-		rd := utility.BuildErrorResponse(http.StatusBadRequest, "error", err.Error(), err, nil)
-		ctx.JSON(http.StatusBadRequest, rd)
+		rd := utility.BuildErrorResponse(code, "error", err.Error(), err, nil)
+		ctx.JSON(code, rd)
 		return
 	}
 
@@ -104,7 +104,7 @@ func (base *Controller) GetProduct(c *gin.Context) {
 		return
 	}
 
-	respData, code, err := product.GetProduct(productId, base.Db.Postgresql)
+	respData, code, err := product.GetProduct(productId, base.Db.Postgresql, c)
 	if err != nil {
 		rd := utility.BuildErrorResponse(code, "error", err.Error(), "Product not found", nil)
 		c.JSON(code, rd)
@@ -138,8 +138,8 @@ func (base *Controller) UpdateProduct(c *gin.Context) {
 
 	respData, code, err := product.UpdateProduct(req, base.Db.Postgresql, c)
 	if err != nil {
-		rd := utility.BuildErrorResponse(http.StatusBadRequest, "error", err.Error(), err, nil)
-		c.JSON(http.StatusBadRequest, rd)
+		rd := utility.BuildErrorResponse(code, "error", err.Error(), err, nil)
+		c.JSON(code, rd)
 		return
 	}
 
@@ -226,10 +226,10 @@ func (base *Controller) UploadImage(ctx *gin.Context) {
 		return
 	}
 
-	respData, code, err := product.UploadImage(productId, image, base.Db.Postgresql)
+	respData, code, err := product.UploadImage(productId, image, base.Db.Postgresql, ctx)
 	if err != nil {
-		rd := utility.BuildErrorResponse(http.StatusBadRequest, "error", err.Error(), err, nil)
-		ctx.JSON(http.StatusBadRequest, rd)
+		rd := utility.BuildErrorResponse(code, "error", err.Error(), err, nil)
+		ctx.JSON(code, rd)
 		return
 	}
 
@@ -84,17 +84,42 @@ func (base *Controller) DeleteAUser(c *gin.Context) {
 		userID = c.Param("user_id")
 	)
 
-	code, err := service.DeleteAUser(userID, base.Db.Postgresql, c)
+	respData, code, err := service.DeleteAUser(userID, base.Db.Postgresql, c)
 	if err != nil {
-		rd := utility.BuildErrorResponse(code, "error", err.Error(), nil, nil)
+		rd := utility.BuildErrorResponse(code, "error", err.Error(), nil, respData)
 		c.JSON(code, rd)
 		return
 	}
 
+	if code == http.StatusAccepted {
+		base.Logger.Info("account scheduled for deletion")
+		rd := utility.BuildSuccessResponse(http.StatusAccepted, "account scheduled for deletion", respData)
+		c.JSON(http.StatusAccepted, rd)
+		return
+	}
+
 	rd := utility.BuildSuccessResponse(http.StatusOK, "User deleted successfully", nil)
 	c.JSON(http.StatusOK, rd)
 }
 
+func (base *Controller) CancelAccountDeletion(c *gin.Context) {
+	var (
+		userID = c.Param("user_id")
+	)
+
+	respData, code, err := service.CancelAccountDeletion(userID, base.Db.Postgresql, c)
+	if err != nil {
+		rd := utility.BuildErrorResponse(code, "error", err.Error(), err, nil)
+		c.JSON(code, rd)
+		return
+	}
+
+	base.Logger.Info("account deletion cancelled")
+
+	rd := utility.BuildSuccessResponse(http.StatusOK, "account deletion cancelled", respData)
+	c.JSON(http.StatusOK, rd)
+}
+
 func (base *Controller) UpdateAUser(c *gin.Context) {
 	var (
 		userID = c.Param("user_id")
@@ -3,6 +3,7 @@ package middleware
 import (
 	"net/http"
 	"strings"
+	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/golang-jwt/jwt"
@@ -12,6 +13,9 @@ import (
 	"github.com/hngprojects/hng_boilerplate_golang_web/utility"
 )
 
+// sessionLastUsedInterval throttles how often a session's last use is written.
+const sessionLastUsedInterval = time.Minute
+
 func Authorize(db *gorm.DB, inputRole ...models.RoleId) gin.HandlerFunc {
 	// if no role is passed it would assume default user role
 	return func(c *gin.Context) {
@@ -33,6 +37,23 @@ func Authorize(db *gorm.DB, inputRole ...models.RoleId) gin.HandlerFunc {
 			return
 		}
 
+		if IsPersonalAccessToken(tokenStr) {
+			claims, code, err := personalAccessTokenClaims(db, tokenStr, c.Request.Method)
+			if err != nil {
+				c.AbortWithStatusJSON(code, utility.BuildErrorResponse(code, "error", err.Error(), "Unauthorized", nil))
+				return
+			}
+
+			if !roleAuthorized(claims, inputRole) {
+				c.AbortWithStatusJSON(http.StatusUnauthorized, utility.BuildErrorResponse(http.StatusUnauthorized, "error", "role not authorized!", "Unauthorized", nil))
+				return
+			}
+
+			c.Set("userClaims", claims)
+			c.Next()
+			return
+		}
+
 		token, err := TokenValid(tokenStr)
 		if err != nil {
 			r := utility.BuildErrorResponse(http.StatusUnauthorized, "error", "Token is invalid!", "Unauthorized", nil)
@@ -44,6 +65,12 @@ func Authorize(db *gorm.DB, inputRole ...models.RoleId) gin.HandlerFunc {
 
 		claims := token.Claims.(jwt.MapClaims)
 
+		// reject 2fa challenge tokens and any other non session token
+		if !IsAccessToken(claims) {
+			c.AbortWithStatusJSON(http.StatusUnauthorized, utility.BuildErrorResponse(http.StatusUnauthorized, "error", "Token is invalid!", "Unauthorized", nil))
+			return
+		}
+
 		// check if user id exists and fetch it
 		userID, ok := claims["user_id"].(string) //convert the interface to string
 		if !ok {
@@ -74,17 +101,7 @@ func Authorize(db *gorm.DB, inputRole ...models.RoleId) gin.HandlerFunc {
 
 		// compare user role
 
-		userRole := int(claims["role"].(float64)) //check if token is authorised for middleware
-		var authorizedRole bool
-
-		for _, role := range inputRole {
-			if int(role) == userRole {
-				authorizedRole = true
-				break
-			}
-		}
-
-		if !authorizedRole && len(inputRole) > 0 {
+		if !roleAuthorized(claims, inputRole) {
 			c.AbortWithStatusJSON(http.StatusUnauthorized, utility.BuildErrorResponse(http.StatusUnauthorized, "error", "role not authorized!", "Unauthorized", nil))
 			return
 		}
@@ -97,6 +114,10 @@ func Authorize(db *gorm.DB, inputRole ...models.RoleId) gin.HandlerFunc {
 			return
 		}
 
+		// record session activity for the session management endpoints
+
+		_ = access_token.TouchLastUsed(db, sessionLastUsedInterval)
+
 		// store user claims in Context
 		// for accesiblity in controller
 
@@ -108,7 +129,32 @@ func Authorize(db *gorm.DB, inputRole ...models.RoleId) gin.HandlerFunc {
 	}
 }
 
+// roleAuthorized compares the role of the claims with the roles a route allows.
+// No roles allows every role.
+func roleAuthorized(claims jwt.MapClaims, inputRole []models.RoleId) bool {
+	if len(inputRole) == 0 {
+		return true
+	}
+
+	userRole := int(claims["role"].(float64)) //check if token is authorised for middleware
+
+	for _, role := range inputRole {
+		if int(role) == userRole {
+			return true
+		}
+	}
+
+	return false
+}
+
 func GetIdFromToken(c *gin.Context) (string, interface{}) {
+	// prefer the claims Authorize already verified, they also cover personal access tokens
+	if claims, exists := c.Get("userClaims"); exists {
+		if id, ok := claims.(jwt.MapClaims)["user_id"].(string); ok {
+			return id, ""
+		}
+	}
+
 	var tokenStr string
 	bearerToken := c.GetHeader("Authorization")
 	strArr := strings.Split(bearerToken, " ")
@@ -130,9 +176,13 @@ func GetIdFromToken(c *gin.Context) (string, interface{}) {
 	// access user claims
 
 	claims := token.Claims.(jwt.MapClaims)
+	if !IsAccessToken(claims) {
+		return "", utility.BuildErrorResponse(http.StatusUnauthorized, "error", "Token is invalid!", "Unauthorized", nil)
+	}
+
 	id, ok := claims["user_id"].(string)
 	if !ok {
 		return "", utility.BuildErrorResponse(http.StatusForbidden, "error", "Forbidden", "Unauthorized", nil)
 	}
 	return id, ""
-}
\ No newline at end of file
+}
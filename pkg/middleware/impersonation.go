@@ -0,0 +1,41 @@
+package middleware
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang-jwt/jwt"
+
+	"github.com/hngprojects/hng_boilerplate_golang_web/utility"
+)
+
+// ImpersonatorID returns the superadmin named by the act claim of an
+// impersonation token, or false for a token the user signed in for.
+func ImpersonatorID(claims jwt.MapClaims) (string, bool) {
+	act, ok := claims["act"].(map[string]interface{})
+	if !ok {
+		return "", false
+	}
+
+	actorID, ok := act["sub"].(string)
+	return actorID, ok && actorID != ""
+}
+
+// BlockImpersonation rejects impersonated requests. It must run after Authorize
+// and guards routes that change how the user signs in or remove the account.
+func BlockImpersonation() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		claims, exists := c.Get("userClaims")
+		if !exists {
+			c.AbortWithStatusJSON(http.StatusUnauthorized, utility.BuildErrorResponse(http.StatusUnauthorized, "error", "Token is invalid!", "Unauthorized", nil))
+			return
+		}
+
+		if _, impersonated := ImpersonatorID(claims.(jwt.MapClaims)); impersonated {
+			c.AbortWithStatusJSON(http.StatusForbidden, utility.BuildErrorResponse(http.StatusForbidden, "error", "this action is not allowed while impersonating a user", "Forbidden", nil))
+			return
+		}
+
+		c.Next()
+	}
+}
@@ -14,13 +14,38 @@ import (
 	"github.com/hngprojects/hng_boilerplate_golang_web/utility"
 )
 
+const (
+	AccessTokenType         = "access"
+	TwoFAChallengeTokenType = "2fa_challenge"
+)
+
 type TokenDetailDTO struct {
 	AccessUuid  string `json:"access_uuid"`
 	AccessToken string `json:"access_token"`
 	ExpiresAt   time.Time
 }
 
-func CreateToken(user models.User) (*TokenDetailDTO, error) {
+// Actor is the superadmin a token is issued to while impersonating its user.
+// The token carries an act claim naming the actor and expires after Duration.
+type Actor struct {
+	UserID   string
+	Duration time.Duration
+}
+
+func CreateToken(user models.User, actor ...Actor) (*TokenDetailDTO, error) {
+	if len(actor) > 0 {
+		return createAccessToken(user, utility.GenerateUUID(), &actor[0])
+	}
+	return createAccessToken(user, utility.GenerateUUID(), nil)
+}
+
+// CreateTokenForSession signs a new access token for an existing session, so a
+// refreshed token keeps the access uuid stored in models.AccessToken.
+func CreateTokenForSession(user models.User, accessUuid string) (*TokenDetailDTO, error) {
+	return createAccessToken(user, accessUuid, nil)
+}
+
+func createAccessToken(user models.User, accessUuid string, actor *Actor) (*TokenDetailDTO, error) {
 
 	var (
 		tokenData = &TokenDetailDTO{}
@@ -28,9 +53,15 @@ func CreateToken(user models.User) (*TokenDetailDTO, error) {
 		err       error
 	)
 
-	tokenData.ExpiresAt = time.Now().AddDate(0, 0, config.Server.AccessTokenExpireDuration) // token valid for env set days
-	tokenData.AccessUuid = user.ID
-	tokenData.AccessUuid = utility.GenerateUUID()
+	if config.Server.AccessTokenExpireMinutes > 0 {
+		tokenData.ExpiresAt = time.Now().Add(time.Duration(config.Server.AccessTokenExpireMinutes) * time.Minute)
+	} else {
+		tokenData.ExpiresAt = time.Now().AddDate(0, 0, config.Server.AccessTokenExpireDuration) // token valid for env set days
+	}
+	if actor != nil && time.Now().Add(actor.Duration).Before(tokenData.ExpiresAt) {
+		tokenData.ExpiresAt = time.Now().Add(actor.Duration)
+	}
+	tokenData.AccessUuid = accessUuid
 
 	//create token
 	userClaims := jwt.MapClaims{}
@@ -41,10 +72,49 @@ func CreateToken(user models.User) (*TokenDetailDTO, error) {
 	userClaims["role"] = user.Role
 	userClaims["exp"] = tokenData.ExpiresAt.Unix()
 	userClaims["authorised"] = true
+	userClaims["token_type"] = AccessTokenType
+	userClaims["jti"] = utility.GenerateUUID()
+
+	if actor != nil {
+		userClaims["act"] = map[string]interface{}{"sub": actor.UserID}
+	}
+
+	tokenData.AccessToken, err = signClaims(userClaims)
+	if err != nil {
+		return tokenData, err
+	}
+
+	return tokenData, nil
+}
 
-	token := jwt.NewWithClaims(jwt.SigningMethodHS256, userClaims)
+// CreateTwoFAChallengeToken mints a short-lived token proving the password step
+// of a login succeeded. It is not backed by an AccessToken session, so Authorize
+// rejects it everywhere; it can only be exchanged for a session with a TOTP code.
+func CreateTwoFAChallengeToken(user models.User) (*TokenDetailDTO, error) {
 
-	tokenData.AccessToken, err = token.SignedString([]byte(config.Server.Secret))
+	var (
+		tokenData = &TokenDetailDTO{}
+		config    = config.GetConfig()
+		duration  = config.App.TwoFAChallengeDuration
+		err       error
+	)
+
+	if duration <= 0 {
+		duration = 5
+	}
+
+	tokenData.ExpiresAt = time.Now().Add(time.Duration(duration) * time.Minute)
+	tokenData.AccessUuid = utility.GenerateUUID()
+
+	userClaims := jwt.MapClaims{}
+
+	userClaims["user_id"] = user.ID
+	userClaims["challenge_uuid"] = tokenData.AccessUuid
+	userClaims["exp"] = tokenData.ExpiresAt.Unix()
+	userClaims["authorised"] = false
+	userClaims["token_type"] = TwoFAChallengeTokenType
+
+	tokenData.AccessToken, err = signClaims(userClaims)
 	if err != nil {
 		return tokenData, err
 	}
@@ -52,16 +122,20 @@ func CreateToken(user models.User) (*TokenDetailDTO, error) {
 	return tokenData, nil
 }
 
+// IsAccessToken reports whether the claims belong to a session access token.
+// Tokens minted before token_type existed carry no type and are treated as access tokens.
+func IsAccessToken(claims jwt.MapClaims) bool {
+	tokenType, ok := claims["token_type"].(string)
+	if !ok {
+		return true
+	}
+	return tokenType == AccessTokenType
+}
+
 // verify token
 
 func verifyToken(tokenString string) (*jwt.Token, error) {
-	config := config.GetConfig()
-	token, err := jwt.Parse(tokenString, func(token *jwt.Token) (interface{}, error) {
-		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
-			return nil, fmt.Errorf("unexpected signing method: %v", token.Header["alg"])
-		}
-		return []byte(config.Server.Secret), nil
-	})
+	token, err := jwt.Parse(tokenString, verificationKey)
 	if err != nil {
 		return token, fmt.Errorf("Unauthorized")
 	}
@@ -98,4 +172,4 @@ func GetUserClaims(c *gin.Context, db *gorm.DB, theValue string) (interface{}, e
 
 	return userValue, nil
 
-}
\ No newline at end of file
+}
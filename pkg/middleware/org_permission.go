@@ -0,0 +1,64 @@
+package middleware
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang-jwt/jwt"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+
+	"github.com/hngprojects/hng_boilerplate_golang_web/internal/models"
+	"github.com/hngprojects/hng_boilerplate_golang_web/utility"
+)
+
+// RequireOrgPermission lets the request through only when the caller holds
+// permission in the organisation named by the :org_id path parameter. It must
+// run after Authorize.
+func RequireOrgPermission(db *gorm.DB, permission string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var org models.Organisation
+
+		claims, exists := c.Get("userClaims")
+		if !exists {
+			c.AbortWithStatusJSON(http.StatusUnauthorized, utility.BuildErrorResponse(http.StatusUnauthorized, "error", "Token is invalid!", "Unauthorized", nil))
+			return
+		}
+
+		userID, _ := claims.(jwt.MapClaims)["user_id"].(string)
+
+		orgID := c.Param("org_id")
+		if _, err := uuid.Parse(orgID); err != nil {
+			c.AbortWithStatusJSON(http.StatusBadRequest, utility.BuildErrorResponse(http.StatusBadRequest, "error", "invalid organisation id format", "Bad Request", nil))
+			return
+		}
+
+		if _, err := org.CheckOrgExists(orgID, db); err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				c.AbortWithStatusJSON(http.StatusNotFound, utility.BuildErrorResponse(http.StatusNotFound, "error", "organisation not found", "Not Found", nil))
+				return
+			}
+			c.AbortWithStatusJSON(http.StatusInternalServerError, utility.BuildErrorResponse(http.StatusInternalServerError, "error", err.Error(), "Internal Server Error", nil))
+			return
+		}
+
+		allowed, err := org.HasPermission(db, orgID, userID, permission)
+		if err != nil {
+			if errors.Is(err, models.ErrNotOrgMember) {
+				c.AbortWithStatusJSON(http.StatusForbidden, utility.BuildErrorResponse(http.StatusForbidden, "error", err.Error(), "Forbidden", nil))
+				return
+			}
+			c.AbortWithStatusJSON(http.StatusInternalServerError, utility.BuildErrorResponse(http.StatusInternalServerError, "error", err.Error(), "Internal Server Error", nil))
+			return
+		}
+
+		if !allowed {
+			c.AbortWithStatusJSON(http.StatusForbidden, utility.BuildErrorResponse(http.StatusForbidden, "error", fmt.Sprintf("missing organisation permission: %v", permission), "Forbidden", nil))
+			return
+		}
+
+		c.Next()
+	}
+}
@@ -0,0 +1,264 @@
+package middleware
+
+import (
+	"crypto"
+	"crypto/ed25519"
+	"crypto/rsa"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"math/big"
+	"os"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/golang-jwt/jwt"
+
+	"github.com/hngprojects/hng_boilerplate_golang_web/internal/config"
+)
+
+// SigningKey is one asymmetric key of the token key set. Retiring keys only
+// verify tokens that were signed before the last rotation.
+type SigningKey struct {
+	ID         string
+	Method     jwt.SigningMethod
+	PrivateKey crypto.PrivateKey
+	PublicKey  crypto.PublicKey
+}
+
+type signingKeySet struct {
+	active *SigningKey
+	keys   map[string]*SigningKey
+	// acceptHMAC is set while HS256 signs, or when tokens signed with the
+	// server secret are still accepted after moving to an asymmetric key.
+	acceptHMAC bool
+}
+
+type JWK struct {
+	Kty string `json:"kty"`
+	Kid string `json:"kid"`
+	Use string `json:"use"`
+	Alg string `json:"alg"`
+	Crv string `json:"crv,omitempty"`
+	N   string `json:"n,omitempty"`
+	E   string `json:"e,omitempty"`
+	X   string `json:"x,omitempty"`
+}
+
+type JWKSet struct {
+	Keys []JWK `json:"keys"`
+}
+
+var (
+	keySet     *signingKeySet
+	keySetLock sync.RWMutex
+)
+
+// LoadSigningKeys parses the configured key set. HS256 keeps signing with the
+// server secret and needs no keys.
+func LoadSigningKeys(server config.ServerConfiguration) error {
+	set, err := parseSigningKeySet(server)
+	if err != nil {
+		return err
+	}
+
+	keySetLock.Lock()
+	keySet = set
+	keySetLock.Unlock()
+	return nil
+}
+
+func getSigningKeySet() (*signingKeySet, error) {
+	keySetLock.RLock()
+	set := keySet
+	keySetLock.RUnlock()
+	if set != nil {
+		return set, nil
+	}
+
+	if err := LoadSigningKeys(config.GetConfig().Server); err != nil {
+		return nil, err
+	}
+
+	keySetLock.RLock()
+	defer keySetLock.RUnlock()
+	return keySet, nil
+}
+
+func parseSigningKeySet(server config.ServerConfiguration) (*signingKeySet, error) {
+	var (
+		set    = &signingKeySet{keys: map[string]*SigningKey{}}
+		method jwt.SigningMethod
+	)
+
+	switch strings.ToUpper(server.JWTAlgorithm) {
+	case "", "HS256":
+		set.acceptHMAC = true
+		return set, nil
+	case "RS256":
+		method = jwt.SigningMethodRS256
+	case "EDDSA":
+		method = jwt.SigningMethodEdDSA
+	default:
+		return nil, fmt.Errorf("unsupported jwt algorithm: %v", server.JWTAlgorithm)
+	}
+
+	if server.JWTActiveKeyID == "" || server.JWTActiveKey == "" {
+		return nil, errors.New("jwt active key id and key are required for " + method.Alg())
+	}
+
+	active, err := parseSigningKey(server.JWTActiveKeyID, server.JWTActiveKey, method)
+	if err != nil {
+		return nil, err
+	}
+	if active.PrivateKey == nil {
+		return nil, fmt.Errorf("jwt active key %v must be a private key", active.ID)
+	}
+
+	set.active = active
+	set.keys[active.ID] = active
+	set.acceptHMAC = server.JWTAcceptLegacyHS256
+
+	for kid, value := range server.JWTRetiringKeys {
+		if _, exists := set.keys[kid]; exists {
+			return nil, fmt.Errorf("duplicate jwt key id: %v", kid)
+		}
+
+		key, err := parseSigningKey(kid, value, method)
+		if err != nil {
+			return nil, err
+		}
+		set.keys[kid] = key
+	}
+
+	return set, nil
+}
+
+// parseSigningKey reads a PEM encoded key, either inline or from a file path.
+// Retiring keys may be given as public keys only.
+func parseSigningKey(kid, value string, method jwt.SigningMethod) (*SigningKey, error) {
+	pemData := []byte(strings.ReplaceAll(value, `\n`, "\n"))
+	if !strings.HasPrefix(strings.TrimSpace(value), "-----BEGIN") {
+		data, err := os.ReadFile(value)
+		if err != nil {
+			return nil, fmt.Errorf("unable to read jwt key %v: %v", kid, err)
+		}
+		pemData = data
+	}
+
+	key := &SigningKey{ID: kid, Method: method}
+
+	switch method {
+	case jwt.SigningMethodRS256:
+		if private, err := jwt.ParseRSAPrivateKeyFromPEM(pemData); err == nil {
+			key.PrivateKey, key.PublicKey = private, &private.PublicKey
+			return key, nil
+		}
+		public, err := jwt.ParseRSAPublicKeyFromPEM(pemData)
+		if err != nil {
+			return nil, fmt.Errorf("invalid RSA key %v: %v", kid, err)
+		}
+		key.PublicKey = public
+	case jwt.SigningMethodEdDSA:
+		if private, err := jwt.ParseEdPrivateKeyFromPEM(pemData); err == nil {
+			key.PrivateKey, key.PublicKey = private, private.(ed25519.PrivateKey).Public()
+			return key, nil
+		}
+		public, err := jwt.ParseEdPublicKeyFromPEM(pemData)
+		if err != nil {
+			return nil, fmt.Errorf("invalid Ed25519 key %v: %v", kid, err)
+		}
+		key.PublicKey = public
+	}
+
+	return key, nil
+}
+
+// signClaims signs the claims with the active key, falling back to the server
+// secret when no asymmetric algorithm is configured.
+func signClaims(claims jwt.MapClaims) (string, error) {
+	set, err := getSigningKeySet()
+	if err != nil {
+		return "", err
+	}
+
+	if set.active == nil {
+		token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
+		return token.SignedString([]byte(config.GetConfig().Server.Secret))
+	}
+
+	token := jwt.NewWithClaims(set.active.Method, claims)
+	token.Header["kid"] = set.active.ID
+	return token.SignedString(set.active.PrivateKey)
+}
+
+// verificationKey picks the key for a token being parsed. Tokens carrying a kid
+// must match a key of the set with the same algorithm. Once an asymmetric key
+// is active, HMAC tokens are only accepted with the server secret while
+// JWTAcceptLegacyHS256 is set, so sessions can outlive the switch.
+func verificationKey(token *jwt.Token) (interface{}, error) {
+	set, err := getSigningKeySet()
+	if err != nil {
+		return nil, err
+	}
+
+	if _, ok := token.Method.(*jwt.SigningMethodHMAC); ok {
+		if _, hasKid := token.Header["kid"]; hasKid {
+			return nil, fmt.Errorf("unexpected kid for signing method: %v", token.Header["alg"])
+		}
+		if !set.acceptHMAC {
+			return nil, errors.New("hmac tokens are not accepted")
+		}
+		secret := config.GetConfig().Server.Secret
+		if secret == "" {
+			return nil, errors.New("hmac tokens are not accepted")
+		}
+		return []byte(secret), nil
+	}
+
+	kid, _ := token.Header["kid"].(string)
+	key, ok := set.keys[kid]
+	if !ok {
+		return nil, fmt.Errorf("unknown signing key: %v", kid)
+	}
+
+	if token.Method.Alg() != key.Method.Alg() {
+		return nil, fmt.Errorf("unexpected signing method: %v", token.Header["alg"])
+	}
+
+	return key.PublicKey, nil
+}
+
+// GetJWKS returns the public keys of the active and retiring keys.
+func GetJWKS() (JWKSet, error) {
+	jwks := JWKSet{Keys: []JWK{}}
+
+	set, err := getSigningKeySet()
+	if err != nil {
+		return jwks, err
+	}
+
+	for _, key := range set.keys {
+		jwk := JWK{Kid: key.ID, Use: "sig", Alg: key.Method.Alg()}
+
+		switch public := key.PublicKey.(type) {
+		case *rsa.PublicKey:
+			jwk.Kty = "RSA"
+			jwk.N = base64.RawURLEncoding.EncodeToString(public.N.Bytes())
+			jwk.E = base64.RawURLEncoding.EncodeToString(big.NewInt(int64(public.E)).Bytes())
+		case ed25519.PublicKey:
+			jwk.Kty = "OKP"
+			jwk.Crv = "Ed25519"
+			jwk.X = base64.RawURLEncoding.EncodeToString(public)
+		default:
+			continue
+		}
+
+		jwks.Keys = append(jwks.Keys, jwk)
+	}
+
+	sort.Slice(jwks.Keys, func(i, j int) bool { return jwks.Keys[i].Kid < jwks.Keys[j].Kid })
+
+	return jwks, nil
+}
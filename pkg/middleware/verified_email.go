@@ -0,0 +1,44 @@
+package middleware
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang-jwt/jwt"
+	"gorm.io/gorm"
+
+	"github.com/hngprojects/hng_boilerplate_golang_web/internal/config"
+	"github.com/hngprojects/hng_boilerplate_golang_web/internal/models"
+	"github.com/hngprojects/hng_boilerplate_golang_web/pkg/repository/storage/postgresql"
+	"github.com/hngprojects/hng_boilerplate_golang_web/utility"
+)
+
+// RequireVerifiedEmail guards sensitive routes from users who have not verified
+// their email address. It must run after Authorize and only applies when email
+// verification is enforced.
+func RequireVerifiedEmail(db *gorm.DB) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		switch config.GetConfig().App.EmailVerificationRequired {
+		case config.EmailVerificationOnLogin, config.EmailVerificationOnRoutes:
+		default:
+			c.Next()
+			return
+		}
+
+		claims, exists := c.Get("userClaims")
+		if !exists {
+			c.AbortWithStatusJSON(http.StatusUnauthorized, utility.BuildErrorResponse(http.StatusUnauthorized, "error", "Token is invalid!", "Unauthorized", nil))
+			return
+		}
+
+		userID, _ := claims.(jwt.MapClaims)["user_id"].(string)
+
+		var user models.User
+		if !postgresql.CheckExists(db, &user, "id = ?", userID) || !user.IsVerified {
+			c.AbortWithStatusJSON(http.StatusForbidden, utility.BuildErrorResponse(http.StatusForbidden, "error", "email address is not verified", "Forbidden", nil))
+			return
+		}
+
+		c.Next()
+	}
+}
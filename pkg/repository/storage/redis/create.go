@@ -29,3 +29,27 @@ func PushToQueue(rdb *redis.Client, value interface{}) error {
 
 	return nil
 }
+
+func RedisSetWithExpiry(rdb *redis.Client, key string, value interface{}, expiry time.Duration) error {
+	serialized, err := json.Marshal(value)
+	if err != nil {
+		return err
+	}
+	return rdb.Set(Ctx, key, serialized, expiry).Err()
+}
+
+// RedisIncr increments a counter and starts its expiry window on the first increment.
+func RedisIncr(rdb *redis.Client, key string, expiry time.Duration) (int64, error) {
+	count, err := rdb.Incr(Ctx, key).Result()
+	if err != nil {
+		return 0, err
+	}
+
+	if count == 1 {
+		if err := rdb.Expire(Ctx, key, expiry).Err(); err != nil {
+			return count, err
+		}
+	}
+
+	return count, nil
+}
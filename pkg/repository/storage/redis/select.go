@@ -3,6 +3,7 @@ package redis
 import (
 	"encoding/json"
 	"fmt"
+	"time"
 
 	"github.com/go-redis/redis/v8"
 )
@@ -27,3 +28,15 @@ func PopFromQueue(rdb *redis.Client) (interface{}, error) {
 
 	return response, nil
 }
+
+// RedisTTL returns the remaining lifetime of a key, or zero if it does not exist.
+func RedisTTL(rdb *redis.Client, key string) (time.Duration, error) {
+	ttl, err := rdb.TTL(Ctx, key).Result()
+	if err != nil {
+		return 0, err
+	}
+	if ttl < 0 {
+		return 0, nil
+	}
+	return ttl, nil
+}
@@ -28,17 +28,66 @@ func Auth(r *gin.Engine, ApiVersion string, validator *validator.Validate, db *s
 		authUrl.POST("/password-reset/verify", auth.VerifyResetToken)
 		authUrl.POST("/magick-link", auth.RequestMagicLink)
 		authUrl.POST("/magick-link/verify", auth.VerifyMagicLink)
-		authUrl.POST("/2fa/enable", key.CreateKey)
-		authUrl.POST("/2fa/verify", key.VerifyKey)
+		authUrl.POST("/otp/request", auth.RequestLoginOTP)
+		authUrl.POST("/otp/verify", auth.VerifyLoginOTP)
+		authUrl.POST("/2fa/login", auth.VerifyTwoFALogin)
+		authUrl.POST("/2fa/webauthn/begin", auth.BeginWebAuthnTwoFA)
+		authUrl.POST("/2fa/webauthn/finish", auth.FinishWebAuthnTwoFA)
+		authUrl.POST("/webauthn/login/begin", auth.BeginWebAuthnLogin)
+		authUrl.POST("/webauthn/login/finish", auth.FinishWebAuthnLogin)
+		authUrl.POST("/token/refresh", auth.RefreshAccessToken)
+		authUrl.POST("/email/verify", auth.VerifyEmail)
+		authUrl.POST("/email/verify/resend", auth.ResendEmailVerification)
+		authUrl.POST("/email/change/confirm", auth.ConfirmEmailChange)
+		authUrl.POST("/email/change/cancel", auth.CancelEmailChange)
+		authUrl.POST("/unlock", auth.UnlockAccount)
+		authUrl.GET("/oauth/providers", auth.GetOAuthProviders)
+		authUrl.GET("/oauth/:provider/authorize", auth.AuthorizeOAuth)
+		authUrl.POST("/oauth/callback", auth.OAuthCallback)
 	}
 
 	authUrlSec := r.Group(fmt.Sprintf("%v/auth", ApiVersion),
-		middleware.Authorize(db.Postgresql, models.RoleIdentity.SuperAdmin, models.RoleIdentity.User))
+		middleware.Authorize(db.Postgresql, models.RoleIdentity.SuperAdmin, models.RoleIdentity.User),
+		middleware.RequireSession())
 	{
 		authUrlSec.POST("/logout", auth.LogoutUser)
-		authUrlSec.PUT("/change-password", auth.ChangePassword)
+		authUrlSec.PUT("/change-password", middleware.BlockImpersonation(), middleware.RequireVerifiedEmail(db.Postgresql), auth.ChangePassword)
+		authUrlSec.POST("/email/change", middleware.BlockImpersonation(), auth.RequestEmailChange)
+		authUrlSec.POST("/2fa/enable", middleware.BlockImpersonation(), middleware.RequireVerifiedEmail(db.Postgresql), key.CreateKey)
+		authUrlSec.POST("/2fa/verify", middleware.BlockImpersonation(), key.VerifyKey)
+		authUrlSec.POST("/2fa/recovery-codes", middleware.BlockImpersonation(), key.RegenerateRecoveryCodes)
+		authUrlSec.POST("/2fa/disable", middleware.BlockImpersonation(), key.DisableTwoFA)
+		authUrlSec.GET("/sessions", auth.GetSessions)
+		authUrlSec.DELETE("/sessions", auth.RevokeOtherSessions)
+		authUrlSec.DELETE("/sessions/:session_id", auth.RevokeSession)
+		authUrlSec.POST("/personal-access-tokens", middleware.BlockImpersonation(), auth.CreatePersonalAccessToken)
+		authUrlSec.GET("/personal-access-tokens", auth.GetPersonalAccessTokens)
+		authUrlSec.DELETE("/personal-access-tokens/:token_id", auth.RevokePersonalAccessToken)
+		authUrlSec.POST("/oauth/:provider/link", middleware.BlockImpersonation(), auth.LinkOAuth)
+		authUrlSec.POST("/oauth/link/callback", middleware.BlockImpersonation(), auth.LinkOAuthCallback)
+		authUrlSec.GET("/identities", auth.GetSocialIdentities)
+		authUrlSec.DELETE("/identities/:identity_id", middleware.BlockImpersonation(), auth.UnlinkSocialIdentity)
+		authUrlSec.POST("/webauthn/register/begin", middleware.BlockImpersonation(), auth.BeginWebAuthnRegistration)
+		authUrlSec.POST("/webauthn/register/finish", middleware.BlockImpersonation(), auth.FinishWebAuthnRegistration)
+		authUrlSec.GET("/webauthn/credentials", auth.GetWebAuthnCredentials)
+		authUrlSec.PATCH("/webauthn/credentials/:credential_id", middleware.BlockImpersonation(), auth.RenameWebAuthnCredential)
+		authUrlSec.DELETE("/webauthn/credentials/:credential_id", middleware.BlockImpersonation(), auth.DeleteWebAuthnCredential)
+		authUrlSec.POST("/impersonation/stop", auth.StopImpersonation)
 	}
 
+	authUrlAdmin := r.Group(fmt.Sprintf("%v/auth", ApiVersion),
+		middleware.Authorize(db.Postgresql, models.RoleIdentity.SuperAdmin),
+		middleware.RequireSession())
+	{
+		authUrlAdmin.GET("/users/:user_id/sessions", auth.GetUserSessions)
+		authUrlAdmin.DELETE("/users/:user_id/sessions", auth.RevokeAllUserSessions)
+		authUrlAdmin.DELETE("/users/:user_id/sessions/:session_id", auth.RevokeUserSession)
+		authUrlAdmin.POST("/users/:user_id/unlock", auth.UnlockUserAccount)
+		authUrlAdmin.POST("/users/:user_id/impersonate", auth.StartImpersonation)
+	}
+
+	r.GET("/.well-known/jwks.json", auth.JWKS)
+
 	authSocial := r.Group(fmt.Sprintf("%v/auth", ApiVersion))
 	{
 		authSocial.POST("/google", auth.GoogleLogin)
@@ -7,7 +7,9 @@ import (
 	"github.com/go-playground/validator/v10"
 
 	"github.com/hngprojects/hng_boilerplate_golang_web/external/request"
+	"github.com/hngprojects/hng_boilerplate_golang_web/internal/models"
 	"github.com/hngprojects/hng_boilerplate_golang_web/pkg/controller/billing"
+	"github.com/hngprojects/hng_boilerplate_golang_web/pkg/middleware"
 	"github.com/hngprojects/hng_boilerplate_golang_web/pkg/repository/storage"
 	"github.com/hngprojects/hng_boilerplate_golang_web/utility"
 )
@@ -17,13 +19,17 @@ func Billing(r *gin.Engine, ApiVersion string, validator *validator.Validate, db
 	billing := billing.Controller{Db: db, Validator: validator, Logger: logger, ExtReq: extReq}
 
 	billingUrl := r.Group(fmt.Sprintf("%v", ApiVersion))
+	billingAdminUrl := r.Group(fmt.Sprintf("%v", ApiVersion), middleware.Authorize(db.Postgresql, models.RoleIdentity.SuperAdmin), middleware.RequireSession())
 
 	{
-		billingUrl.POST("/billing-plans", billing.CreateBilling)
-		billingUrl.DELETE("/billing-plans/:id", billing.DeleteBilling)
+		billingAdminUrl.POST("/billing-plans", billing.CreateBilling)
+		billingAdminUrl.DELETE("/billing-plans/:id", billing.DeleteBilling)
+		billingAdminUrl.PATCH("/billing-plans/:id", billing.UpdateBillingById)
+		billingAdminUrl.POST("/billing-plans/:id/archive", billing.ArchiveBilling)
+		billingAdminUrl.POST("/billing-plans/:id/restore", billing.RestoreBilling)
+		billingAdminUrl.GET("/billing-plans/:id/audit-logs", billing.GetBillingAuditLogs)
 		billingUrl.GET("/billing-plans", billing.GetBillings)
 		billingUrl.GET("/billing-plans/:id", billing.GetBillingById)
-		billingUrl.PATCH("/billing-plans/:id", billing.UpdateBillingById)
 	}
 
 	return r
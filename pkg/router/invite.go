@@ -7,6 +7,7 @@ import (
 	"github.com/go-playground/validator/v10"
 
 	"github.com/hngprojects/hng_boilerplate_golang_web/external/request"
+	"github.com/hngprojects/hng_boilerplate_golang_web/internal/models"
 	"github.com/hngprojects/hng_boilerplate_golang_web/pkg/controller/invite"
 
 	"github.com/hngprojects/hng_boilerplate_golang_web/pkg/middleware"
@@ -22,7 +23,7 @@ func Invite(r *gin.Engine, ApiVersion string, validator *validator.Validate, db
 	{
 		{
 			inviteUrl.POST("/invite/create", invite.CreateInvite)
-			inviteUrl.POST("/organization/send-invite", middleware.RateLimiter(), invite.PostInvite)
+			inviteUrl.POST("/organization/send-invite", middleware.RequireVerifiedEmail(db.Postgresql), middleware.RateLimiter(), invite.PostInvite)
 			inviteUrl.POST("/invite/accept", invite.PostAcceptInvite)
 		}
 
@@ -32,6 +33,18 @@ func Invite(r *gin.Engine, ApiVersion string, validator *validator.Validate, db
 			inviteUrl.GET("/invite/accept/:t", invite.GetAcceptInvite)
 		}
 
+		{
+			inviteUrl.GET("/organizations/:org_id/invitations", middleware.RequireOrgPermission(db.Postgresql, models.PermInviteMembers), invite.GetOrgInvitations)
+			inviteUrl.DELETE("/organizations/:org_id/invitations/:invitation_id", middleware.RequireOrgPermission(db.Postgresql, models.PermInviteMembers), invite.RevokeInvitation)
+			inviteUrl.POST("/organizations/:org_id/invitations/:invitation_id/resend", middleware.RequireOrgPermission(db.Postgresql, models.PermInviteMembers), invite.ResendInvitation)
+		}
+
+	}
+
+	// holding the link is enough to decline, so invitees without an account can
+	inviteUrlPublic := r.Group(fmt.Sprintf("%v", ApiVersion))
+	{
+		inviteUrlPublic.POST("/invite/decline", invite.DeclineInvite)
 	}
 	return r
 }
\ No newline at end of file
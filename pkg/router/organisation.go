@@ -20,23 +20,55 @@ func Organisation(r *gin.Engine, ApiVersion string, validator *validator.Validat
 
 	organisationUrl := r.Group(fmt.Sprintf("%v", ApiVersion), middleware.Authorize(db.Postgresql, models.RoleIdentity.SuperAdmin, models.RoleIdentity.User))
 	{
-		organisationUrl.POST("/organizations", organisation.CreateOrganisation)
+		organisationUrl.POST("/organizations", middleware.RequireVerifiedEmail(db.Postgresql), organisation.CreateOrganisation)
 		organisationUrl.GET("/organizations/:org_id", organisation.GetOrganisation)
-		organisationUrl.DELETE("/organizations/:org_id", organisation.DeleteOrganisation)
-		organisationUrl.PATCH("/organizations/:org_id", organisation.UpdateOrganisation)
+		organisationUrl.DELETE("/organizations/:org_id", middleware.RequireSession(), middleware.RequireOrgPermission(db.Postgresql, models.PermDeleteOrganisation), organisation.DeleteOrganisation)
+		organisationUrl.PATCH("/organizations/:org_id", middleware.RequireOrgPermission(db.Postgresql, models.PermEditOrganisation), organisation.UpdateOrganisation)
 		organisationUrl.GET("/organizations/:org_id/users", organisation.GetUsersInOrganisation)
-		organisationUrl.POST("/organizations/:org_id/roles", organisation.CreateOrgRole)
-		organisationUrl.GET("/organizations/:org_id/roles", organisation.GetOrgRoles)
-		organisationUrl.GET("/organizations/:org_id/roles/:role_id", organisation.GetAOrgRole)
-		organisationUrl.DELETE("/organizations/:org_id/roles/:role_id", organisation.DeleteOrgRole)
-		organisationUrl.PATCH("/organizations/:org_id/roles/:role_id", organisation.UpdateOrgRole)
-		organisationUrl.PATCH("/organizations/:org_id/roles/:role_id/permissions", organisation.UpdateOrgPermissions)
+		organisationUrl.GET("/permissions", organisation.GetPermissionCatalog)
+		organisationUrl.POST("/organizations/:org_id/roles", middleware.RequireOrgPermission(db.Postgresql, models.PermManageRoles), organisation.CreateOrgRole)
+		organisationUrl.GET("/organizations/:org_id/roles", middleware.RequireOrgPermission(db.Postgresql, models.PermViewRoles), organisation.GetOrgRoles)
+		organisationUrl.GET("/organizations/:org_id/roles/:role_id", middleware.RequireOrgPermission(db.Postgresql, models.PermViewRoles), organisation.GetAOrgRole)
+		organisationUrl.DELETE("/organizations/:org_id/roles/:role_id", middleware.RequireOrgPermission(db.Postgresql, models.PermManageRoles), organisation.DeleteOrgRole)
+		organisationUrl.PATCH("/organizations/:org_id/roles/:role_id", middleware.RequireOrgPermission(db.Postgresql, models.PermManageRoles), organisation.UpdateOrgRole)
+		organisationUrl.PATCH("/organizations/:org_id/roles/:role_id/permissions", middleware.RequireOrgPermission(db.Postgresql, models.PermManageRoles), organisation.UpdateOrgPermissions)
+		organisationUrl.PUT("/organizations/:org_id/roles/:role_id/default", middleware.RequireOrgPermission(db.Postgresql, models.PermManageRoles), organisation.SetDefaultOrgRole)
+		organisationUrl.PUT("/organizations/:org_id/users/:user_id/role", middleware.RequireOrgPermission(db.Postgresql, models.PermManageMembers), organisation.AssignMemberRole)
+		organisationUrl.DELETE("/organizations/:org_id/users/:user_id/role", middleware.RequireOrgPermission(db.Postgresql, models.PermManageMembers), organisation.RemoveMemberRole)
+		organisationUrl.DELETE("/organizations/:org_id/users/:user_id", middleware.RequireSession(), middleware.RequireOrgPermission(db.Postgresql, models.PermManageMembers), organisation.RemoveMember)
+		organisationUrl.POST("/organizations/:org_id/leave", middleware.RequireSession(), middleware.BlockImpersonation(), organisation.LeaveOrganisation)
+		organisationUrl.POST("/organizations/:org_id/domains", middleware.RequireOrgPermission(db.Postgresql, models.PermEditOrganisation), organisation.ClaimOrgDomain)
+		organisationUrl.GET("/organizations/:org_id/domains", middleware.RequireOrgPermission(db.Postgresql, models.PermEditOrganisation), organisation.GetOrgDomains)
+		organisationUrl.POST("/organizations/:org_id/domains/:domain_id/verify", middleware.RequireOrgPermission(db.Postgresql, models.PermEditOrganisation), organisation.VerifyOrgDomain)
+		organisationUrl.PATCH("/organizations/:org_id/domains/:domain_id", middleware.RequireOrgPermission(db.Postgresql, models.PermEditOrganisation), organisation.UpdateOrgDomain)
+		organisationUrl.DELETE("/organizations/:org_id/domains/:domain_id", middleware.RequireOrgPermission(db.Postgresql, models.PermEditOrganisation), organisation.DeleteOrgDomain)
+		organisationUrl.GET("/organizations/:org_id/join-requests", middleware.RequireOrgPermission(db.Postgresql, models.PermInviteMembers), organisation.GetJoinRequests)
+		organisationUrl.POST("/organizations/:org_id/join-requests/:request_id/approve", middleware.RequireOrgPermission(db.Postgresql, models.PermInviteMembers), organisation.ApproveJoinRequest)
+		organisationUrl.POST("/organizations/:org_id/join-requests/:request_id/reject", middleware.RequireOrgPermission(db.Postgresql, models.PermInviteMembers), organisation.RejectJoinRequest)
+		organisationUrl.POST("/organizations/:org_id/teams", middleware.RequireOrgPermission(db.Postgresql, models.PermManageTeams), organisation.CreateTeam)
+		organisationUrl.GET("/organizations/:org_id/teams", organisation.GetTeams)
+		organisationUrl.GET("/organizations/:org_id/teams/:team_id", organisation.GetTeam)
+		organisationUrl.PATCH("/organizations/:org_id/teams/:team_id", middleware.RequireOrgPermission(db.Postgresql, models.PermManageTeams), organisation.UpdateTeam)
+		organisationUrl.DELETE("/organizations/:org_id/teams/:team_id", middleware.RequireOrgPermission(db.Postgresql, models.PermManageTeams), organisation.DeleteTeam)
+		organisationUrl.PUT("/organizations/:org_id/teams/:team_id/role", middleware.RequireOrgPermission(db.Postgresql, models.PermManageMembers), organisation.SetTeamRole)
+		organisationUrl.DELETE("/organizations/:org_id/teams/:team_id/role", middleware.RequireOrgPermission(db.Postgresql, models.PermManageMembers), organisation.RemoveTeamRole)
+		organisationUrl.GET("/organizations/:org_id/teams/:team_id/members", organisation.GetTeamMembers)
+		organisationUrl.POST("/organizations/:org_id/teams/:team_id/members", organisation.AddTeamMember)
+		organisationUrl.PATCH("/organizations/:org_id/teams/:team_id/members/:user_id", middleware.RequireOrgPermission(db.Postgresql, models.PermManageTeams), organisation.UpdateTeamMember)
+		organisationUrl.DELETE("/organizations/:org_id/teams/:team_id/members/:user_id", organisation.RemoveTeamMember)
+		organisationUrl.GET("/organizations/:org_id/teams/:team_id/resources", organisation.GetTeamResources)
+		organisationUrl.POST("/organizations/:org_id/teams/:team_id/resources", organisation.ShareTeamResource)
+		organisationUrl.DELETE("/organizations/:org_id/teams/:team_id/resources/:share_id", organisation.UnshareTeamResource)
+		organisationUrl.POST("/organizations/:org_id/transfer", middleware.RequireSession(), middleware.BlockImpersonation(), organisation.RequestOwnershipTransfer)
+		organisationUrl.DELETE("/organizations/:org_id/transfer", middleware.RequireSession(), middleware.BlockImpersonation(), organisation.CancelOwnershipTransfer)
+		organisationUrl.POST("/organizations/transfer/accept", middleware.RequireSession(), middleware.BlockImpersonation(), organisation.AcceptOwnershipTransfer)
 	}
 
 	organisationUrlSec := r.Group(fmt.Sprintf("%v", ApiVersion), middleware.Authorize(db.Postgresql, models.RoleIdentity.SuperAdmin))
 
 	{
 		organisationUrlSec.POST("/organizations/:org_id/users", organisation.AddUserToOrganisation)
+		organisationUrlSec.POST("/organizations/:org_id/transfer/force", middleware.RequireSession(), organisation.ForceOwnershipTransfer)
 	}
 	return r
 }
@@ -59,6 +59,7 @@ func Setup(logger *utility.Logger, validator *validator.Validate, db *storage.Da
 	Profile(r, ApiVersion, validator, db, logger)
 	Contact(r, ApiVersion, validator, db, logger)
 	NotificationSettings(r, ApiVersion, validator, db, logger)
+	Billing(r, ApiVersion, validator, db, logger)
 
 	r.GET("/", func(c *gin.Context) {
 		c.JSON(http.StatusOK, gin.H{
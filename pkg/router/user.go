@@ -19,17 +19,18 @@ func User(r *gin.Engine, ApiVersion string, validator *validator.Validate, db *s
 	user := user.Controller{Db: db, Validator: validator, Logger: logger, ExtReq: extReq}
 
 	userUrl := r.Group(fmt.Sprintf("%v", ApiVersion), middleware.Authorize(db.Postgresql, models.RoleIdentity.SuperAdmin, models.RoleIdentity.User))
-	adminUrl := r.Group(fmt.Sprintf("%v", ApiVersion), middleware.Authorize(db.Postgresql, models.RoleIdentity.SuperAdmin))
+	adminUrl := r.Group(fmt.Sprintf("%v", ApiVersion), middleware.Authorize(db.Postgresql, models.RoleIdentity.SuperAdmin), middleware.RequireSession())
 	{
 		userUrl.GET("/users/:user_id", user.GetAUser)
-		userUrl.DELETE("/users/:user_id", user.DeleteAUser)
+		userUrl.DELETE("/users/:user_id", middleware.RequireSession(), middleware.BlockImpersonation(), user.DeleteAUser)
+		userUrl.DELETE("/users/:user_id/deletion", middleware.RequireSession(), middleware.BlockImpersonation(), user.CancelAccountDeletion)
 		userUrl.PUT("/users/:user_id", user.UpdateAUser)
 		userUrl.GET("/organizations", user.GetAUserOrganisation)
-		userUrl.PUT("/users/:user_id/roles/:role_id", user.AssignRoleToUser)
+		userUrl.PUT("/users/:user_id/roles/:role_id", middleware.RequireSession(), user.AssignRoleToUser)
 		userUrl.PUT("/users/:user_id/regions", user.UpdateUserRegion)
 		userUrl.GET("/users/:user_id/regions", user.GetUserRegion)
 		userUrl.GET("/users/:user_id/data-privacy-settings", user.GetUserDataPrivacySettings)
-		userUrl.PUT("/users/:user_id/data-privacy-settings", user.UpdateUserDataPrivacySettings)
+		userUrl.PUT("/users/:user_id/data-privacy-settings", middleware.RequireSession(), user.UpdateUserDataPrivacySettings)
 	}
 	adminUrl.GET("/users", user.GetAllUsers)
 
@@ -10,13 +10,21 @@ import (
 type NotificationName string
 
 const (
-	SendWelcomeMail           NotificationName = "send_welcome_mail"
-	SendOTP                   NotificationName = "send_otp"
-	SendResetPasswordMail     NotificationName = "send_reset_password_mail"
-	SendEmailVerificationMail NotificationName = "send_email_verification_mail"
-	SendMagicLink             NotificationName = "send_magic_link"
-	SendSqueeze               NotificationName = "send_squeeze"
-	SendContactUsMail         NotificationName = "send_contact_us"
+	SendWelcomeMail            NotificationName = "send_welcome_mail"
+	SendOTP                    NotificationName = "send_otp"
+	SendResetPasswordMail      NotificationName = "send_reset_password_mail"
+	SendEmailVerificationMail  NotificationName = "send_email_verification_mail"
+	SendEmailVerifiedMail      NotificationName = "send_email_verified_mail"
+	SendEmailChangeConfirmMail NotificationName = "send_email_change_confirm_mail"
+	SendEmailChangeCancelMail  NotificationName = "send_email_change_cancel_mail"
+	SendAccountDeletionMail    NotificationName = "send_account_deletion_mail"
+	SendOwnershipTransferMail  NotificationName = "send_ownership_transfer_mail"
+	SendMemberRemovedMail      NotificationName = "send_member_removed_mail"
+	SendInvitationMail         NotificationName = "send_invitation_mail"
+	SendSuspiciousLoginMail    NotificationName = "send_suspicious_login_mail"
+	SendMagicLink              NotificationName = "send_magic_link"
+	SendSqueeze                NotificationName = "send_squeeze"
+	SendContactUsMail          NotificationName = "send_contact_us"
 )
 
 func Check() {
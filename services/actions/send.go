@@ -30,6 +30,30 @@ func Send(extReq request.ExternalRequest, db *gorm.DB, rdb *redis.Client, notifi
 		names.SendEmailVerificationMail: func() error {
 			return req.SendEmailVerificationMail()
 		},
+		names.SendEmailVerifiedMail: func() error {
+			return req.SendEmailVerifiedMail()
+		},
+		names.SendEmailChangeConfirmMail: func() error {
+			return req.SendEmailChangeConfirmMail()
+		},
+		names.SendEmailChangeCancelMail: func() error {
+			return req.SendEmailChangeCancelMail()
+		},
+		names.SendAccountDeletionMail: func() error {
+			return req.SendAccountDeletionMail()
+		},
+		names.SendOwnershipTransferMail: func() error {
+			return req.SendOwnershipTransferMail()
+		},
+		names.SendMemberRemovedMail: func() error {
+			return req.SendMemberRemovedMail()
+		},
+		names.SendInvitationMail: func() error {
+			return req.SendInvitationMail()
+		},
+		names.SendSuspiciousLoginMail: func() error {
+			return req.SendSuspiciousLoginMail()
+		},
 		names.SendMagicLink: func() error {
 			return req.SendMagicLink()
 		},
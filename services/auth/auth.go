@@ -11,7 +11,6 @@ import (
 	"gorm.io/gorm"
 
 	"github.com/hngprojects/hng_boilerplate_golang_web/internal/models"
-	"github.com/hngprojects/hng_boilerplate_golang_web/pkg/middleware"
 	"github.com/hngprojects/hng_boilerplate_golang_web/pkg/repository/storage"
 	"github.com/hngprojects/hng_boilerplate_golang_web/pkg/repository/storage/postgresql"
 	"github.com/hngprojects/hng_boilerplate_golang_web/services/actions"
@@ -62,7 +61,7 @@ func GetUser(userIDStr string, db *gorm.DB) (models.User, error) {
 	return userResp, nil
 }
 
-func CreateUser(req models.CreateUserRequestModel, db *gorm.DB) (gin.H, int, error) {
+func CreateUser(req models.CreateUserRequestModel, db *gorm.DB, client models.SessionClient) (gin.H, int, error) {
 
 	var (
 		email        = strings.ToLower(req.Email)
@@ -74,6 +73,10 @@ func CreateUser(req models.CreateUserRequestModel, db *gorm.DB) (gin.H, int, err
 		responseData gin.H
 	)
 
+	if code, err := ValidatePassword(db, "CreateUserRequestModel.Password", req.Password, nil); err != nil {
+		return nil, code, err
+	}
+
 	password, err := utility.HashPassword(req.Password)
 	if err != nil {
 		return nil, http.StatusInternalServerError, err
@@ -98,39 +101,58 @@ func CreateUser(req models.CreateUserRequestModel, db *gorm.DB) (gin.H, int, err
 		return nil, http.StatusInternalServerError, err
 	}
 
-	tokenData, err := middleware.CreateToken(user)
-	if err != nil {
-		return responseData, http.StatusInternalServerError, fmt.Errorf("error saving token: " + err.Error())
+	if err := recordPassword(db, user.ID, user.Password); err != nil {
+		return nil, http.StatusInternalServerError, err
 	}
 
-	tokens := map[string]string{
-		"access_token": tokenData.AccessToken,
-		"exp":          strconv.Itoa(int(tokenData.ExpiresAt.Unix())),
+	err = SendEmailVerification(user, db)
+	if err != nil {
+		return nil, http.StatusInternalServerError, err
 	}
 
-	access_token := models.AccessToken{ID: tokenData.AccessUuid, OwnerID: user.ID}
+	joinedOrgID, err := joinInvitedOrganisation(db, user, req.InviteToken)
+	if err != nil {
+		return nil, http.StatusInternalServerError, err
+	}
 
-	err = access_token.CreateAccessToken(db, tokens)
+	if err := joinDomainOrganisation(db, user); err != nil {
+		return nil, http.StatusInternalServerError, err
+	}
 
-	if err != nil {
-		return responseData, http.StatusInternalServerError, fmt.Errorf("error saving token: " + err.Error())
+	userResponse := map[string]string{
+		"id":          user.ID,
+		"email":       user.Email,
+		"username":    user.Name,
+		"first_name":  user.Profile.FirstName,
+		"last_name":   user.Profile.LastName,
+		"fullname":    user.Profile.FirstName + " " + user.Profile.LastName,
+		"phone":       user.Profile.Phone,
+		"role":        strconv.Itoa(user.Role),
+		"is_verified": strconv.FormatBool(user.IsVerified),
+		"created_at":  strconv.Itoa(int(user.CreatedAt.Unix())),
+		"updated_at":  strconv.Itoa(int(user.UpdatedAt.Unix())),
 	}
 
 	responseData = gin.H{
-		"user": map[string]string{
-			"id":         user.ID,
-			"email":      user.Email,
-			"username":   user.Name,
-			"first_name": user.Profile.FirstName,
-			"last_name":  user.Profile.LastName,
-			"fullname":   user.Profile.FirstName + " " + user.Profile.LastName,
-			"phone":      user.Profile.Phone,
-			"role":       strconv.Itoa(user.Role),
-			"expires_in": strconv.Itoa(int(tokenData.ExpiresAt.Unix())),
-			"created_at": strconv.Itoa(int(user.CreatedAt.Unix())),
-			"updated_at": strconv.Itoa(int(user.UpdatedAt.Unix())),
-		},
-		"access_token": tokenData.AccessToken,
+		"user": userResponse,
+	}
+
+	if joinedOrgID != "" {
+		responseData["organisation_id"] = joinedOrgID
+	}
+
+	// without a verified email no session is issued until the user verifies
+	if EmailVerificationBlocksLogin() {
+		responseData["email_verification_required"] = true
+	} else {
+		tokenData, err := createSession(user, db, client)
+		if err != nil {
+			return responseData, http.StatusInternalServerError, fmt.Errorf("error saving token: " + err.Error())
+		}
+
+		userResponse["expires_in"] = strconv.Itoa(int(tokenData.ExpiresAt.Unix()))
+		responseData["access_token"] = tokenData.AccessToken
+		responseData["refresh_token"] = tokenData.RefreshToken
 	}
 
 	resetReq := models.SendWelcomeMail{
@@ -145,7 +167,7 @@ func CreateUser(req models.CreateUserRequestModel, db *gorm.DB) (gin.H, int, err
 	return responseData, http.StatusCreated, nil
 }
 
-func CreateAdmin(req models.CreateUserRequestModel, db *gorm.DB) (gin.H, int, error) {
+func CreateAdmin(req models.CreateUserRequestModel, db *gorm.DB, client models.SessionClient) (gin.H, int, error) {
 
 	var (
 		email        = strings.ToLower(req.Email)
@@ -157,6 +179,10 @@ func CreateAdmin(req models.CreateUserRequestModel, db *gorm.DB) (gin.H, int, er
 		responseData gin.H
 	)
 
+	if code, err := ValidatePassword(db, "CreateUserRequestModel.Password", req.Password, nil); err != nil {
+		return nil, code, err
+	}
+
 	password, err := utility.HashPassword(req.Password)
 	if err != nil {
 		return nil, http.StatusInternalServerError, err
@@ -181,20 +207,11 @@ func CreateAdmin(req models.CreateUserRequestModel, db *gorm.DB) (gin.H, int, er
 		return nil, http.StatusInternalServerError, err
 	}
 
-	tokenData, err := middleware.CreateToken(user)
-	if err != nil {
-		return responseData, http.StatusInternalServerError, fmt.Errorf("error saving token: " + err.Error())
-	}
-
-	tokens := map[string]string{
-		"access_token": tokenData.AccessToken,
-		"exp":          strconv.Itoa(int(tokenData.ExpiresAt.Unix())),
+	if err := recordPassword(db, user.ID, user.Password); err != nil {
+		return nil, http.StatusInternalServerError, err
 	}
 
-	access_token := models.AccessToken{ID: tokenData.AccessUuid, OwnerID: user.ID}
-
-	err = access_token.CreateAccessToken(db, tokens)
-
+	tokenData, err := createSession(user, db, client)
 	if err != nil {
 		return responseData, http.StatusInternalServerError, fmt.Errorf("error saving token: " + err.Error())
 	}
@@ -213,48 +230,77 @@ func CreateAdmin(req models.CreateUserRequestModel, db *gorm.DB) (gin.H, int, er
 			"created_at": strconv.Itoa(int(user.CreatedAt.Unix())),
 			"updated_at": strconv.Itoa(int(user.UpdatedAt.Unix())),
 		},
-		"access_token": tokenData.AccessToken,
+		"access_token":  tokenData.AccessToken,
+		"refresh_token": tokenData.RefreshToken,
 	}
 
 	return responseData, http.StatusCreated, nil
 }
 
-func LoginUser(req models.LoginRequestModel, db *gorm.DB) (gin.H, int, error) {
+func LoginUser(req models.LoginRequestModel, db *gorm.DB, client models.SessionClient) (gin.H, int, error) {
 
 	var (
 		user         = models.User{}
 		responseData gin.H
 	)
 
+	guard := newLoginGuard(req.Email, client)
+	if code, err := guard.check(); err != nil {
+		return responseData, code, err
+	}
+
 	// Check if the user email exists
 	exists := postgresql.CheckExists(db, &user, "email = ?", req.Email)
 	if !exists {
+		if err := guard.recordFailure(nil); err != nil {
+			return responseData, http.StatusInternalServerError, err
+		}
 		return responseData, 400, fmt.Errorf("invalid credentials")
 	}
 
 	if !utility.CompareHash(req.Password, user.Password) {
+		if err := guard.recordFailure(&user); err != nil {
+			return responseData, http.StatusInternalServerError, err
+		}
 		return responseData, 400, fmt.Errorf("invalid credentials")
 	}
 
-	userData, err := user.GetUserByID(db, user.ID)
-	if err != nil {
-		return responseData, http.StatusInternalServerError, fmt.Errorf("unable to fetch user " + err.Error())
+	if !user.IsVerified && EmailVerificationBlocksLogin() {
+		return responseData, http.StatusForbidden, errEmailNotVerified
 	}
 
-	tokenData, err := middleware.CreateToken(user)
+	twoFAEnabled, err := IsTwoFAEnabled(user.ID, db)
 	if err != nil {
-		return responseData, http.StatusInternalServerError, fmt.Errorf("error saving token: " + err.Error())
+		return responseData, http.StatusInternalServerError, err
+	}
+
+	if twoFAEnabled {
+		return TwoFAChallenge(user)
 	}
 
-	tokens := map[string]string{
-		"access_token": tokenData.AccessToken,
-		"exp":          strconv.Itoa(int(tokenData.ExpiresAt.Unix())),
+	// failures are only cleared once the login is complete, with 2FA the
+	// challenge clears them
+	if err := guard.recordSuccess(); err != nil {
+		return responseData, http.StatusInternalServerError, err
 	}
 
-	access_token := models.AccessToken{ID: tokenData.AccessUuid, OwnerID: user.ID}
+	return CreateLoginSession(user, db, client)
+}
+
+// CreateLoginSession issues an access token for an already authenticated user,
+// stores it as a live session and builds the login response.
+func CreateLoginSession(user models.User, db *gorm.DB, client models.SessionClient) (gin.H, int, error) {
 
-	err = access_token.CreateAccessToken(db, tokens)
+	var (
+		responseData gin.H
+	)
+
+	userData, err := user.GetUserByID(db, user.ID)
+	if err != nil {
+		return responseData, http.StatusInternalServerError, fmt.Errorf("unable to fetch user " + err.Error())
+	}
 
+	tokenData, err := createSession(user, db, client)
 	if err != nil {
 		return responseData, http.StatusInternalServerError, fmt.Errorf("error saving token: " + err.Error())
 	}
@@ -274,7 +320,8 @@ func LoginUser(req models.LoginRequestModel, db *gorm.DB) (gin.H, int, error) {
 			"created_at": strconv.Itoa(int(userData.CreatedAt.Unix())),
 			"updated_at": strconv.Itoa(int(userData.UpdatedAt.Unix())),
 		},
-		"access_token": tokenData.AccessToken,
+		"access_token":  tokenData.AccessToken,
+		"refresh_token": tokenData.RefreshToken,
 	}
 
 	return responseData, http.StatusOK, nil
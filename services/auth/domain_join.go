@@ -0,0 +1,54 @@
+package auth
+
+import (
+	"strings"
+
+	"gorm.io/gorm"
+
+	"github.com/hngprojects/hng_boilerplate_golang_web/internal/models"
+	"github.com/hngprojects/hng_boilerplate_golang_web/services/invite"
+	"github.com/hngprojects/hng_boilerplate_golang_web/utility"
+)
+
+// joinDomainOrganisation brings a user whose email is on an organisation's
+// verified domain into it, directly or through a join request as the domain
+// is set up. Anyone can sign up with any address, so nothing happens until
+// the address is verified; the verification flows call this again.
+func joinDomainOrganisation(db *gorm.DB, user models.User) error {
+	var (
+		orgDomain models.OrgDomain
+		org       models.Organisation
+	)
+
+	if !user.IsVerified {
+		return nil
+	}
+
+	at := strings.LastIndex(user.Email, "@")
+	if at < 0 {
+		return nil
+	}
+
+	domain, err := orgDomain.GetVerifiedDomain(db, strings.ToLower(user.Email[at+1:]))
+	if err != nil || domain == nil {
+		return err
+	}
+
+	isMember, err := org.CheckUserIsMemberOfOrg(user.ID, domain.OrganisationID, db)
+	if err != nil || isMember {
+		return err
+	}
+
+	if domain.JoinPolicy == models.DomainJoinRequest {
+		joinRequest := models.OrgJoinRequest{
+			ID:             utility.GenerateUUID(),
+			OrganisationID: domain.OrganisationID,
+			UserID:         user.ID,
+			Email:          user.Email,
+			Status:         models.JoinRequestPending,
+		}
+		return joinRequest.RequestToJoin(db)
+	}
+
+	return invite.AddUserToOrganisation(db, domain.OrganisationID, user.ID)
+}
@@ -0,0 +1,203 @@
+package auth
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+
+	"github.com/hngprojects/hng_boilerplate_golang_web/internal/config"
+	"github.com/hngprojects/hng_boilerplate_golang_web/internal/models"
+	"github.com/hngprojects/hng_boilerplate_golang_web/pkg/repository/storage"
+	"github.com/hngprojects/hng_boilerplate_golang_web/pkg/repository/storage/postgresql"
+	"github.com/hngprojects/hng_boilerplate_golang_web/services/actions"
+	"github.com/hngprojects/hng_boilerplate_golang_web/services/actions/names"
+	"github.com/hngprojects/hng_boilerplate_golang_web/utility"
+)
+
+var (
+	errInvalidEmailChange = errors.New("invalid or expired email change link")
+	errEmailInUse         = errors.New("email address is already in use")
+)
+
+// RequestEmailChange re-checks the password and mails a confirmation link to
+// the new address and a cancel link to the current one. The address does not
+// change until the new one is confirmed.
+func RequestEmailChange(userID, sessionID string, req models.ChangeEmailRequestModel, db *gorm.DB) (gin.H, int, error) {
+	var (
+		user     models.User
+		newEmail = strings.ToLower(strings.TrimSpace(req.NewEmail))
+		duration = config.GetConfig().App.EmailVerificationDuration
+	)
+
+	if duration <= 0 {
+		duration = 24 * 60
+	}
+
+	user, err := user.GetUserByID(db, userID)
+	if err != nil {
+		return nil, http.StatusNotFound, errors.New("user not found")
+	}
+
+	if user.Password == "" {
+		return nil, http.StatusBadRequest, errors.New("set a password before changing your email address")
+	}
+
+	if !utility.CompareHash(req.Password, user.Password) {
+		return nil, http.StatusUnauthorized, errors.New("password is incorrect")
+	}
+
+	if strings.EqualFold(newEmail, user.Email) {
+		return nil, http.StatusBadRequest, errors.New("new email address is the same as the current one")
+	}
+
+	if postgresql.CheckExists(db, &models.User{}, "email = ?", newEmail) {
+		return nil, http.StatusConflict, errEmailInUse
+	}
+
+	confirmToken, err := utility.GenerateSecureToken(32)
+	if err != nil {
+		return nil, http.StatusInternalServerError, err
+	}
+
+	cancelToken, err := utility.GenerateSecureToken(32)
+	if err != nil {
+		return nil, http.StatusInternalServerError, err
+	}
+
+	change := models.EmailChange{
+		ID:               utility.GenerateUUID(),
+		UserID:           user.ID,
+		OldEmail:         user.Email,
+		NewEmail:         newEmail,
+		ConfirmTokenHash: utility.HashToken(confirmToken),
+		CancelTokenHash:  utility.HashToken(cancelToken),
+		SessionID:        sessionID,
+		ExpiresAt:        time.Now().Add(time.Duration(duration) * time.Minute),
+	}
+
+	if err := change.ReplaceEmailChange(db); err != nil {
+		return nil, http.StatusInternalServerError, err
+	}
+
+	confirmReq := models.SendEmailChangeMail{
+		Email:    newEmail,
+		Name:     user.Name,
+		NewEmail: newEmail,
+		Token:    confirmToken,
+	}
+
+	err = actions.AddNotificationToQueue(storage.DB.Redis, names.SendEmailChangeConfirmMail, confirmReq)
+	if err != nil {
+		return nil, http.StatusInternalServerError, err
+	}
+
+	cancelReq := models.SendEmailChangeMail{
+		Email:    user.Email,
+		Name:     user.Name,
+		NewEmail: newEmail,
+		Token:    cancelToken,
+	}
+
+	err = actions.AddNotificationToQueue(storage.DB.Redis, names.SendEmailChangeCancelMail, cancelReq)
+	if err != nil {
+		return nil, http.StatusInternalServerError, err
+	}
+
+	return gin.H{
+		"new_email":  newEmail,
+		"expires_at": change.ExpiresAt,
+	}, http.StatusOK, nil
+}
+
+// ConfirmEmailChange swaps the address and revokes every live session except
+// the one the change was requested from.
+func ConfirmEmailChange(req models.EmailChangeTokenRequestModel, db *gorm.DB) (gin.H, int, error) {
+	var (
+		change       models.EmailChange
+		user         models.User
+		verification models.EmailVerification
+		accessToken  models.AccessToken
+		revoked      int
+	)
+
+	change, err := change.GetByConfirmTokenHash(db, utility.HashToken(req.Token))
+	if err != nil {
+		return nil, http.StatusBadRequest, errInvalidEmailChange
+	}
+
+	user, err = user.GetUserByID(db, change.UserID)
+	if err != nil {
+		return nil, http.StatusNotFound, errors.New("user not found")
+	}
+
+	err = db.Transaction(func(tx *gorm.DB) error {
+		deleted, err := change.DeleteEmailChange(tx)
+		if err != nil {
+			return err
+		}
+		if !deleted {
+			return errInvalidEmailChange
+		}
+
+		if postgresql.CheckExists(tx, &models.User{}, "email = ? AND id <> ?", change.NewEmail, user.ID) {
+			return errEmailInUse
+		}
+
+		// following the link proves control of the new address
+		err = tx.Model(&models.User{}).Where("id = ?", user.ID).Updates(map[string]interface{}{
+			"email":       change.NewEmail,
+			"is_verified": true,
+			"verified_at": time.Now(),
+		}).Error
+		if err != nil {
+			return err
+		}
+
+		if err := tx.Where("email = ?", change.OldEmail).Delete(&verification).Error; err != nil {
+			return err
+		}
+
+		revoked, err = accessToken.RevokeOwnerSessions(tx, user.ID, change.SessionID)
+		return err
+	})
+	switch {
+	case errors.Is(err, errInvalidEmailChange):
+		return nil, http.StatusBadRequest, err
+	case errors.Is(err, errEmailInUse):
+		return nil, http.StatusConflict, err
+	case err != nil:
+		return nil, http.StatusInternalServerError, err
+	}
+
+	user.Email = change.NewEmail
+	user.IsVerified = true
+	if err := joinDomainOrganisation(db, user); err != nil {
+		return nil, http.StatusInternalServerError, err
+	}
+
+	return gin.H{
+		"email":            change.NewEmail,
+		"revoked_sessions": revoked,
+	}, http.StatusOK, nil
+}
+
+// CancelEmailChange discards a pending change from the link mailed to the
+// current address. It works after expiry too, so a late click still answers.
+func CancelEmailChange(req models.EmailChangeTokenRequestModel, db *gorm.DB) (gin.H, int, error) {
+	var change models.EmailChange
+
+	change, err := change.GetByCancelTokenHash(db, utility.HashToken(req.Token))
+	if err != nil {
+		return nil, http.StatusBadRequest, errInvalidEmailChange
+	}
+
+	if _, err := change.DeleteEmailChange(db); err != nil {
+		return nil, http.StatusInternalServerError, err
+	}
+
+	return gin.H{}, http.StatusOK, nil
+}
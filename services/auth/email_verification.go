@@ -0,0 +1,198 @@
+package auth
+
+import (
+	"crypto/subtle"
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+
+	"github.com/hngprojects/hng_boilerplate_golang_web/internal/config"
+	"github.com/hngprojects/hng_boilerplate_golang_web/internal/models"
+	"github.com/hngprojects/hng_boilerplate_golang_web/pkg/repository/storage"
+	"github.com/hngprojects/hng_boilerplate_golang_web/services/actions"
+	"github.com/hngprojects/hng_boilerplate_golang_web/services/actions/names"
+	"github.com/hngprojects/hng_boilerplate_golang_web/utility"
+)
+
+const (
+	emailVerificationMaxAttempts    = 5
+	emailVerificationResendCooldown = time.Minute
+)
+
+var errEmailNotVerified = errors.New("email address is not verified")
+
+// EmailVerificationBlocksLogin reports whether unverified users are refused a session.
+func EmailVerificationBlocksLogin() bool {
+	return config.GetConfig().App.EmailVerificationRequired == config.EmailVerificationOnLogin
+}
+
+// SendEmailVerification replaces any pending verification of the user and
+// mails a fresh link and code.
+func SendEmailVerification(user models.User, db *gorm.DB) error {
+	var (
+		verification = models.EmailVerification{}
+		duration     = config.GetConfig().App.EmailVerificationDuration
+	)
+
+	if duration <= 0 {
+		duration = 24 * 60
+	}
+
+	existing, err := verification.GetEmailVerificationByEmail(db, user.Email)
+	if err != nil {
+		return err
+	}
+
+	if existing != nil {
+		if err := existing.DeleteEmailVerification(db); err != nil {
+			return err
+		}
+	}
+
+	token, err := utility.GenerateSecureToken(32)
+	if err != nil {
+		return err
+	}
+
+	code, err := utility.GenerateOTP(6)
+	if err != nil {
+		return err
+	}
+
+	verification = models.EmailVerification{
+		ID:        utility.GenerateUUID(),
+		Email:     user.Email,
+		TokenHash: utility.HashToken(token),
+		Code:      strconv.Itoa(code),
+		ExpiresAt: time.Now().Add(time.Duration(duration) * time.Minute),
+	}
+
+	err = verification.CreateEmailVerification(db)
+	if err != nil {
+		return err
+	}
+
+	verificationReq := models.SendEmailVerificationMail{
+		Email: user.Email,
+		Code:  uint(code),
+		Token: token,
+	}
+
+	return actions.AddNotificationToQueue(storage.DB.Redis, names.SendEmailVerificationMail, verificationReq)
+}
+
+// VerifyEmail accepts either the link token or the email and code pair.
+func VerifyEmail(req models.VerifyEmailRequestModel, db *gorm.DB) (gin.H, int, error) {
+	var (
+		user         = models.User{}
+		verification = models.EmailVerification{}
+		errInvalid   = errors.New("invalid or expired verification code")
+	)
+
+	if req.Token != "" {
+		found, err := verification.GetEmailVerificationByTokenHash(db, utility.HashToken(req.Token))
+		if err != nil {
+			return nil, http.StatusBadRequest, errInvalid
+		}
+		verification = found
+	} else {
+		found, err := verification.GetEmailVerificationByEmail(db, strings.ToLower(req.Email))
+		if err != nil {
+			return nil, http.StatusInternalServerError, err
+		}
+
+		if found == nil || time.Now().After(found.ExpiresAt) || found.Attempts >= emailVerificationMaxAttempts {
+			return nil, http.StatusBadRequest, errInvalid
+		}
+
+		if subtle.ConstantTimeCompare([]byte(found.Code), []byte(strings.TrimSpace(req.Code))) != 1 {
+			if err := found.IncrementAttempts(db); err != nil {
+				return nil, http.StatusInternalServerError, err
+			}
+			return nil, http.StatusBadRequest, errInvalid
+		}
+		verification = *found
+	}
+
+	user, err := user.GetUserByEmail(db, verification.Email)
+	if err != nil {
+		return nil, http.StatusNotFound, errors.New("user not found")
+	}
+
+	if !user.IsVerified {
+		if err := user.MarkEmailVerified(db); err != nil {
+			return nil, http.StatusInternalServerError, err
+		}
+
+		verifiedReq := models.SendEmailVerifiedMail{
+			Email: user.Email,
+		}
+
+		err = actions.AddNotificationToQueue(storage.DB.Redis, names.SendEmailVerifiedMail, verifiedReq)
+		if err != nil {
+			return nil, http.StatusInternalServerError, err
+		}
+
+		if err := joinDomainOrganisation(db, user); err != nil {
+			return nil, http.StatusInternalServerError, err
+		}
+	}
+
+	if err := verification.DeleteEmailVerification(db); err != nil {
+		return nil, http.StatusInternalServerError, err
+	}
+
+	return gin.H{
+		"email":       user.Email,
+		"is_verified": user.IsVerified,
+	}, http.StatusOK, nil
+}
+
+func ResendEmailVerification(req models.ResendEmailVerificationRequestModel, db *gorm.DB) (gin.H, int, error) {
+	var (
+		user         = models.User{}
+		verification = models.EmailVerification{}
+		email        = strings.ToLower(req.Email)
+	)
+
+	user, err := user.GetUserByEmail(db, email)
+	if err != nil {
+		return nil, http.StatusNotFound, errors.New("user not found")
+	}
+
+	if user.IsVerified {
+		return nil, http.StatusBadRequest, errors.New("email address is already verified")
+	}
+
+	existing, err := verification.GetEmailVerificationByEmail(db, email)
+	if err != nil {
+		return nil, http.StatusInternalServerError, err
+	}
+
+	if existing != nil && time.Since(existing.CreatedAt) < emailVerificationResendCooldown {
+		return nil, http.StatusTooManyRequests, errors.New("please wait before requesting another verification email")
+	}
+
+	if err := SendEmailVerification(user, db); err != nil {
+		return nil, http.StatusInternalServerError, err
+	}
+
+	return gin.H{}, http.StatusOK, nil
+}
+
+// markVerifiedByProvider records a verification vouched for by a login method
+// that already proves control of the email address.
+func markVerifiedByProvider(user *models.User, db *gorm.DB) error {
+	if user.IsVerified {
+		return nil
+	}
+	if err := user.MarkEmailVerified(db); err != nil {
+		return err
+	}
+	return joinDomainOrganisation(db, *user)
+}
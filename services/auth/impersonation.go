@@ -0,0 +1,130 @@
+package auth
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+
+	"github.com/hngprojects/hng_boilerplate_golang_web/internal/config"
+	"github.com/hngprojects/hng_boilerplate_golang_web/internal/models"
+	"github.com/hngprojects/hng_boilerplate_golang_web/pkg/middleware"
+	"github.com/hngprojects/hng_boilerplate_golang_web/pkg/repository/storage/postgresql"
+	"github.com/hngprojects/hng_boilerplate_golang_web/utility"
+)
+
+const defaultImpersonationDuration = 30
+
+// StartImpersonation signs a token that lets the superadmin act as the user.
+// The session has no refresh token, so it ends when the token expires.
+func StartImpersonation(adminID, userID string, req models.ImpersonateUserRequestModel, db *gorm.DB, client models.SessionClient) (gin.H, int, error) {
+	var user models.User
+
+	if adminID == userID {
+		return nil, http.StatusBadRequest, errors.New("you cannot impersonate yourself")
+	}
+
+	if !postgresql.CheckExists(db, &user, "id = ?", userID) {
+		return nil, http.StatusNotFound, errors.New("user not found")
+	}
+
+	if user.Role == int(models.RoleIdentity.SuperAdmin) {
+		return nil, http.StatusForbidden, errors.New("superadmins cannot be impersonated")
+	}
+
+	duration := config.GetConfig().App.ImpersonationDuration
+	if duration <= 0 {
+		duration = defaultImpersonationDuration
+	}
+
+	tokenData, err := middleware.CreateToken(user, middleware.Actor{
+		UserID:   adminID,
+		Duration: time.Duration(duration) * time.Minute,
+	})
+	if err != nil {
+		return nil, http.StatusInternalServerError, fmt.Errorf("error saving token: " + err.Error())
+	}
+
+	tokens := map[string]string{
+		"access_token": tokenData.AccessToken,
+		"exp":          strconv.Itoa(int(tokenData.ExpiresAt.Unix())),
+	}
+
+	session := models.AccessToken{
+		ID:             tokenData.AccessUuid,
+		OwnerID:        user.ID,
+		UserAgent:      client.UserAgent,
+		IPAddress:      client.IPAddress,
+		ImpersonatorID: &adminID,
+	}
+
+	err = db.Transaction(func(tx *gorm.DB) error {
+		if err := session.CreateAccessToken(tx, tokens); err != nil {
+			return err
+		}
+		return recordImpersonation(tx, models.AuditActionImpersonationStart, adminID, session, req.Reason, client)
+	})
+	if err != nil {
+		return nil, http.StatusInternalServerError, err
+	}
+
+	return gin.H{
+		"user": map[string]string{
+			"id":       user.ID,
+			"email":    user.Email,
+			"fullname": user.Name,
+			"role":     strconv.Itoa(user.Role),
+		},
+		"impersonator_id": adminID,
+		"session_id":      session.ID,
+		"access_token":    tokenData.AccessToken,
+		"expires_in":      strconv.Itoa(int(tokenData.ExpiresAt.Unix())),
+	}, http.StatusCreated, nil
+}
+
+// StopImpersonation ends the impersonation session the request was made with.
+func StopImpersonation(sessionID, userID string, db *gorm.DB, client models.SessionClient) (gin.H, int, error) {
+	session := models.AccessToken{ID: sessionID}
+
+	if _, err := session.GetByID(db); err != nil || session.OwnerID != userID || !session.IsLive {
+		return nil, http.StatusNotFound, errors.New("session not found")
+	}
+
+	if session.ImpersonatorID == nil {
+		return nil, http.StatusBadRequest, errors.New("this session is not an impersonation")
+	}
+
+	err := db.Transaction(func(tx *gorm.DB) error {
+		if err := session.RevokeAccessToken(tx); err != nil {
+			return err
+		}
+		return recordImpersonation(tx, models.AuditActionImpersonationStop, *session.ImpersonatorID, session, "", client)
+	})
+	if err != nil {
+		return nil, http.StatusInternalServerError, err
+	}
+
+	return gin.H{}, http.StatusOK, nil
+}
+
+func recordImpersonation(db *gorm.DB, action, adminID string, session models.AccessToken, reason string, client models.SessionClient) error {
+	auditLog := models.AuditLog{
+		ID:         utility.GenerateUUID(),
+		ActorID:    adminID,
+		Action:     action,
+		TargetType: "user",
+		TargetID:   session.OwnerID,
+		Details:    fmt.Sprintf("session %v", session.ID),
+		IPAddress:  client.IPAddress,
+		UserAgent:  client.UserAgent,
+	}
+	if reason != "" {
+		auditLog.Details = fmt.Sprintf("session %v: %v", session.ID, reason)
+	}
+
+	return auditLog.CreateAuditLog(db)
+}
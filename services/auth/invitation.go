@@ -0,0 +1,29 @@
+package auth
+
+import (
+	"gorm.io/gorm"
+
+	"github.com/hngprojects/hng_boilerplate_golang_web/internal/models"
+	"github.com/hngprojects/hng_boilerplate_golang_web/services/invite"
+)
+
+// joinInvitedOrganisation accepts the invitation a new user signed up with
+// and adds them to its organisation, returning its id. A stale token or one
+// sent to another address is ignored so it never blocks sign up.
+func joinInvitedOrganisation(db *gorm.DB, user models.User, inviteToken string) (string, error) {
+	if inviteToken == "" {
+		return "", nil
+	}
+
+	token := invite.ExtractTokenFromInvitationLink(inviteToken)
+	invitation, _, err := invite.AcceptInvitationLink(user.ID, token, db)
+	if err != nil {
+		return "", nil
+	}
+
+	if err := invite.AddUserToOrganisation(db, invitation.OrganisationID, user.ID); err != nil {
+		return "", err
+	}
+
+	return invitation.OrganisationID, nil
+}
@@ -0,0 +1,248 @@
+package auth
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+
+	"github.com/hngprojects/hng_boilerplate_golang_web/internal/config"
+	"github.com/hngprojects/hng_boilerplate_golang_web/internal/models"
+	"github.com/hngprojects/hng_boilerplate_golang_web/pkg/repository/storage"
+	"github.com/hngprojects/hng_boilerplate_golang_web/pkg/repository/storage/redis"
+	"github.com/hngprojects/hng_boilerplate_golang_web/services/actions"
+	"github.com/hngprojects/hng_boilerplate_golang_web/services/actions/names"
+	"github.com/hngprojects/hng_boilerplate_golang_web/utility"
+)
+
+const (
+	// loginFreeAttempts failures are allowed before every further attempt is delayed
+	loginFreeAttempts   = 2
+	loginMaxDelay       = time.Minute
+	unlockTokenDuration = 24 * time.Hour
+)
+
+var errAccountLocked = errors.New("account temporarily locked due to too many failed login attempts")
+
+// loginGuard tracks failed logins per account and per client ip in redis.
+type loginGuard struct {
+	email         string
+	client        models.SessionClient
+	maxAttempts   int
+	maxIPAttempts int
+	lockout       time.Duration
+	trackIP       bool
+}
+
+func newLoginGuard(email string, client models.SessionClient) loginGuard {
+	var (
+		configData = config.GetConfig()
+		guard      = loginGuard{
+			email:         strings.ToLower(strings.TrimSpace(email)),
+			client:        client,
+			maxAttempts:   configData.App.LoginMaxAttempts,
+			maxIPAttempts: configData.App.LoginMaxIPAttempts,
+			lockout:       time.Duration(configData.App.LoginLockoutDuration) * time.Minute,
+		}
+	)
+
+	if guard.maxAttempts <= 0 {
+		guard.maxAttempts = 5
+	}
+	if guard.maxIPAttempts <= 0 {
+		guard.maxIPAttempts = 50
+	}
+	if guard.lockout <= 0 {
+		guard.lockout = 15 * time.Minute
+	}
+
+	guard.trackIP = client.IPAddress != "" && !utility.InStringSlice(client.IPAddress, configData.Server.ExemptFromThrottle)
+
+	return guard
+}
+
+func loginAccountKey(kind, email string) string {
+	return fmt.Sprintf("login:%v:account:%v", kind, email)
+}
+
+func loginIPKey(kind, ip string) string {
+	return fmt.Sprintf("login:%v:ip:%v", kind, ip)
+}
+
+func unlockTokenKey(tokenHash string) string {
+	return "login:unlock:" + tokenHash
+}
+
+// check refuses the attempt while the ip or account is locked or still waiting
+// out the delay of its last failure.
+func (g loginGuard) check() (int, error) {
+	rdb := storage.DB.Redis
+
+	if g.trackIP {
+		ttl, err := redis.RedisTTL(rdb, loginIPKey("lock", g.client.IPAddress))
+		if err != nil {
+			return http.StatusInternalServerError, err
+		}
+		if ttl > 0 {
+			return http.StatusTooManyRequests, fmt.Errorf("too many failed login attempts from this address, try again in %v", formatRetry(ttl))
+		}
+	}
+
+	ttl, err := redis.RedisTTL(rdb, loginAccountKey("lock", g.email))
+	if err != nil {
+		return http.StatusInternalServerError, err
+	}
+	if ttl > 0 {
+		return http.StatusLocked, errAccountLocked
+	}
+
+	ttl, err = redis.RedisTTL(rdb, loginAccountKey("delay", g.email))
+	if err != nil {
+		return http.StatusInternalServerError, err
+	}
+	if ttl > 0 {
+		return http.StatusTooManyRequests, fmt.Errorf("too many failed login attempts, try again in %v", formatRetry(ttl))
+	}
+
+	return http.StatusOK, nil
+}
+
+// recordFailure counts a failed attempt. user is nil when the email does not
+// belong to an account, which is still counted so it cannot be told apart.
+func (g loginGuard) recordFailure(user *models.User) error {
+	rdb := storage.DB.Redis
+
+	if g.trackIP {
+		count, err := redis.RedisIncr(rdb, loginIPKey("fail", g.client.IPAddress), g.lockout)
+		if err != nil {
+			return err
+		}
+		if count >= int64(g.maxIPAttempts) {
+			if err := redis.RedisSetWithExpiry(rdb, loginIPKey("lock", g.client.IPAddress), true, g.lockout); err != nil {
+				return err
+			}
+			if _, err := redis.RedisDelete(rdb, loginIPKey("fail", g.client.IPAddress)); err != nil {
+				return err
+			}
+		}
+	}
+
+	count, err := redis.RedisIncr(rdb, loginAccountKey("fail", g.email), g.lockout)
+	if err != nil {
+		return err
+	}
+
+	if count >= int64(g.maxAttempts) {
+		return g.lockAccount(user)
+	}
+
+	if count > loginFreeAttempts {
+		delay := time.Duration(math.Pow(2, float64(count-loginFreeAttempts-1))) * time.Second
+		if delay > loginMaxDelay {
+			delay = loginMaxDelay
+		}
+		return redis.RedisSetWithExpiry(rdb, loginAccountKey("delay", g.email), true, delay)
+	}
+
+	return nil
+}
+
+func (g loginGuard) recordSuccess() error {
+	return clearAccountLock(g.email)
+}
+
+// lockAccount locks the account and tells its owner, with a link to unlock it early.
+func (g loginGuard) lockAccount(user *models.User) error {
+	rdb := storage.DB.Redis
+
+	if err := redis.RedisSetWithExpiry(rdb, loginAccountKey("lock", g.email), true, g.lockout); err != nil {
+		return err
+	}
+
+	for _, kind := range []string{"fail", "delay"} {
+		if _, err := redis.RedisDelete(rdb, loginAccountKey(kind, g.email)); err != nil {
+			return err
+		}
+	}
+
+	if user == nil {
+		return nil
+	}
+
+	token, err := utility.GenerateSecureToken(32)
+	if err != nil {
+		return err
+	}
+
+	if err := redis.RedisSetWithExpiry(rdb, unlockTokenKey(utility.HashToken(token)), g.email, unlockTokenDuration); err != nil {
+		return err
+	}
+
+	suspiciousReq := models.SendSuspiciousLoginMail{
+		Email:       user.Email,
+		IPAddress:   g.client.IPAddress,
+		UserAgent:   g.client.UserAgent,
+		LockedUntil: time.Now().Add(g.lockout).Format(time.RFC1123),
+		UnlockLink:  fmt.Sprintf("%v/unlock-account?token=%v", config.GetConfig().App.Url, token),
+	}
+
+	return actions.AddNotificationToQueue(rdb, names.SendSuspiciousLoginMail, suspiciousReq)
+}
+
+func clearAccountLock(email string) error {
+	for _, kind := range []string{"lock", "fail", "delay"} {
+		if _, err := redis.RedisDelete(storage.DB.Redis, loginAccountKey(kind, email)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func formatRetry(ttl time.Duration) string {
+	return fmt.Sprintf("%d seconds", int(math.Ceil(ttl.Seconds())))
+}
+
+// UnlockAccount lifts a lockout with the token from the suspicious login email.
+func UnlockAccount(req models.UnlockAccountRequestModel, db *gorm.DB) (gin.H, int, error) {
+	var (
+		email string
+		key   = unlockTokenKey(utility.HashToken(req.Token))
+	)
+
+	value, err := redis.RedisGet(storage.DB.Redis, key)
+	if err != nil || json.Unmarshal(value, &email) != nil {
+		return nil, http.StatusBadRequest, errors.New("invalid or expired unlock token")
+	}
+
+	if err := clearAccountLock(email); err != nil {
+		return nil, http.StatusInternalServerError, err
+	}
+
+	if _, err := redis.RedisDelete(storage.DB.Redis, key); err != nil {
+		return nil, http.StatusInternalServerError, err
+	}
+
+	return gin.H{}, http.StatusOK, nil
+}
+
+// UnlockUserAccount lets a superadmin lift the lockout of any user.
+func UnlockUserAccount(userID string, db *gorm.DB) (gin.H, int, error) {
+	var user models.User
+
+	user, err := user.GetUserByID(db, userID)
+	if err != nil {
+		return nil, http.StatusNotFound, errors.New("user not found")
+	}
+
+	if err := clearAccountLock(strings.ToLower(user.Email)); err != nil {
+		return nil, http.StatusInternalServerError, err
+	}
+
+	return gin.H{}, http.StatusOK, nil
+}
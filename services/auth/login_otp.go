@@ -0,0 +1,189 @@
+package auth
+
+import (
+	"crypto/subtle"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+
+	"github.com/hngprojects/hng_boilerplate_golang_web/internal/config"
+	"github.com/hngprojects/hng_boilerplate_golang_web/internal/models"
+	"github.com/hngprojects/hng_boilerplate_golang_web/pkg/repository/storage"
+	"github.com/hngprojects/hng_boilerplate_golang_web/pkg/repository/storage/redis"
+	"github.com/hngprojects/hng_boilerplate_golang_web/services/actions"
+	"github.com/hngprojects/hng_boilerplate_golang_web/services/actions/names"
+	"github.com/hngprojects/hng_boilerplate_golang_web/utility"
+)
+
+const loginOTPResendCooldown = time.Minute
+
+var errInvalidLoginOTP = errors.New("invalid or expired code")
+
+func loginOTPKey(kind, email string) string {
+	return "login:otp:" + kind + ":" + email
+}
+
+func loginOTPSettings() (time.Duration, int64) {
+	var (
+		app         = config.GetConfig().App
+		duration    = time.Duration(app.LoginOTPDuration) * time.Minute
+		maxAttempts = int64(app.LoginOTPMaxAttempts)
+	)
+
+	if duration <= 0 {
+		duration = 10 * time.Minute
+	}
+	if maxAttempts <= 0 {
+		maxAttempts = 5
+	}
+
+	return duration, maxAttempts
+}
+
+// RequestLoginOTP emails a one time sign in code. Only the hash of the code is
+// kept in redis, and requesting a new code replaces the previous one. An
+// unknown email gets the same response and cooldown without any mail, so the
+// endpoint can't be used to find out who has an account.
+func RequestLoginOTP(req models.LoginOTPRequestModel, db *gorm.DB) (gin.H, int, error) {
+	var (
+		user        models.User
+		email       = strings.ToLower(strings.TrimSpace(req.Email))
+		rdb         = storage.DB.Redis
+		duration, _ = loginOTPSettings()
+		respData    = gin.H{"expires_in": int(duration.Seconds())}
+	)
+
+	ttl, err := redis.RedisTTL(rdb, loginOTPKey("cooldown", email))
+	if err != nil {
+		return nil, http.StatusInternalServerError, err
+	}
+	if ttl > 0 {
+		return nil, http.StatusTooManyRequests, errors.New("please wait before requesting another code")
+	}
+
+	user, err = user.GetUserByEmail(db, email)
+	if err != nil {
+		if !errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, http.StatusInternalServerError, err
+		}
+		if err := redis.RedisSetWithExpiry(rdb, loginOTPKey("cooldown", email), true, loginOTPResendCooldown); err != nil {
+			return nil, http.StatusInternalServerError, err
+		}
+		return respData, http.StatusOK, nil
+	}
+
+	code, err := utility.GenerateOTP(6)
+	if err != nil {
+		return nil, http.StatusInternalServerError, err
+	}
+
+	if _, err := redis.RedisDelete(rdb, loginOTPKey("attempts", email)); err != nil {
+		return nil, http.StatusInternalServerError, err
+	}
+	if err := redis.RedisSetWithExpiry(rdb, loginOTPKey("code", email), utility.HashToken(strconv.Itoa(code)), duration); err != nil {
+		return nil, http.StatusInternalServerError, err
+	}
+	if err := redis.RedisSetWithExpiry(rdb, loginOTPKey("cooldown", email), true, loginOTPResendCooldown); err != nil {
+		return nil, http.StatusInternalServerError, err
+	}
+
+	otpReq := models.SendOTP{
+		Email:    user.Email,
+		OtpToken: code,
+	}
+
+	err = actions.AddNotificationToQueue(rdb, names.SendOTP, otpReq)
+	if err != nil {
+		return nil, http.StatusInternalServerError, err
+	}
+
+	return respData, http.StatusOK, nil
+}
+
+// VerifyLoginOTP signs the user in with an emailed code, through the same
+// lockout, two-factor and session steps as a password login. A code is
+// discarded once it was guessed wrong too often.
+func VerifyLoginOTP(req models.VerifyLoginOTPRequestModel, db *gorm.DB, client models.SessionClient) (gin.H, int, error) {
+	var (
+		user                  models.User
+		email                 = strings.ToLower(strings.TrimSpace(req.Email))
+		rdb                   = storage.DB.Redis
+		duration, maxAttempts = loginOTPSettings()
+		codeHash              string
+	)
+
+	guard := newLoginGuard(email, client)
+	if code, err := guard.check(); err != nil {
+		return nil, code, err
+	}
+
+	user, err := user.GetUserByEmail(db, email)
+	if err != nil {
+		if err := guard.recordFailure(nil); err != nil {
+			return nil, http.StatusInternalServerError, err
+		}
+		return nil, http.StatusBadRequest, errInvalidLoginOTP
+	}
+
+	data, err := redis.RedisGet(rdb, loginOTPKey("code", email))
+	if err != nil || json.Unmarshal(data, &codeHash) != nil {
+		return nil, http.StatusBadRequest, errInvalidLoginOTP
+	}
+
+	attempts, err := redis.RedisIncr(rdb, loginOTPKey("attempts", email), duration)
+	if err != nil {
+		return nil, http.StatusInternalServerError, err
+	}
+	if attempts > maxAttempts {
+		if _, err := redis.RedisDelete(rdb, loginOTPKey("code", email)); err != nil {
+			return nil, http.StatusInternalServerError, err
+		}
+		return nil, http.StatusTooManyRequests, errors.New("too many wrong codes, request a new code")
+	}
+
+	if subtle.ConstantTimeCompare([]byte(utility.HashToken(req.OTP)), []byte(codeHash)) != 1 {
+		if err := guard.recordFailure(&user); err != nil {
+			return nil, http.StatusInternalServerError, err
+		}
+		return nil, http.StatusBadRequest, errInvalidLoginOTP
+	}
+
+	// deleting the code consumes it, a concurrent request with the same code loses
+	deleted, err := redis.RedisDelete(rdb, loginOTPKey("code", email))
+	if err != nil {
+		return nil, http.StatusInternalServerError, err
+	}
+	if deleted != 1 {
+		return nil, http.StatusBadRequest, errInvalidLoginOTP
+	}
+
+	if _, err := redis.RedisDelete(rdb, loginOTPKey("attempts", email)); err != nil {
+		return nil, http.StatusInternalServerError, err
+	}
+
+	// the code was delivered to the address, which proves the user controls it
+	if err := markVerifiedByProvider(&user, db); err != nil {
+		return nil, http.StatusInternalServerError, err
+	}
+
+	twoFAEnabled, err := IsTwoFAEnabled(user.ID, db)
+	if err != nil {
+		return nil, http.StatusInternalServerError, err
+	}
+
+	if twoFAEnabled {
+		return TwoFAChallenge(user)
+	}
+
+	if err := guard.recordSuccess(); err != nil {
+		return nil, http.StatusInternalServerError, err
+	}
+
+	return CreateLoginSession(user, db, client)
+}
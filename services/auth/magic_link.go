@@ -13,7 +13,6 @@ import (
 
 	"github.com/hngprojects/hng_boilerplate_golang_web/internal/config"
 	"github.com/hngprojects/hng_boilerplate_golang_web/internal/models"
-	"github.com/hngprojects/hng_boilerplate_golang_web/pkg/middleware"
 	"github.com/hngprojects/hng_boilerplate_golang_web/pkg/repository/storage"
 	"github.com/hngprojects/hng_boilerplate_golang_web/pkg/repository/storage/postgresql"
 	"github.com/hngprojects/hng_boilerplate_golang_web/services/actions"
@@ -78,7 +77,7 @@ func MagicLinkRequest(userEmail, url string, db *gorm.DB) (string, int, error) {
 	return "success", http.StatusOK, nil
 }
 
-func VerifyMagicLinkToken(req models.VerifyMagicLinkRequest, db *gorm.DB) (gin.H, int, error) {
+func VerifyMagicLinkToken(req models.VerifyMagicLinkRequest, db *gorm.DB, client models.SessionClient) (gin.H, int, error) {
 
 	var (
 		user         = models.User{}
@@ -101,20 +100,12 @@ func VerifyMagicLinkToken(req models.VerifyMagicLinkRequest, db *gorm.DB) (gin.H
 		return responseData, http.StatusInternalServerError, errors.New("unable to fetch user")
 	}
 
-	tokenData, err := middleware.CreateToken(user)
-	if err != nil {
-		return responseData, http.StatusInternalServerError, errors.New("error saving token")
-	}
-
-	tokens := map[string]string{
-		"access_token": tokenData.AccessToken,
-		"exp":          strconv.Itoa(int(tokenData.ExpiresAt.Unix())),
+	// the link was delivered to the address, which proves the user controls it
+	if err := markVerifiedByProvider(&user, db); err != nil {
+		return responseData, http.StatusInternalServerError, err
 	}
 
-	access_token := models.AccessToken{ID: tokenData.AccessUuid, OwnerID: user.ID}
-
-	err = access_token.CreateAccessToken(db, tokens)
-
+	tokenData, err := createSession(user, db, client)
 	if err != nil {
 		return responseData, http.StatusInternalServerError, errors.New("error saving token")
 	}
@@ -138,7 +129,8 @@ func VerifyMagicLinkToken(req models.VerifyMagicLinkRequest, db *gorm.DB) (gin.H
 			"created_at": strconv.Itoa(int(userData.CreatedAt.Unix())),
 			"updated_at": strconv.Itoa(int(userData.UpdatedAt.Unix())),
 		},
-		"access_token": tokenData.AccessToken,
+		"access_token":  tokenData.AccessToken,
+		"refresh_token": tokenData.RefreshToken,
 	}
 
 	return responseData, http.StatusOK, nil
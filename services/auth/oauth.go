@@ -0,0 +1,329 @@
+package auth
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"golang.org/x/oauth2"
+	"gorm.io/gorm"
+
+	"github.com/hngprojects/hng_boilerplate_golang_web/external/thirdparty/oauth"
+	"github.com/hngprojects/hng_boilerplate_golang_web/internal/models"
+	"github.com/hngprojects/hng_boilerplate_golang_web/pkg/repository/storage"
+	"github.com/hngprojects/hng_boilerplate_golang_web/pkg/repository/storage/postgresql"
+	"github.com/hngprojects/hng_boilerplate_golang_web/pkg/repository/storage/redis"
+	"github.com/hngprojects/hng_boilerplate_golang_web/services/actions"
+	"github.com/hngprojects/hng_boilerplate_golang_web/services/actions/names"
+	"github.com/hngprojects/hng_boilerplate_golang_web/utility"
+)
+
+const oauthStateDuration = 10 * time.Minute
+
+var (
+	errInvalidOAuthState = errors.New("invalid or expired oauth state")
+	errOAuthStateSession = errors.New("oauth state was not started by this session")
+)
+
+// oauthState is kept in redis between sending the user to the provider and
+// the callback. UserID is set when an signed in user links a provider.
+type oauthState struct {
+	Provider string `json:"provider"`
+	Verifier string `json:"verifier"`
+	Nonce    string `json:"nonce"`
+	UserID   string `json:"user_id,omitempty"`
+}
+
+func oauthStateKey(state string) string {
+	return "oauth:state:" + utility.HashToken(state)
+}
+
+func GetOAuthProviders() []oauth.ProviderInfo {
+	return oauth.Providers()
+}
+
+// BeginOAuth returns the provider page to send the user to. linkUserID is empty
+// for a sign in, or the user the provider account will be linked to.
+func BeginOAuth(providerName, linkUserID string) (gin.H, int, error) {
+	provider, err := oauth.GetProvider(providerName)
+	if err != nil {
+		return nil, http.StatusNotFound, err
+	}
+
+	state, err := utility.GenerateSecureToken(32)
+	if err != nil {
+		return nil, http.StatusInternalServerError, err
+	}
+
+	nonce, err := utility.GenerateSecureToken(32)
+	if err != nil {
+		return nil, http.StatusInternalServerError, err
+	}
+
+	verifier := oauth2.GenerateVerifier()
+
+	authURL, err := provider.AuthCodeURL(context.Background(), state, verifier, nonce)
+	if err != nil {
+		return nil, http.StatusBadGateway, err
+	}
+
+	value := oauthState{Provider: provider.Name, Verifier: verifier, Nonce: nonce, UserID: linkUserID}
+	if err := redis.RedisSetWithExpiry(storage.DB.Redis, oauthStateKey(state), value, oauthStateDuration); err != nil {
+		return nil, http.StatusInternalServerError, err
+	}
+
+	return gin.H{
+		"authorization_url": authURL,
+		"state":             state,
+	}, http.StatusOK, nil
+}
+
+// consumeOAuthState returns the stored state once; a replayed callback fails.
+func consumeOAuthState(state string) (oauthState, error) {
+	var (
+		value oauthState
+		key   = oauthStateKey(state)
+	)
+
+	data, err := redis.RedisGet(storage.DB.Redis, key)
+	if err != nil || json.Unmarshal(data, &value) != nil {
+		return value, errInvalidOAuthState
+	}
+
+	deleted, err := redis.RedisDelete(storage.DB.Redis, key)
+	if err != nil || deleted != 1 {
+		return value, errInvalidOAuthState
+	}
+
+	return value, nil
+}
+
+// CompleteOAuth handles the code and state the provider redirected back with,
+// either signing the user in or linking the provider to the user who began it.
+// sessionUserID is empty on the public callback and the signed in user on the
+// link callback; it has to match the user the state was started for, so a
+// link can't be completed by anyone but the account it was started from.
+func CompleteOAuth(req models.OAuthCallbackRequestModel, sessionUserID string, db *gorm.DB, client models.SessionClient) (gin.H, int, error) {
+	state, err := consumeOAuthState(req.State)
+	if err != nil {
+		return nil, http.StatusBadRequest, err
+	}
+
+	if state.UserID != sessionUserID {
+		return nil, http.StatusForbidden, errOAuthStateSession
+	}
+
+	provider, err := oauth.GetProvider(state.Provider)
+	if err != nil {
+		return nil, http.StatusNotFound, err
+	}
+
+	identity, err := provider.Exchange(context.Background(), req.Code, state.Verifier, state.Nonce)
+	if err != nil {
+		return nil, http.StatusUnauthorized, err
+	}
+
+	if state.UserID != "" {
+		return linkSocialIdentity(state.UserID, provider.Name, identity, db)
+	}
+
+	return oauthLogin(provider.Name, identity, db, client)
+}
+
+func linkSocialIdentity(userID, providerName string, identity oauth.Identity, db *gorm.DB) (gin.H, int, error) {
+	var socialIdentity models.SocialIdentity
+
+	code, err := socialIdentity.GetByProviderSubject(db, providerName, identity.Subject)
+	if err == nil {
+		if socialIdentity.UserID != userID {
+			return nil, http.StatusConflict, errors.New("this provider account is linked to another user")
+		}
+		return gin.H{"identity": socialIdentity}, http.StatusOK, nil
+	}
+	if code == http.StatusInternalServerError {
+		return nil, code, err
+	}
+
+	socialIdentity = models.SocialIdentity{
+		ID:       utility.GenerateUUID(),
+		UserID:   userID,
+		Provider: providerName,
+		Subject:  identity.Subject,
+		Email:    identity.Email,
+	}
+
+	if err := socialIdentity.CreateSocialIdentity(db); err != nil {
+		return nil, http.StatusInternalServerError, err
+	}
+
+	return gin.H{"identity": socialIdentity}, http.StatusCreated, nil
+}
+
+// oauthLogin signs in the user linked to the identity. Without a link an
+// existing account is only matched by email when the provider verified it, so
+// nobody can take over an account by adding its address at a provider. Users
+// with 2FA get a challenge, as they do when signing in with a password. The
+// email is normalised the way registration stores it before it is compared.
+func oauthLogin(providerName string, identity oauth.Identity, db *gorm.DB, client models.SessionClient) (gin.H, int, error) {
+	var (
+		user           models.User
+		socialIdentity models.SocialIdentity
+		isNewUser      bool
+	)
+
+	identity.Email = strings.ToLower(strings.TrimSpace(identity.Email))
+
+	code, err := socialIdentity.GetByProviderSubject(db, providerName, identity.Subject)
+	switch {
+	case err == nil:
+		user, err = user.GetUserWithProfile(db, socialIdentity.UserID)
+		if err != nil {
+			return nil, http.StatusInternalServerError, fmt.Errorf("error fetching user " + err.Error())
+		}
+
+	case code != http.StatusNotFound:
+		return nil, code, err
+
+	case identity.Email == "":
+		return nil, http.StatusBadRequest, errors.New("the provider did not share an email address")
+
+	case postgresql.CheckExists(db, &user, "email = ?", identity.Email):
+		if !identity.EmailVerified {
+			return nil, http.StatusConflict, errors.New("an account with this email already exists, sign in and link the provider from your account")
+		}
+
+		user, err = user.GetUserWithProfile(db, user.ID)
+		if err != nil {
+			return nil, http.StatusInternalServerError, fmt.Errorf("error fetching user " + err.Error())
+		}
+
+	default:
+		user = models.User{
+			ID:    utility.GenerateUUID(),
+			Name:  strings.ToLower(identity.Name),
+			Email: identity.Email,
+			Role:  int(models.RoleIdentity.User),
+			Profile: models.Profile{
+				ID:        utility.GenerateUUID(),
+				AvatarURL: identity.AvatarURL,
+			},
+		}
+		if err := user.CreateUser(db); err != nil {
+			return nil, http.StatusInternalServerError, err
+		}
+		isNewUser = true
+	}
+
+	if socialIdentity.ID == "" {
+		socialIdentity = models.SocialIdentity{
+			ID:       utility.GenerateUUID(),
+			UserID:   user.ID,
+			Provider: providerName,
+			Subject:  identity.Subject,
+			Email:    identity.Email,
+		}
+		if err := socialIdentity.CreateSocialIdentity(db); err != nil {
+			return nil, http.StatusInternalServerError, err
+		}
+	}
+
+	if identity.EmailVerified && strings.EqualFold(identity.Email, user.Email) {
+		if err := markVerifiedByProvider(&user, db); err != nil {
+			return nil, http.StatusInternalServerError, err
+		}
+	} else if isNewUser {
+		if err := SendEmailVerification(user, db); err != nil {
+			return nil, http.StatusInternalServerError, err
+		}
+	}
+
+	if !user.IsVerified && EmailVerificationBlocksLogin() {
+		return nil, http.StatusForbidden, errEmailNotVerified
+	}
+
+	if err := socialIdentity.TouchLastLogin(db); err != nil {
+		return nil, http.StatusInternalServerError, err
+	}
+
+	twoFAEnabled, err := IsTwoFAEnabled(user.ID, db)
+	if err != nil {
+		return nil, http.StatusInternalServerError, err
+	}
+
+	if twoFAEnabled {
+		return TwoFAChallenge(user)
+	}
+
+	responseData, code, err := CreateLoginSession(user, db, client)
+	if err != nil {
+		return nil, code, err
+	}
+	responseData["user"].(map[string]string)["avatar_url"] = user.Profile.AvatarURL
+
+	if isNewUser {
+		welcomeReq := models.SendWelcomeMail{
+			Email: user.Email,
+		}
+
+		err = actions.AddNotificationToQueue(storage.DB.Redis, names.SendWelcomeMail, welcomeReq)
+		if err != nil {
+			return responseData, http.StatusInternalServerError, err
+		}
+
+		return responseData, http.StatusCreated, nil
+	}
+
+	return responseData, http.StatusOK, nil
+}
+
+func GetSocialIdentities(userID string, db *gorm.DB) ([]models.SocialIdentity, int, error) {
+	var socialIdentity models.SocialIdentity
+
+	identities, err := socialIdentity.GetUserIdentities(db, userID)
+	if err != nil {
+		return nil, http.StatusInternalServerError, err
+	}
+
+	return identities, http.StatusOK, nil
+}
+
+// UnlinkSocialIdentity removes a linked provider, unless it is the only way left
+// for the user to sign in.
+func UnlinkSocialIdentity(userID, identityID string, db *gorm.DB) (gin.H, int, error) {
+	var (
+		user           models.User
+		socialIdentity models.SocialIdentity
+	)
+
+	if !postgresql.CheckExists(db, &user, "id = ?", userID) {
+		return nil, http.StatusNotFound, errors.New("user not found")
+	}
+
+	identities, err := socialIdentity.GetUserIdentities(db, userID)
+	if err != nil {
+		return nil, http.StatusInternalServerError, err
+	}
+
+	for _, identity := range identities {
+		if identity.ID != identityID {
+			continue
+		}
+
+		if user.Password == "" && len(identities) == 1 {
+			return nil, http.StatusConflict, errors.New("cannot unlink the only sign in method, set a password first")
+		}
+
+		if err := identity.DeleteSocialIdentity(db); err != nil {
+			return nil, http.StatusInternalServerError, err
+		}
+
+		return gin.H{}, http.StatusOK, nil
+	}
+
+	return nil, http.StatusNotFound, errors.New("identity not found")
+}
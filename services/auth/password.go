@@ -49,6 +49,10 @@ func UpdateUserPassword(c *gin.Context, req models.ChangePasswordRequestModel, d
 		return nil, http.StatusConflict, errors.New("new password cannot be the same as the old password")
 	}
 
+	if code, err := ValidatePassword(db, "ChangePasswordRequestModel.NewPassword", req.NewPassword, &userDataExist); err != nil {
+		return nil, code, err
+	}
+
 	hashedPassword, err := utility.HashPassword(req.NewPassword)
 	if err != nil {
 		return nil, http.StatusBadRequest, err
@@ -60,6 +64,10 @@ func UpdateUserPassword(c *gin.Context, req models.ChangePasswordRequestModel, d
 		return nil, http.StatusBadRequest, err
 	}
 
+	if err := recordPassword(db, userDataExist.ID, hashedPassword); err != nil {
+		return nil, http.StatusInternalServerError, err
+	}
+
 	return &userDataExist, http.StatusOK, nil
 }
 
@@ -135,6 +143,10 @@ func VerifyPasswordResetToken(req models.ResetPasswordRequestModel, db *gorm.DB)
 		return nil, http.StatusNotFound, err
 	}
 
+	if code, err := ValidatePassword(db, "ResetPasswordRequestModel.NewPassword", req.NewPassword, &userDataExist); err != nil {
+		return nil, code, err
+	}
+
 	hashedPassword, err := utility.HashPassword(req.NewPassword)
 	if err != nil {
 		return nil, http.StatusBadRequest, err
@@ -146,6 +158,10 @@ func VerifyPasswordResetToken(req models.ResetPasswordRequestModel, db *gorm.DB)
 		return nil, http.StatusInternalServerError, err
 	}
 
+	if err := recordPassword(db, userDataExist.ID, hashedPassword); err != nil {
+		return nil, http.StatusInternalServerError, err
+	}
+
 	if err := resetExist.DeletePasswordReset(db); err != nil {
 		return nil, http.StatusInternalServerError, err
 	}
@@ -0,0 +1,164 @@
+package auth
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"unicode"
+
+	"gorm.io/gorm"
+
+	"github.com/hngprojects/hng_boilerplate_golang_web/internal/config"
+	"github.com/hngprojects/hng_boilerplate_golang_web/internal/models"
+	"github.com/hngprojects/hng_boilerplate_golang_web/utility"
+)
+
+const defaultPasswordMinLength = 8
+
+var (
+	breachedPasswords     *utility.BreachedPasswords
+	breachedPasswordsPath string
+	breachedPasswordsLock sync.Mutex
+)
+
+// getBreachedPasswords opens the configured breached password list once and
+// reuses it until the configured path changes.
+func getBreachedPasswords(path string) (*utility.BreachedPasswords, error) {
+	breachedPasswordsLock.Lock()
+	defer breachedPasswordsLock.Unlock()
+
+	if breachedPasswords != nil && breachedPasswordsPath == path {
+		return breachedPasswords, nil
+	}
+
+	list, err := utility.NewBreachedPasswords(path)
+	if err != nil {
+		return nil, err
+	}
+
+	breachedPasswords, breachedPasswordsPath = list, path
+	return list, nil
+}
+
+// ValidatePassword checks a new password against the configured policy. field
+// is the request field it came from, as reported by the validator. user is nil
+// when the password belongs to a new account, which has no history to check.
+func ValidatePassword(db *gorm.DB, field, password string, user *models.User) (int, error) {
+	var (
+		app      = config.GetConfig().App
+		name     = field[strings.LastIndex(field, ".")+1:]
+		messages []string
+	)
+
+	minLength := app.PasswordMinLength
+	if minLength <= 0 {
+		minLength = defaultPasswordMinLength
+	}
+
+	if len([]rune(password)) < minLength {
+		messages = append(messages, fmt.Sprintf("%v must be at least %d characters in length", name, minLength))
+	}
+
+	var hasUpper, hasLower, hasDigit, hasSymbol bool
+	for _, r := range password {
+		switch {
+		case unicode.IsUpper(r):
+			hasUpper = true
+		case unicode.IsLower(r):
+			hasLower = true
+		case unicode.IsDigit(r):
+			hasDigit = true
+		case unicode.IsPunct(r) || unicode.IsSymbol(r) || unicode.IsSpace(r):
+			hasSymbol = true
+		}
+	}
+
+	if app.PasswordRequireUpper && !hasUpper {
+		messages = append(messages, fmt.Sprintf("%v must contain an uppercase letter", name))
+	}
+	if app.PasswordRequireLower && !hasLower {
+		messages = append(messages, fmt.Sprintf("%v must contain a lowercase letter", name))
+	}
+	if app.PasswordRequireDigit && !hasDigit {
+		messages = append(messages, fmt.Sprintf("%v must contain a digit", name))
+	}
+	if app.PasswordRequireSymbol && !hasSymbol {
+		messages = append(messages, fmt.Sprintf("%v must contain a symbol", name))
+	}
+
+	if app.PasswordBreachedList != "" {
+		list, err := getBreachedPasswords(app.PasswordBreachedList)
+		if err != nil {
+			return http.StatusInternalServerError, err
+		}
+
+		breached, err := list.Contains(password)
+		if err != nil {
+			return http.StatusInternalServerError, err
+		}
+		if breached {
+			messages = append(messages, fmt.Sprintf("%v has appeared in a data breach and cannot be used", name))
+		}
+	}
+
+	if user != nil && app.PasswordHistoryCount > 0 {
+		reused, err := passwordReused(db, *user, password, app.PasswordHistoryCount)
+		if err != nil {
+			return http.StatusInternalServerError, err
+		}
+		if reused {
+			messages = append(messages, fmt.Sprintf("%v must not match any of your last %d passwords", name, app.PasswordHistoryCount))
+		}
+	}
+
+	if len(messages) > 0 {
+		return http.StatusUnprocessableEntity, &utility.FieldValidationError{Field: field, Messages: messages}
+	}
+
+	return http.StatusOK, nil
+}
+
+// passwordReused compares the password with the current one and the stored
+// history. Users created before the history existed only have the current one.
+func passwordReused(db *gorm.DB, user models.User, password string, count int) (bool, error) {
+	var history models.PasswordHistory
+
+	if user.Password != "" && utility.CompareHash(password, user.Password) {
+		return true, nil
+	}
+
+	previous, err := history.GetRecentPasswords(db, user.ID, count)
+	if err != nil {
+		return false, err
+	}
+
+	for _, entry := range previous {
+		if utility.CompareHash(password, entry.Password) {
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
+
+// recordPassword stores a newly set password hash in the history of the user,
+// keeping only as many entries as the policy checks.
+func recordPassword(db *gorm.DB, userID, hashedPassword string) error {
+	count := config.GetConfig().App.PasswordHistoryCount
+	if count <= 0 {
+		return nil
+	}
+
+	history := models.PasswordHistory{
+		ID:       utility.GenerateUUID(),
+		UserID:   userID,
+		Password: hashedPassword,
+	}
+
+	if err := history.CreatePasswordHistory(db); err != nil {
+		return err
+	}
+
+	return history.PrunePasswordHistory(db, userID, count)
+}
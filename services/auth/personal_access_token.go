@@ -0,0 +1,89 @@
+package auth
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+
+	"github.com/hngprojects/hng_boilerplate_golang_web/internal/models"
+	"github.com/hngprojects/hng_boilerplate_golang_web/utility"
+)
+
+const (
+	defaultPersonalAccessTokenDays = 30
+	personalAccessTokenHintLength  = 4
+)
+
+// CreatePersonalAccessToken issues a new token for the user. The token itself is
+// only returned here; afterwards it is identified by its name and hint.
+func CreatePersonalAccessToken(userID string, req models.CreatePersonalAccessTokenRequestModel, db *gorm.DB) (gin.H, int, error) {
+	days := req.ExpiresInDays
+	if days <= 0 {
+		days = defaultPersonalAccessTokenDays
+	}
+
+	secret, err := utility.GenerateSecureToken(32)
+	if err != nil {
+		return nil, http.StatusInternalServerError, err
+	}
+	token := models.PersonalAccessTokenPrefix + secret
+
+	var scopes []string
+	for _, scope := range req.Scopes {
+		if !utility.InStringSlice(scope, scopes) {
+			scopes = append(scopes, scope)
+		}
+	}
+
+	personalAccessToken := models.PersonalAccessToken{
+		ID:        utility.GenerateUUID(),
+		UserID:    userID,
+		Name:      req.Name,
+		TokenHash: utility.HashToken(token),
+		Hint:      token[:len(models.PersonalAccessTokenPrefix)+personalAccessTokenHintLength],
+		Scopes:    scopes,
+		ExpiresAt: time.Now().AddDate(0, 0, days),
+	}
+
+	if err := personalAccessToken.CreatePersonalAccessToken(db); err != nil {
+		return nil, http.StatusInternalServerError, err
+	}
+
+	return gin.H{
+		"token":                 token,
+		"personal_access_token": personalAccessToken,
+	}, http.StatusCreated, nil
+}
+
+func GetPersonalAccessTokens(userID string, db *gorm.DB) ([]models.PersonalAccessToken, int, error) {
+	var personalAccessToken models.PersonalAccessToken
+
+	tokens, err := personalAccessToken.GetUserTokens(db, userID)
+	if err != nil {
+		return nil, http.StatusInternalServerError, err
+	}
+
+	return tokens, http.StatusOK, nil
+}
+
+func RevokePersonalAccessToken(userID, tokenID string, db *gorm.DB) (gin.H, int, error) {
+	var personalAccessToken models.PersonalAccessToken
+
+	if !utility.IsValidUUID(tokenID) {
+		return nil, http.StatusNotFound, errors.New("personal access token not found")
+	}
+
+	revoked, err := personalAccessToken.Revoke(db, userID, tokenID)
+	if err != nil {
+		return nil, http.StatusInternalServerError, err
+	}
+
+	if !revoked {
+		return nil, http.StatusNotFound, errors.New("personal access token not found")
+	}
+
+	return gin.H{}, http.StatusOK, nil
+}
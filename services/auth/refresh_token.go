@@ -0,0 +1,169 @@
+package auth
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+
+	"github.com/hngprojects/hng_boilerplate_golang_web/internal/config"
+	"github.com/hngprojects/hng_boilerplate_golang_web/internal/models"
+	"github.com/hngprojects/hng_boilerplate_golang_web/pkg/middleware"
+	"github.com/hngprojects/hng_boilerplate_golang_web/utility"
+)
+
+var errInvalidRefreshToken = errors.New("invalid or expired refresh token")
+
+type SessionTokens struct {
+	*middleware.TokenDetailDTO
+	RefreshToken          string
+	RefreshTokenExpiresAt time.Time
+}
+
+// createSession signs an access token, stores it as a live session for the client
+// and issues the first refresh token of the session's family.
+func createSession(user models.User, db *gorm.DB, client models.SessionClient) (*SessionTokens, error) {
+	tokenData, err := middleware.CreateToken(user)
+	if err != nil {
+		return nil, err
+	}
+
+	tokens := map[string]string{
+		"access_token": tokenData.AccessToken,
+		"exp":          strconv.Itoa(int(tokenData.ExpiresAt.Unix())),
+	}
+
+	access_token := models.AccessToken{
+		ID:        tokenData.AccessUuid,
+		OwnerID:   user.ID,
+		UserAgent: client.UserAgent,
+		IPAddress: client.IPAddress,
+	}
+
+	err = access_token.CreateAccessToken(db, tokens)
+	if err != nil {
+		return nil, err
+	}
+
+	refreshToken, refreshExpiresAt, err := issueRefreshToken(db, user.ID, access_token.ID, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return &SessionTokens{
+		TokenDetailDTO:        tokenData,
+		RefreshToken:          refreshToken,
+		RefreshTokenExpiresAt: refreshExpiresAt,
+	}, nil
+}
+
+func issueRefreshToken(db *gorm.DB, ownerID, accessTokenID string, parentID *string) (string, time.Time, error) {
+	duration := config.GetConfig().Server.RefreshTokenExpireDuration
+	if duration <= 0 {
+		duration = 30
+	}
+
+	plain, err := utility.GenerateSecureToken(32)
+	if err != nil {
+		return "", time.Time{}, err
+	}
+
+	refreshToken := models.RefreshToken{
+		ID:            utility.GenerateUUID(),
+		OwnerID:       ownerID,
+		AccessTokenID: accessTokenID,
+		ParentID:      parentID,
+		TokenHash:     utility.HashToken(plain),
+		ExpiresAt:     time.Now().AddDate(0, 0, duration),
+	}
+
+	if err := refreshToken.CreateRefreshToken(db); err != nil {
+		return "", time.Time{}, err
+	}
+
+	return plain, refreshToken.ExpiresAt, nil
+}
+
+// RefreshAccessToken rotates a refresh token: the presented token is consumed and
+// a new access and refresh token pair is issued for the same session. Presenting a
+// token that was already used revokes the whole family and ends the session.
+func RefreshAccessToken(req models.RefreshTokenRequestModel, db *gorm.DB) (gin.H, int, error) {
+	var (
+		refreshToken models.RefreshToken
+		user         models.User
+	)
+
+	code, err := refreshToken.GetByTokenHash(db, utility.HashToken(req.RefreshToken))
+	if err != nil {
+		if code == http.StatusUnauthorized {
+			return nil, code, errInvalidRefreshToken
+		}
+		return nil, code, err
+	}
+
+	session := models.AccessToken{ID: refreshToken.AccessTokenID}
+
+	if refreshToken.RevokedAt != nil {
+		return nil, http.StatusUnauthorized, errInvalidRefreshToken
+	}
+
+	if refreshToken.UsedAt != nil {
+		return nil, http.StatusUnauthorized, revokeReusedFamily(db, session)
+	}
+
+	if time.Now().After(refreshToken.ExpiresAt) {
+		return nil, http.StatusUnauthorized, errInvalidRefreshToken
+	}
+
+	if _, err := session.GetByID(db); err != nil || !session.IsLive || session.OwnerID != refreshToken.OwnerID {
+		return nil, http.StatusUnauthorized, errInvalidRefreshToken
+	}
+
+	consumed, err := refreshToken.MarkUsed(db)
+	if err != nil {
+		return nil, http.StatusInternalServerError, err
+	}
+
+	if !consumed {
+		return nil, http.StatusUnauthorized, revokeReusedFamily(db, session)
+	}
+
+	user, err = user.GetUserByID(db, refreshToken.OwnerID)
+	if err != nil {
+		return nil, http.StatusUnauthorized, errInvalidRefreshToken
+	}
+
+	tokenData, err := middleware.CreateTokenForSession(user, session.ID)
+	if err != nil {
+		return nil, http.StatusInternalServerError, errors.New("error saving token: " + err.Error())
+	}
+
+	err = session.RotateLoginAccessToken(db, tokenData.AccessToken, strconv.Itoa(int(tokenData.ExpiresAt.Unix())))
+	if err != nil {
+		return nil, http.StatusInternalServerError, errors.New("error saving token: " + err.Error())
+	}
+
+	newRefreshToken, refreshExpiresAt, err := issueRefreshToken(db, user.ID, session.ID, &refreshToken.ID)
+	if err != nil {
+		return nil, http.StatusInternalServerError, errors.New("error saving token: " + err.Error())
+	}
+
+	return gin.H{
+		"access_token":       tokenData.AccessToken,
+		"expires_in":         strconv.Itoa(int(tokenData.ExpiresAt.Unix())),
+		"refresh_token":      newRefreshToken,
+		"refresh_expires_in": strconv.Itoa(int(refreshExpiresAt.Unix())),
+	}, http.StatusOK, nil
+}
+
+// revokeReusedFamily ends the session after a consumed refresh token was replayed,
+// since either the legitimate client or an attacker holds a stolen token.
+func revokeReusedFamily(db *gorm.DB, session models.AccessToken) error {
+	if err := session.RevokeAccessToken(db); err != nil {
+		return err
+	}
+	return errors.New("refresh token reuse detected, session revoked")
+}
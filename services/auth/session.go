@@ -0,0 +1,80 @@
+package auth
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+
+	"github.com/hngprojects/hng_boilerplate_golang_web/internal/models"
+	"github.com/hngprojects/hng_boilerplate_golang_web/pkg/repository/storage/postgresql"
+)
+
+// GetUserSessions lists the live sessions of a user. currentSessionID marks the
+// session the request was made with, if it belongs to the same user.
+func GetUserSessions(userID, currentSessionID string, db *gorm.DB) ([]models.SessionResponse, int, error) {
+	var (
+		user         models.User
+		access_token models.AccessToken
+	)
+
+	if !postgresql.CheckExists(db, &user, "id = ?", userID) {
+		return nil, http.StatusNotFound, errors.New("user not found")
+	}
+
+	sessions, err := access_token.GetLiveSessions(db, userID)
+	if err != nil {
+		return nil, http.StatusInternalServerError, err
+	}
+
+	response := make([]models.SessionResponse, 0, len(sessions))
+	for _, session := range sessions {
+		response = append(response, models.SessionResponse{
+			ID:           session.ID,
+			UserAgent:    session.UserAgent,
+			IPAddress:    session.IPAddress,
+			Current:      session.ID == currentSessionID,
+			Impersonated: session.ImpersonatorID != nil,
+			CreatedAt:    session.CreatedAt,
+			LastUsedAt:   session.LastUsedAt,
+		})
+	}
+
+	return response, http.StatusOK, nil
+}
+
+// RevokeUserSession revokes a single live session owned by userID.
+func RevokeUserSession(userID, sessionID string, db *gorm.DB) (gin.H, int, error) {
+	access_token := models.AccessToken{ID: sessionID}
+
+	if _, err := access_token.GetByID(db); err != nil || access_token.OwnerID != userID || !access_token.IsLive {
+		return nil, http.StatusNotFound, errors.New("session not found")
+	}
+
+	if err := access_token.RevokeAccessToken(db); err != nil {
+		return nil, http.StatusInternalServerError, errors.New("error revoking user session: " + err.Error())
+	}
+
+	return gin.H{}, http.StatusOK, nil
+}
+
+// RevokeOtherSessions revokes every live session of userID except keepSessionID.
+// An empty keepSessionID revokes all of them.
+func RevokeOtherSessions(userID, keepSessionID string, db *gorm.DB) (gin.H, int, error) {
+	var (
+		user         models.User
+		access_token models.AccessToken
+	)
+
+	if !postgresql.CheckExists(db, &user, "id = ?", userID) {
+		return nil, http.StatusNotFound, errors.New("user not found")
+	}
+
+	revoked, err := access_token.RevokeOwnerSessions(db, userID, keepSessionID)
+	if err != nil {
+		return nil, http.StatusInternalServerError, errors.New("error revoking user sessions: " + err.Error())
+	}
+
+	return gin.H{"revoked": revoked}, http.StatusOK, nil
+}
@@ -13,7 +13,6 @@ import (
 	"gorm.io/gorm"
 
 	"github.com/hngprojects/hng_boilerplate_golang_web/internal/models"
-	"github.com/hngprojects/hng_boilerplate_golang_web/pkg/middleware"
 	"github.com/hngprojects/hng_boilerplate_golang_web/pkg/repository/storage"
 	"github.com/hngprojects/hng_boilerplate_golang_web/pkg/repository/storage/postgresql"
 	"github.com/hngprojects/hng_boilerplate_golang_web/services/actions"
@@ -21,7 +20,7 @@ import (
 	"github.com/hngprojects/hng_boilerplate_golang_web/utility"
 )
 
-func CreateGoogleUser(req models.GoogleRequestModel, db *gorm.DB) (gin.H, int, error) {
+func CreateGoogleUser(req models.GoogleRequestModel, db *gorm.DB, client models.SessionClient) (gin.H, int, error) {
 
 	var (
 		userClaims   map[string]interface{}
@@ -82,20 +81,17 @@ func CreateGoogleUser(req models.GoogleRequestModel, db *gorm.DB) (gin.H, int, e
 		}
 	}
 
-	tokenData, err := middleware.CreateToken(user)
-	if err != nil {
-		return responseData, http.StatusInternalServerError, fmt.Errorf("error saving token: " + err.Error())
+	if verified, _ := userClaims["email_verified"].(bool); verified {
+		if err := markVerifiedByProvider(&user, db); err != nil {
+			return responseData, http.StatusInternalServerError, err
+		}
 	}
 
-	tokens := map[string]string{
-		"access_token": tokenData.AccessToken,
-		"exp":          strconv.Itoa(int(tokenData.ExpiresAt.Unix())),
+	if !user.IsVerified && EmailVerificationBlocksLogin() {
+		return responseData, http.StatusForbidden, errEmailNotVerified
 	}
 
-	access_token := models.AccessToken{ID: tokenData.AccessUuid, OwnerID: user.ID}
-
-	err = access_token.CreateAccessToken(db, tokens)
-
+	tokenData, err := createSession(user, db, client)
 	if err != nil {
 		return responseData, http.StatusInternalServerError, fmt.Errorf("error saving token: " + err.Error())
 	}
@@ -111,8 +107,9 @@ func CreateGoogleUser(req models.GoogleRequestModel, db *gorm.DB) (gin.H, int, e
 			"role":       string(models.UserRoleName),
 			"avatar_url": user.Profile.AvatarURL,
 		},
-		"access_token": tokenData.AccessToken,
-		"expires_in":   strconv.Itoa(int(tokenData.ExpiresAt.Unix())),
+		"access_token":  tokenData.AccessToken,
+		"refresh_token": tokenData.RefreshToken,
+		"expires_in":    strconv.Itoa(int(tokenData.ExpiresAt.Unix())),
 	}
 	if sendWelcome {
 		resetReq := models.SendWelcomeMail{
@@ -128,7 +125,7 @@ func CreateGoogleUser(req models.GoogleRequestModel, db *gorm.DB) (gin.H, int, e
 	return responseData, http.StatusCreated, nil
 }
 
-func CreateFacebookUser(req models.FacebookRequestModel, db *gorm.DB) (gin.H, int, error) {
+func CreateFacebookUser(req models.FacebookRequestModel, db *gorm.DB, client models.SessionClient) (gin.H, int, error) {
 
 	var userClaims models.GoogleClaims
 	var reqUser models.CreateUserRequestModel
@@ -178,22 +175,18 @@ func CreateFacebookUser(req models.FacebookRequestModel, db *gorm.DB) (gin.H, in
 		if err != nil {
 			return responseData, http.StatusInternalServerError, err
 		}
-	}
 
-	tokenData, err := middleware.CreateToken(user)
-	if err != nil {
-		return responseData, http.StatusInternalServerError, fmt.Errorf("error saving token: " + err.Error())
+		err = SendEmailVerification(user, db)
+		if err != nil {
+			return responseData, http.StatusInternalServerError, err
+		}
 	}
 
-	tokens := map[string]string{
-		"access_token": tokenData.AccessToken,
-		"exp":          strconv.Itoa(int(tokenData.ExpiresAt.Unix())),
+	if !user.IsVerified && EmailVerificationBlocksLogin() {
+		return responseData, http.StatusForbidden, errEmailNotVerified
 	}
 
-	access_token := models.AccessToken{ID: tokenData.AccessUuid, OwnerID: user.ID}
-
-	err = access_token.CreateAccessToken(db, tokens)
-
+	tokenData, err := createSession(user, db, client)
 	if err != nil {
 		return responseData, http.StatusInternalServerError, fmt.Errorf("error saving token: " + err.Error())
 	}
@@ -207,7 +200,8 @@ func CreateFacebookUser(req models.FacebookRequestModel, db *gorm.DB) (gin.H, in
 			"avatar_url": user.Profile.AvatarURL,
 			"expires_in": strconv.Itoa(int(tokenData.ExpiresAt.Unix())),
 		},
-		"access_token": tokenData.AccessToken,
+		"access_token":  tokenData.AccessToken,
+		"refresh_token": tokenData.RefreshToken,
 	}
 
 	return responseData, http.StatusCreated, nil
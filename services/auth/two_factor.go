@@ -0,0 +1,243 @@
+package auth
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang-jwt/jwt"
+	"github.com/pquerna/otp/totp"
+	"gorm.io/gorm"
+
+	"github.com/hngprojects/hng_boilerplate_golang_web/internal/models"
+	"github.com/hngprojects/hng_boilerplate_golang_web/pkg/middleware"
+	"github.com/hngprojects/hng_boilerplate_golang_web/pkg/repository/storage"
+	"github.com/hngprojects/hng_boilerplate_golang_web/pkg/repository/storage/redis"
+	"github.com/hngprojects/hng_boilerplate_golang_web/utility"
+)
+
+// twoFAChallengeMaxAttempts wrong second factors end a challenge, on top of
+// the account lockout, so one challenge token cannot be used to guess codes.
+const twoFAChallengeMaxAttempts = 5
+
+var errInvalidChallenge = errors.New("invalid or expired challenge token")
+
+func twoFAChallengeKey(kind, challengeID string) string {
+	return fmt.Sprintf("2fa:challenge:%v:%v", kind, challengeID)
+}
+
+// IsTwoFAEnabled reports whether the user has both switched 2FA on and
+// enrolled a TOTP key or a passkey, so nobody is locked out by the flag alone.
+func IsTwoFAEnabled(userID string, db *gorm.DB) (bool, error) {
+	var (
+		privacy    models.DataPrivacySettings
+		key        models.Key
+		credential models.WebAuthnCredential
+	)
+
+	settings, err := privacy.GetUserDataPrivacySettingsByID(db, userID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return false, nil
+		}
+		return false, err
+	}
+
+	if !settings.Enable2FA {
+		return false, nil
+	}
+
+	_, err = key.GetKeyByUserID(db, userID)
+	if err == nil {
+		return true, nil
+	}
+	if !errors.Is(err, gorm.ErrRecordNotFound) {
+		return false, err
+	}
+
+	return credential.HasCredentials(db, userID)
+}
+
+func TwoFAChallenge(user models.User) (gin.H, int, error) {
+	challenge, err := middleware.CreateTwoFAChallengeToken(user)
+	if err != nil {
+		return nil, http.StatusInternalServerError, errors.New("error creating 2fa challenge: " + err.Error())
+	}
+
+	// the challenge lives in redis until it is used, given up or expires
+	err = redis.RedisSetWithExpiry(storage.DB.Redis, twoFAChallengeKey("live", challenge.AccessUuid), user.ID, time.Until(challenge.ExpiresAt))
+	if err != nil {
+		return nil, http.StatusInternalServerError, err
+	}
+
+	return gin.H{
+		"two_factor_required": true,
+		"challenge_token":     challenge.AccessToken,
+		"expires_in":          strconv.Itoa(int(challenge.ExpiresAt.Unix())),
+	}, http.StatusOK, nil
+}
+
+// VerifyTwoFALogin exchanges a challenge token and a TOTP code for a session.
+func VerifyTwoFALogin(req models.TwoFALoginRequestModel, db *gorm.DB, client models.SessionClient) (gin.H, int, error) {
+	var (
+		user models.User
+		key  models.Key
+	)
+
+	userID, challengeID, err := ParseTwoFAChallenge(req.ChallengeToken)
+	if err != nil {
+		return nil, http.StatusUnauthorized, err
+	}
+
+	user, err = user.GetUserByID(db, userID)
+	if err != nil {
+		return nil, http.StatusUnauthorized, errInvalidChallenge
+	}
+
+	keyData, err := key.GetKeyByUserID(db, user.ID)
+	if err != nil {
+		return nil, http.StatusUnauthorized, errors.New("two factor authentication is not set up")
+	}
+
+	// wrong codes count towards the same lockout as wrong passwords
+	guard := newLoginGuard(user.Email, client)
+	if code, err := guard.check(); err != nil {
+		return nil, code, err
+	}
+
+	if req.Code != "" {
+		if !totp.Validate(req.Code, keyData.Key) {
+			if err := guard.recordFailure(&user); err != nil {
+				return nil, http.StatusInternalServerError, err
+			}
+			if err := recordChallengeFailure(challengeID); err != nil {
+				return nil, http.StatusInternalServerError, err
+			}
+			return nil, http.StatusUnauthorized, errors.New("invalid 2fa code")
+		}
+	} else {
+		valid, err := useRecoveryCode(db, user.ID, req.RecoveryCode)
+		if err != nil {
+			return nil, http.StatusInternalServerError, err
+		}
+
+		if !valid {
+			if err := guard.recordFailure(&user); err != nil {
+				return nil, http.StatusInternalServerError, err
+			}
+			if err := recordChallengeFailure(challengeID); err != nil {
+				return nil, http.StatusInternalServerError, err
+			}
+			return nil, http.StatusUnauthorized, errors.New("invalid recovery code")
+		}
+	}
+
+	if err := consumeTwoFAChallenge(challengeID); err != nil {
+		return nil, http.StatusUnauthorized, err
+	}
+
+	if err := guard.recordSuccess(); err != nil {
+		return nil, http.StatusInternalServerError, err
+	}
+
+	return CreateLoginSession(user, db, client)
+}
+
+// useRecoveryCode consumes the matching unused recovery code of the user, if any.
+func useRecoveryCode(db *gorm.DB, userID, code string) (bool, error) {
+	var recoveryCode models.RecoveryCode
+
+	code = strings.ToLower(strings.TrimSpace(code))
+
+	codes, err := recoveryCode.GetUnusedRecoveryCodes(db, userID)
+	if err != nil {
+		return false, err
+	}
+
+	for _, stored := range codes {
+		if utility.CompareHash(code, stored.CodeHash) {
+			return stored.MarkUsed(db)
+		}
+	}
+
+	return false, nil
+}
+
+// ParseTwoFAChallenge validates a challenge token that has not been used yet
+// and returns the user it was issued to with the id of the challenge.
+func ParseTwoFAChallenge(challengeToken string) (string, string, error) {
+	token, err := middleware.TokenValid(challengeToken)
+	if err != nil {
+		return "", "", errInvalidChallenge
+	}
+
+	claims, ok := token.Claims.(jwt.MapClaims)
+	if !ok {
+		return "", "", errInvalidChallenge
+	}
+
+	tokenType, _ := claims["token_type"].(string)
+	if tokenType != middleware.TwoFAChallengeTokenType {
+		return "", "", errInvalidChallenge
+	}
+
+	userID, ok := claims["user_id"].(string)
+	if !ok || userID == "" {
+		return "", "", errInvalidChallenge
+	}
+
+	challengeID, _ := claims["challenge_uuid"].(string)
+	data, err := redis.RedisGet(storage.DB.Redis, twoFAChallengeKey("live", challengeID))
+	if err != nil || challengeID == "" {
+		return "", "", errInvalidChallenge
+	}
+
+	var liveUserID string
+	if json.Unmarshal(data, &liveUserID) != nil || liveUserID != userID {
+		return "", "", errInvalidChallenge
+	}
+
+	return userID, challengeID, nil
+}
+
+// consumeTwoFAChallenge ends the challenge once its second factor passed.
+// Deleting it is what uses it up, so a concurrent request with the same token
+// loses.
+func consumeTwoFAChallenge(challengeID string) error {
+	rdb := storage.DB.Redis
+
+	deleted, err := redis.RedisDelete(rdb, twoFAChallengeKey("live", challengeID))
+	if err != nil || deleted != 1 {
+		return errInvalidChallenge
+	}
+
+	if _, err := redis.RedisDelete(rdb, twoFAChallengeKey("attempts", challengeID)); err != nil {
+		return err
+	}
+	return nil
+}
+
+// recordChallengeFailure counts a wrong second factor against the challenge,
+// which is ended after twoFAChallengeMaxAttempts.
+func recordChallengeFailure(challengeID string) error {
+	rdb := storage.DB.Redis
+
+	ttl, err := redis.RedisTTL(rdb, twoFAChallengeKey("live", challengeID))
+	if err != nil || ttl <= 0 {
+		return err
+	}
+
+	attempts, err := redis.RedisIncr(rdb, twoFAChallengeKey("attempts", challengeID), ttl)
+	if err != nil {
+		return err
+	}
+	if attempts >= twoFAChallengeMaxAttempts {
+		_, err = redis.RedisDelete(rdb, twoFAChallengeKey("live", challengeID))
+	}
+	return err
+}
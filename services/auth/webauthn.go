@@ -0,0 +1,597 @@
+package auth
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-webauthn/webauthn/protocol"
+	"github.com/go-webauthn/webauthn/webauthn"
+	"gorm.io/gorm"
+
+	"github.com/hngprojects/hng_boilerplate_golang_web/internal/config"
+	"github.com/hngprojects/hng_boilerplate_golang_web/internal/models"
+	"github.com/hngprojects/hng_boilerplate_golang_web/pkg/repository/storage"
+	"github.com/hngprojects/hng_boilerplate_golang_web/pkg/repository/storage/redis"
+	"github.com/hngprojects/hng_boilerplate_golang_web/services/key"
+	"github.com/hngprojects/hng_boilerplate_golang_web/utility"
+)
+
+const webAuthnSessionDuration = 5 * time.Minute
+
+// Purposes of a webauthn ceremony, so a challenge issued for one cannot be
+// answered in another.
+const (
+	webAuthnRegister = "register"
+	webAuthnLogin    = "login"
+	webAuthnTwoFA    = "2fa"
+)
+
+var (
+	errInvalidWebAuthnSession = errors.New("invalid or expired webauthn session")
+	errInvalidPasskey         = errors.New("passkey verification failed")
+)
+
+// webAuthnSession is kept in redis between the begin and finish steps of a
+// ceremony. UserID is empty for a discoverable login.
+type webAuthnSession struct {
+	Purpose string               `json:"purpose"`
+	UserID  string               `json:"user_id,omitempty"`
+	Data    webauthn.SessionData `json:"data"`
+}
+
+// webAuthnUser adapts a user and their stored credentials to the library.
+type webAuthnUser struct {
+	user        models.User
+	credentials []models.WebAuthnCredential
+}
+
+func (u webAuthnUser) WebAuthnID() []byte {
+	return []byte(u.user.ID)
+}
+
+func (u webAuthnUser) WebAuthnName() string {
+	return u.user.Email
+}
+
+func (u webAuthnUser) WebAuthnDisplayName() string {
+	if u.user.Name != "" {
+		return u.user.Name
+	}
+	return u.user.Email
+}
+
+func (u webAuthnUser) WebAuthnIcon() string {
+	return ""
+}
+
+func (u webAuthnUser) WebAuthnCredentials() []webauthn.Credential {
+	credentials := make([]webauthn.Credential, 0, len(u.credentials))
+
+	for _, stored := range u.credentials {
+		transports := make([]protocol.AuthenticatorTransport, 0, len(stored.Transports))
+		for _, transport := range stored.Transports {
+			transports = append(transports, protocol.AuthenticatorTransport(transport))
+		}
+
+		credentials = append(credentials, webauthn.Credential{
+			ID:              stored.CredentialID,
+			PublicKey:       stored.PublicKey,
+			AttestationType: stored.AttestationType,
+			Transport:       transports,
+			Flags: webauthn.CredentialFlags{
+				BackupEligible: stored.BackupEligible,
+				BackupState:    stored.BackupState,
+			},
+			Authenticator: webauthn.Authenticator{
+				AAGUID:    stored.AAGUID,
+				SignCount: uint32(stored.SignCount),
+			},
+		})
+	}
+
+	return credentials
+}
+
+// credential returns the stored credential the library matched an assertion to.
+func (u webAuthnUser) credential(id []byte) (models.WebAuthnCredential, bool) {
+	for _, stored := range u.credentials {
+		if bytes.Equal(stored.CredentialID, id) {
+			return stored, true
+		}
+	}
+	return models.WebAuthnCredential{}, false
+}
+
+func loadWebAuthnUser(db *gorm.DB, userID string) (webAuthnUser, error) {
+	var (
+		user       models.User
+		credential models.WebAuthnCredential
+	)
+
+	user, err := user.GetUserByID(db, userID)
+	if err != nil {
+		return webAuthnUser{}, err
+	}
+
+	credentials, err := credential.GetUserCredentials(db, userID)
+	if err != nil {
+		return webAuthnUser{}, err
+	}
+
+	return webAuthnUser{user: user, credentials: credentials}, nil
+}
+
+// getWebAuthn builds the relying party from config. Unset values fall back to
+// the host and origin of APP_URL.
+func getWebAuthn() (*webauthn.WebAuthn, error) {
+	var (
+		cfg     = config.GetConfig()
+		rpID    = cfg.WebAuthn.RPID
+		name    = cfg.WebAuthn.RPDisplayName
+		origins = cfg.WebAuthn.RPOrigins
+	)
+
+	if rpID == "" || len(origins) == 0 {
+		appURL, err := url.Parse(cfg.App.Url)
+		if err != nil || appURL.Host == "" {
+			return nil, errors.New("webauthn is not configured")
+		}
+		if rpID == "" {
+			rpID = appURL.Hostname()
+		}
+		if len(origins) == 0 {
+			origins = []string{appURL.Scheme + "://" + appURL.Host}
+		}
+	}
+
+	if name == "" {
+		name = cfg.App.Name
+	}
+
+	return webauthn.New(&webauthn.Config{
+		RPID:          rpID,
+		RPDisplayName: name,
+		RPOrigins:     origins,
+	})
+}
+
+func webAuthnSessionKey(sessionID string) string {
+	return "webauthn:session:" + utility.HashToken(sessionID)
+}
+
+func saveWebAuthnSession(purpose, userID string, data *webauthn.SessionData) (string, error) {
+	sessionID, err := utility.GenerateSecureToken(32)
+	if err != nil {
+		return "", err
+	}
+
+	value := webAuthnSession{Purpose: purpose, UserID: userID, Data: *data}
+	if err := redis.RedisSetWithExpiry(storage.DB.Redis, webAuthnSessionKey(sessionID), value, webAuthnSessionDuration); err != nil {
+		return "", err
+	}
+
+	return sessionID, nil
+}
+
+// consumeWebAuthnSession returns the stored ceremony once, so a challenge can
+// only be answered a single time.
+func consumeWebAuthnSession(sessionID, purpose string) (webAuthnSession, error) {
+	var (
+		value webAuthnSession
+		key   = webAuthnSessionKey(sessionID)
+	)
+
+	data, err := redis.RedisGet(storage.DB.Redis, key)
+	if err != nil || json.Unmarshal(data, &value) != nil {
+		return value, errInvalidWebAuthnSession
+	}
+
+	deleted, err := redis.RedisDelete(storage.DB.Redis, key)
+	if err != nil || deleted != 1 || value.Purpose != purpose {
+		return value, errInvalidWebAuthnSession
+	}
+
+	return value, nil
+}
+
+// BeginWebAuthnRegistration returns the options for navigator.credentials.create.
+// Credentials the user already has are excluded so a key is not added twice.
+func BeginWebAuthnRegistration(userID string, db *gorm.DB) (gin.H, int, error) {
+	wa, err := getWebAuthn()
+	if err != nil {
+		return nil, http.StatusInternalServerError, err
+	}
+
+	user, err := loadWebAuthnUser(db, userID)
+	if err != nil {
+		return nil, http.StatusNotFound, errors.New("user not found")
+	}
+
+	exclusions := make([]protocol.CredentialDescriptor, 0, len(user.credentials))
+	for _, credential := range user.WebAuthnCredentials() {
+		exclusions = append(exclusions, credential.Descriptor())
+	}
+
+	options, sessionData, err := wa.BeginRegistration(user,
+		webauthn.WithExclusions(exclusions),
+		webauthn.WithResidentKeyRequirement(protocol.ResidentKeyRequirementPreferred),
+	)
+	if err != nil {
+		return nil, http.StatusInternalServerError, err
+	}
+
+	sessionID, err := saveWebAuthnSession(webAuthnRegister, userID, sessionData)
+	if err != nil {
+		return nil, http.StatusInternalServerError, err
+	}
+
+	return gin.H{
+		"session_id": sessionID,
+		"options":    options,
+	}, http.StatusOK, nil
+}
+
+// FinishWebAuthnRegistration verifies the attestation and stores the credential.
+// With SecondFactor set, 2FA is switched on for the user.
+func FinishWebAuthnRegistration(userID string, req models.FinishWebAuthnRegistrationRequestModel, db *gorm.DB) (gin.H, int, error) {
+	session, err := consumeWebAuthnSession(req.SessionID, webAuthnRegister)
+	if err != nil || session.UserID != userID {
+		return nil, http.StatusBadRequest, errInvalidWebAuthnSession
+	}
+
+	wa, err := getWebAuthn()
+	if err != nil {
+		return nil, http.StatusInternalServerError, err
+	}
+
+	user, err := loadWebAuthnUser(db, userID)
+	if err != nil {
+		return nil, http.StatusNotFound, errors.New("user not found")
+	}
+
+	parsed, err := protocol.ParseCredentialCreationResponseBody(bytes.NewReader(req.Credential))
+	if err != nil {
+		return nil, http.StatusBadRequest, errors.New("invalid credential")
+	}
+
+	created, err := wa.CreateCredential(user, session.Data, parsed)
+	if err != nil {
+		return nil, http.StatusBadRequest, errors.New("credential verification failed")
+	}
+
+	transports := make([]string, 0, len(created.Transport))
+	for _, transport := range created.Transport {
+		transports = append(transports, string(transport))
+	}
+
+	credential := models.WebAuthnCredential{
+		ID:              utility.GenerateUUID(),
+		UserID:          userID,
+		Name:            req.Name,
+		CredentialID:    created.ID,
+		PublicKey:       created.PublicKey,
+		AttestationType: created.AttestationType,
+		AAGUID:          created.Authenticator.AAGUID,
+		Transports:      transports,
+		SignCount:       int64(created.Authenticator.SignCount),
+		BackupEligible:  created.Flags.BackupEligible,
+		BackupState:     created.Flags.BackupState,
+	}
+
+	err = db.Transaction(func(tx *gorm.DB) error {
+		if err := credential.CreateWebAuthnCredential(tx); err != nil {
+			return err
+		}
+		if req.SecondFactor {
+			return key.EnableTwoFA(tx, userID)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, http.StatusInternalServerError, err
+	}
+
+	return gin.H{"credential": credential}, http.StatusCreated, nil
+}
+
+func GetWebAuthnCredentials(userID string, db *gorm.DB) ([]models.WebAuthnCredential, int, error) {
+	var credential models.WebAuthnCredential
+
+	credentials, err := credential.GetUserCredentials(db, userID)
+	if err != nil {
+		return nil, http.StatusInternalServerError, err
+	}
+
+	return credentials, http.StatusOK, nil
+}
+
+func RenameWebAuthnCredential(userID, credentialID string, req models.RenameWebAuthnCredentialRequestModel, db *gorm.DB) (gin.H, int, error) {
+	var credential models.WebAuthnCredential
+
+	renamed, err := credential.Rename(db, userID, credentialID, req.Name)
+	if err != nil {
+		return nil, http.StatusInternalServerError, err
+	}
+	if !renamed {
+		return nil, http.StatusNotFound, errors.New("credential not found")
+	}
+
+	return gin.H{}, http.StatusOK, nil
+}
+
+// DeleteWebAuthnCredential removes a credential, unless it is the only way
+// left for the user to sign in.
+func DeleteWebAuthnCredential(userID, credentialID string, db *gorm.DB) (gin.H, int, error) {
+	user, err := loadWebAuthnUser(db, userID)
+	if err != nil {
+		return nil, http.StatusNotFound, errors.New("user not found")
+	}
+
+	found := false
+	for _, stored := range user.credentials {
+		found = found || stored.ID == credentialID
+	}
+	if !found {
+		return nil, http.StatusNotFound, errors.New("credential not found")
+	}
+
+	if user.user.Password == "" && len(user.credentials) == 1 {
+		var socialIdentity models.SocialIdentity
+
+		identities, err := socialIdentity.GetUserIdentities(db, userID)
+		if err != nil {
+			return nil, http.StatusInternalServerError, err
+		}
+		if len(identities) == 0 {
+			return nil, http.StatusConflict, errors.New("cannot delete the only sign in method, set a password first")
+		}
+	}
+
+	var credential models.WebAuthnCredential
+
+	deleted, err := credential.Delete(db, userID, credentialID)
+	if err != nil {
+		return nil, http.StatusInternalServerError, err
+	}
+	if !deleted {
+		return nil, http.StatusNotFound, errors.New("credential not found")
+	}
+
+	return gin.H{}, http.StatusOK, nil
+}
+
+// BeginWebAuthnLogin returns the options for navigator.credentials.get. Without
+// an email the browser offers any passkey it holds for this site.
+func BeginWebAuthnLogin(req models.BeginWebAuthnLoginRequestModel, db *gorm.DB) (gin.H, int, error) {
+	var (
+		options     *protocol.CredentialAssertion
+		sessionData *webauthn.SessionData
+		userID      string
+	)
+
+	wa, err := getWebAuthn()
+	if err != nil {
+		return nil, http.StatusInternalServerError, err
+	}
+
+	verification := webauthn.WithUserVerification(protocol.VerificationRequired)
+
+	var passkeyUser webAuthnUser
+	if req.Email != "" {
+		var user models.User
+
+		user, err = user.GetUserByEmail(db, req.Email)
+		if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, http.StatusInternalServerError, err
+		}
+		if err == nil {
+			passkeyUser, err = loadWebAuthnUser(db, user.ID)
+			if err != nil {
+				return nil, http.StatusInternalServerError, err
+			}
+			if len(passkeyUser.credentials) > 0 {
+				userID = user.ID
+			}
+		}
+	}
+
+	// an unknown email and an account without passkeys both fall back to a
+	// discoverable challenge, like a login without an email
+	if userID == "" {
+		options, sessionData, err = wa.BeginDiscoverableLogin(verification)
+	} else {
+		options, sessionData, err = wa.BeginLogin(passkeyUser, verification)
+	}
+	if err != nil {
+		return nil, http.StatusInternalServerError, err
+	}
+
+	sessionID, err := saveWebAuthnSession(webAuthnLogin, userID, sessionData)
+	if err != nil {
+		return nil, http.StatusInternalServerError, err
+	}
+
+	return gin.H{
+		"session_id": sessionID,
+		"options":    options,
+	}, http.StatusOK, nil
+}
+
+// FinishWebAuthnLogin signs the user in with a passkey. A user-verified passkey
+// is itself two factors, so no TOTP step follows.
+func FinishWebAuthnLogin(req models.FinishWebAuthnLoginRequestModel, db *gorm.DB, client models.SessionClient) (gin.H, int, error) {
+	var user webAuthnUser
+
+	session, err := consumeWebAuthnSession(req.SessionID, webAuthnLogin)
+	if err != nil {
+		return nil, http.StatusBadRequest, err
+	}
+
+	wa, err := getWebAuthn()
+	if err != nil {
+		return nil, http.StatusInternalServerError, err
+	}
+
+	parsed, err := protocol.ParseCredentialRequestResponseBody(bytes.NewReader(req.Credential))
+	if err != nil {
+		return nil, http.StatusBadRequest, errors.New("invalid credential")
+	}
+
+	userID := session.UserID
+	if userID == "" {
+		userID = string(parsed.Response.UserHandle)
+	}
+
+	user, err = loadWebAuthnUser(db, userID)
+	if err != nil {
+		return nil, http.StatusUnauthorized, errInvalidPasskey
+	}
+
+	guard := newLoginGuard(user.user.Email, client)
+	if code, err := guard.check(); err != nil {
+		return nil, code, err
+	}
+
+	var validated *webauthn.Credential
+	if session.UserID == "" {
+		validated, err = wa.ValidateDiscoverableLogin(func(_, _ []byte) (webauthn.User, error) {
+			return user, nil
+		}, session.Data, parsed)
+	} else {
+		validated, err = wa.ValidateLogin(user, session.Data, parsed)
+	}
+
+	if code, err := recordPasskeyUse(db, guard, user, validated, err); err != nil {
+		return nil, code, err
+	}
+
+	if !user.user.IsVerified && EmailVerificationBlocksLogin() {
+		return nil, http.StatusForbidden, errEmailNotVerified
+	}
+
+	return CreateLoginSession(user.user, db, client)
+}
+
+// BeginWebAuthnTwoFA starts an assertion for a user who passed the first
+// factor and holds a challenge token.
+func BeginWebAuthnTwoFA(req models.BeginWebAuthnTwoFARequestModel, db *gorm.DB) (gin.H, int, error) {
+	userID, _, err := ParseTwoFAChallenge(req.ChallengeToken)
+	if err != nil {
+		return nil, http.StatusUnauthorized, err
+	}
+
+	wa, err := getWebAuthn()
+	if err != nil {
+		return nil, http.StatusInternalServerError, err
+	}
+
+	user, err := loadWebAuthnUser(db, userID)
+	if err != nil {
+		return nil, http.StatusUnauthorized, errInvalidChallenge
+	}
+	if len(user.credentials) == 0 {
+		return nil, http.StatusBadRequest, errors.New("no passkey is registered for this account")
+	}
+
+	options, sessionData, err := wa.BeginLogin(user, webauthn.WithUserVerification(protocol.VerificationPreferred))
+	if err != nil {
+		return nil, http.StatusInternalServerError, err
+	}
+
+	sessionID, err := saveWebAuthnSession(webAuthnTwoFA, userID, sessionData)
+	if err != nil {
+		return nil, http.StatusInternalServerError, err
+	}
+
+	return gin.H{
+		"session_id": sessionID,
+		"options":    options,
+	}, http.StatusOK, nil
+}
+
+// FinishWebAuthnTwoFA exchanges a challenge token and an assertion for a
+// session, in place of a TOTP code.
+func FinishWebAuthnTwoFA(req models.FinishWebAuthnTwoFARequestModel, db *gorm.DB, client models.SessionClient) (gin.H, int, error) {
+	userID, challengeID, err := ParseTwoFAChallenge(req.ChallengeToken)
+	if err != nil {
+		return nil, http.StatusUnauthorized, err
+	}
+
+	session, err := consumeWebAuthnSession(req.SessionID, webAuthnTwoFA)
+	if err != nil || session.UserID != userID {
+		return nil, http.StatusBadRequest, errInvalidWebAuthnSession
+	}
+
+	wa, err := getWebAuthn()
+	if err != nil {
+		return nil, http.StatusInternalServerError, err
+	}
+
+	user, err := loadWebAuthnUser(db, userID)
+	if err != nil {
+		return nil, http.StatusUnauthorized, errInvalidChallenge
+	}
+
+	parsed, err := protocol.ParseCredentialRequestResponseBody(bytes.NewReader(req.Credential))
+	if err != nil {
+		return nil, http.StatusBadRequest, errors.New("invalid credential")
+	}
+
+	// failed assertions count towards the same lockout as wrong passwords
+	guard := newLoginGuard(user.user.Email, client)
+	if code, err := guard.check(); err != nil {
+		return nil, code, err
+	}
+
+	validated, err := wa.ValidateLogin(user, session.Data, parsed)
+	if code, err := recordPasskeyUse(db, guard, user, validated, err); err != nil {
+		if code == http.StatusUnauthorized {
+			if err := recordChallengeFailure(challengeID); err != nil {
+				return nil, http.StatusInternalServerError, err
+			}
+		}
+		return nil, code, err
+	}
+
+	if err := consumeTwoFAChallenge(challengeID); err != nil {
+		return nil, http.StatusUnauthorized, err
+	}
+
+	return CreateLoginSession(user.user, db, client)
+}
+
+// recordPasskeyUse settles the outcome of an assertion with the login guard
+// and stores the new sign count. A sign count that did not increase means the
+// key may have been cloned, so the login is refused.
+func recordPasskeyUse(db *gorm.DB, guard loginGuard, user webAuthnUser, validated *webauthn.Credential, validateErr error) (int, error) {
+	if validateErr != nil {
+		if err := guard.recordFailure(&user.user); err != nil {
+			return http.StatusInternalServerError, err
+		}
+		return http.StatusUnauthorized, errInvalidPasskey
+	}
+
+	if validated.Authenticator.CloneWarning {
+		return http.StatusUnauthorized, errors.New("this passkey may have been cloned, sign in another way")
+	}
+
+	stored, ok := user.credential(validated.ID)
+	if !ok {
+		return http.StatusUnauthorized, errInvalidPasskey
+	}
+
+	if err := stored.RecordLogin(db, validated.Authenticator.SignCount, validated.Flags.BackupState); err != nil {
+		return http.StatusInternalServerError, err
+	}
+
+	if err := guard.recordSuccess(); err != nil {
+		return http.StatusInternalServerError, err
+	}
+
+	return http.StatusOK, nil
+}
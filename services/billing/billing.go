@@ -1,7 +1,10 @@
 package billing
 
 import (
+	"encoding/json"
 	"errors"
+	"fmt"
+	"net/http"
 
 	"github.com/gin-gonic/gin"
 	"gorm.io/gorm"
@@ -11,24 +14,35 @@ import (
 	"github.com/hngprojects/hng_boilerplate_golang_web/utility"
 )
 
-func CreateBilling(req models.CreateBillingRequest, db *gorm.DB, userId string) (models.BillingResponse, error) {
+var (
+	ErrInvalidBillingStatus    = errors.New("status must be one of active, archived or all")
+	errArchivedWithSubscribers = errors.New("billing plan has subscribers and is already archived")
+)
+
+func CreateBilling(req models.CreateBillingRequest, db *gorm.DB, userId string, client models.SessionClient) (models.BillingResponse, error) {
 	var (
 		user        models.User
 		billingResp models.BillingResponse
 	)
 	Billing := models.Billing{
-		ID:    utility.GenerateUUID(),
-		Name:  req.Name,
-		Price: req.Price,
+		ID:     utility.GenerateUUID(),
+		Name:   req.Name,
+		Price:  req.Price,
+		Status: models.BillingStatusActive,
 	}
 
-	err := Billing.Create(db)
+	user, err := user.GetUserByID(db, userId)
 
 	if err != nil {
 		return billingResp, err
 	}
 
-	user, err = user.GetUserByID(db, userId)
+	err = db.Transaction(func(tx *gorm.DB) error {
+		if err := Billing.Create(tx); err != nil {
+			return err
+		}
+		return recordBillingAudit(tx, models.AuditActionBillingCreate, user.ID, Billing, req, client)
+	})
 
 	if err != nil {
 		return billingResp, err
@@ -38,6 +52,7 @@ func CreateBilling(req models.CreateBillingRequest, db *gorm.DB, userId string)
 		BillingID: Billing.ID,
 		Name:      Billing.Name,
 		Price:     Billing.Price,
+		Status:    Billing.Status,
 		CreatedAt: Billing.CreatedAt,
 		UpdatedAt: billingResp.UpdatedAt,
 	}
@@ -45,26 +60,112 @@ func CreateBilling(req models.CreateBillingRequest, db *gorm.DB, userId string)
 	return response, nil
 }
 
-func DeleteBilling(BillingId string, userId string, db *gorm.DB) error {
+// DeleteBilling removes a plan nobody is subscribed to. A plan that still has
+// subscribers is archived instead, which is reported with http.StatusOK. The
+// plan row stays locked from counting its subscribers until it is archived or
+// deleted, so concurrent requests can't act on a stale count.
+func DeleteBilling(BillingId string, userId string, db *gorm.DB, client models.SessionClient) (models.Billing, int, error) {
+	var (
+		Billing     models.Billing
+		subscribers int64
+	)
+
+	err := db.Transaction(func(tx *gorm.DB) error {
+		var err error
+
+		Billing, err = Billing.LockBillingById(tx, BillingId)
+		if err != nil {
+			return err
+		}
+
+		subscribers, err = Billing.CountSubscribers(tx)
+		if err != nil {
+			return err
+		}
+
+		if subscribers == 0 {
+			if err := Billing.Delete(tx); err != nil {
+				return err
+			}
+			return recordBillingAudit(tx, models.AuditActionBillingDelete, userId, Billing, nil, client)
+		}
+
+		if Billing.Status == models.BillingStatusArchived {
+			return errArchivedWithSubscribers
+		}
+		if err := Billing.SetStatus(tx, models.BillingStatusArchived); err != nil {
+			return err
+		}
+		return recordBillingAudit(tx, models.AuditActionBillingArchive, userId, Billing, gin.H{"subscribers": subscribers}, client)
+	})
+	if err != nil {
+		switch {
+		case errors.Is(err, gorm.ErrRecordNotFound):
+			return Billing, http.StatusNotFound, errors.New("billing not found")
+		case errors.Is(err, errArchivedWithSubscribers):
+			return Billing, http.StatusConflict, err
+		}
+		return Billing, http.StatusInternalServerError, err
+	}
+
+	if subscribers > 0 {
+		return Billing, http.StatusOK, nil
+	}
+	return Billing, http.StatusNoContent, nil
+}
+
+// SetBillingStatus archives a plan or brings an archived plan back.
+func SetBillingStatus(BillingId string, userId string, status string, db *gorm.DB, client models.SessionClient) (models.Billing, int, error) {
 	var Billing models.Billing
 
 	Billing, err := Billing.CheckBillingExists(BillingId, db)
 
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
-			return errors.New("Billing not found")
+			return Billing, http.StatusNotFound, errors.New("billing not found")
+		}
+		return Billing, http.StatusInternalServerError, err
+	}
+
+	if Billing.Status == status {
+		return Billing, http.StatusConflict, fmt.Errorf("billing plan is already %v", status)
+	}
+
+	action := models.AuditActionBillingArchive
+	if status == models.BillingStatusActive {
+		action = models.AuditActionBillingRestore
+	}
+
+	err = db.Transaction(func(tx *gorm.DB) error {
+		if err := Billing.SetStatus(tx, status); err != nil {
+			return err
 		}
-		return err
+		return recordBillingAudit(tx, action, userId, Billing, nil, client)
+	})
+	if err != nil {
+		return Billing, http.StatusInternalServerError, err
 	}
 
-	return Billing.Delete(db)
+	return Billing, http.StatusOK, nil
 }
 
+// GetBillings lists the plans with the requested status, active by default.
+// "all" lists every plan.
 func GetBillings(db *gorm.DB, c *gin.Context) (int, postgresql.PaginationResponse, error) {
 	var (
 		Billing models.Billing
+		status  = c.DefaultQuery("status", models.BillingStatusActive)
 	)
-	Billings, paginationResponse, err := Billing.GetAllBillings(db, c)
+
+	switch status {
+	case models.BillingStatusActive, models.BillingStatusArchived:
+	case "all":
+		status = ""
+	default:
+		return 0, postgresql.PaginationResponse{}, ErrInvalidBillingStatus
+	}
+
+	Billings, paginationResponse, err := Billing.GetAllBillings(db, c, status)
 
 	if err != nil {
 		return 0, paginationResponse, err
@@ -89,7 +190,7 @@ func GetBillingById(BillingId string, db *gorm.DB) (models.Billing, error) {
 	return resp, nil
 }
 
-func UpdateBillingById(BillingId string, userId string, req models.UpdateBillingRequest, db *gorm.DB) (models.Billing, error) {
+func UpdateBillingById(BillingId string, userId string, req models.UpdateBillingRequest, db *gorm.DB, client models.SessionClient) (models.Billing, error) {
 	var (
 		resp models.Billing
 	)
@@ -103,7 +204,12 @@ func UpdateBillingById(BillingId string, userId string, req models.UpdateBilling
 		return resp, err
 	}
 
-	_, err = resp.UpdateBillingById(db, req, BillingId)
+	err = db.Transaction(func(tx *gorm.DB) error {
+		if _, err := resp.UpdateBillingById(tx, req, BillingId); err != nil {
+			return err
+		}
+		return recordBillingAudit(tx, models.AuditActionBillingUpdate, userId, resp, req, client)
+	})
 
 	if err != nil {
 		return resp, err
@@ -111,3 +217,36 @@ func UpdateBillingById(BillingId string, userId string, req models.UpdateBilling
 
 	return resp, nil
 }
+
+// GetBillingAuditLogs returns the audit trail of a plan, newest first.
+func GetBillingAuditLogs(BillingId string, db *gorm.DB) ([]models.AuditLog, int, error) {
+	var auditLog models.AuditLog
+
+	logs, err := auditLog.GetTargetAuditLogs(db, "billing_plan", BillingId)
+	if err != nil {
+		return nil, http.StatusInternalServerError, err
+	}
+
+	return logs, http.StatusOK, nil
+}
+
+func recordBillingAudit(db *gorm.DB, action, actorID string, plan models.Billing, details interface{}, client models.SessionClient) error {
+	auditLog := models.AuditLog{
+		ID:         utility.GenerateUUID(),
+		ActorID:    actorID,
+		Action:     action,
+		TargetType: "billing_plan",
+		TargetID:   plan.ID,
+		IPAddress:  client.IPAddress,
+		UserAgent:  client.UserAgent,
+	}
+	if details != nil {
+		detailsJSON, err := json.Marshal(details)
+		if err != nil {
+			return err
+		}
+		auditLog.Details = string(detailsJSON)
+	}
+
+	return auditLog.CreateAuditLog(db)
+}
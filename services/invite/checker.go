@@ -4,6 +4,7 @@ import (
 	"errors"
 	"fmt"
 	"net/http"
+	"strings"
 	"time"
 
 	"github.com/gin-gonic/gin"
@@ -30,7 +31,7 @@ func CheckerValidator(base *storage.Database, inviteReq models.InvitationCreateR
 		return orgResp, http.StatusInternalServerError, "Internal server error", err
 	}
 	if !isAdmin {
-		return orgResp, http.StatusUnauthorized, "User is not an admin of the organisation", errors.New("User is not an admin of the organisation")
+		return orgResp, http.StatusForbidden, "User is not allowed to invite members to the organisation", errors.New("User is not allowed to invite members to the organisation")
 	}
 	return orgResp, http.StatusOK, "", nil
 }
@@ -85,7 +86,7 @@ func CheckerPostInvite(base *storage.Database, inviteReq models.InvitationReques
 	return orgResp, http.StatusOK, "", nil
 }
 
-func IteratorPostInvite(c *gin.Context, inviteReq models.InvitationRequest, base *storage.Database, logger *utility.Logger, org models.Organisation) (int, string, []mapper) {
+func IteratorPostInvite(c *gin.Context, inviteReq models.InvitationRequest, base *storage.Database, logger *utility.Logger, org models.Organisation, userId string) (int, string, []mapper) {
 	var invitations []mapper
 	var inviteErrors []mapper
 
@@ -93,6 +94,11 @@ func IteratorPostInvite(c *gin.Context, inviteReq models.InvitationRequest, base
 		return http.StatusBadRequest, "No emails provided", nil
 	}
 
+	inviter, code, err := user.GetUser(userId, base.Postgresql)
+	if err != nil {
+		return code, err.Error(), nil
+	}
+
 	// Loop through emails and create invitation
 	for _, email := range inviteReq.Emails {
 		if email == "" {
@@ -104,6 +110,7 @@ func IteratorPostInvite(c *gin.Context, inviteReq models.InvitationRequest, base
 			continue
 		}
 
+		email = strings.ToLower(email)
 		if _, valid := utility.EmailValid(email); !valid {
 			fmt.Println("Invalid email address: ", email)
 			inviteErrors = append(
@@ -114,12 +121,14 @@ func IteratorPostInvite(c *gin.Context, inviteReq models.InvitationRequest, base
 			continue
 		}
 
-		user, err := user.GetUserByEmail(email, base.Postgresql)
+		// the invitee does not need an account yet; one registered with the
+		// invite token joins the organisation on sign up
+		token, err := GenerateInvitationToken()
 		if err != nil {
 			inviteErrors = append(
 				inviteErrors,
 				map[string]interface{}{
-					"error": fmt.Sprintf("error getting user by email: %s", email),
+					"error": fmt.Sprintf("error creating invitation for email: %s", email),
 				},
 			)
 			continue
@@ -127,12 +136,10 @@ func IteratorPostInvite(c *gin.Context, inviteReq models.InvitationRequest, base
 
 		invitation := models.Invitation{
 			ID:             utility.GenerateUUID(),
-			UserID:         user.ID,
+			UserID:         inviter.ID,
 			OrganisationID: org.ID,
+			Token:          token,
 			Email:          email,
-			CreatedAt:      time.Now(),
-			ExpiresAt:      time.Now().Add(time.Hour * 24),
-			IsValid:        true,
 		}
 
 		err = invitation.CreateInvitation(base.Postgresql)
@@ -147,8 +154,7 @@ func IteratorPostInvite(c *gin.Context, inviteReq models.InvitationRequest, base
 			continue
 		}
 
-		// Send email
-		err = SendEmail(email, org.Name, invitation.ExpiresAt.Format(time.RFC3339))
+		err = queueInvitationMail(invitation, org, inviter)
 		if err != nil {
 			inviteErrors = append(
 				inviteErrors,
@@ -179,9 +185,3 @@ func IteratorPostInvite(c *gin.Context, inviteReq models.InvitationRequest, base
 
 	return http.StatusCreated, "Invitation(s) sent successfully", invitations
 }
-
-// write a dummy sending email functions
-func SendEmail(email string, orgName string, expiresAt string) error {
-	fmt.Println("Sending email to: ", email)
-	return nil
-}
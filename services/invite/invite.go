@@ -14,14 +14,17 @@ import (
 	"gorm.io/gorm"
 )
 
+// CheckUserIsAdmin reports whether the user may invite members to the
+// organisation. The org id comes from the request body on the invite routes,
+// so the check runs here rather than in RequireOrgPermission.
 func CheckUserIsAdmin(db *gorm.DB, user_id string, org_id string) (bool, error) {
 	var org models.Organisation
 
-	orgResp, err := org.GetOrgByID(db, org_id)
-	if err != nil {
-		return false, err
+	allowed, err := org.HasPermission(db, org_id, user_id, models.PermInviteMembers)
+	if errors.Is(err, models.ErrNotOrgMember) {
+		return false, nil
 	}
-	return orgResp.OwnerID == user_id, nil
+	return allowed, err
 }
 
 func CheckEmailsLimit(inviteReq models.InvitationRequest) bool {
@@ -110,6 +113,10 @@ func ExtractTokenFromInvitationLink(invitationLink string) string {
 
 func GetInvitationDetails(token string, db *gorm.DB) (models.Invitation, error) {
 	var invitation models.Invitation
+	// invitations created before tokens were issued have none
+	if token == "" {
+		return invitation, errors.New("Invalid invitation link format")
+	}
 	// Check if the invitation token exists in the database
 	exists := postgresql.CheckExists(db, &invitation, "token = ?", token)
 	// If it does, return the invitation details
@@ -144,9 +151,8 @@ func AcceptInvitationLink(user_id string, token string, db *gorm.DB) (models.Inv
 		return invitation, "Invalid invitation link", errors.New("Invalid invitation link")
 	}
 
-	// Set the invitation to invalid and save it to the database
-	invitation.IsValid = false
-	_, err = postgresql.SaveAllFields(db, &invitation)
+	// Close the invitation so the link cannot be used again
+	err = invitation.Respond(db, models.InvitationStatusAccepted)
 	if err != nil {
 		return invitation, "Error saving invitation", err
 	}
@@ -171,5 +177,5 @@ func AddUserToOrganisation(db *gorm.DB, orgID string, userId string) error {
 	if err != nil {
 		return err
 	}
-	return nil
+	return org.GrantDefaultRole(db, user.ID)
 }
@@ -0,0 +1,145 @@
+package invite
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+
+	"github.com/hngprojects/hng_boilerplate_golang_web/internal/models"
+	"github.com/hngprojects/hng_boilerplate_golang_web/pkg/repository/storage"
+	"github.com/hngprojects/hng_boilerplate_golang_web/pkg/repository/storage/postgresql"
+	"github.com/hngprojects/hng_boilerplate_golang_web/services/actions"
+	"github.com/hngprojects/hng_boilerplate_golang_web/services/actions/names"
+	"github.com/hngprojects/hng_boilerplate_golang_web/services/user"
+)
+
+var errInvalidInvitation = errors.New("invalid or expired invitation link")
+
+// ListOrgInvitations lists the invitations sent for an organisation,
+// optionally narrowed to one status.
+func ListOrgInvitations(orgID, status string, pagination postgresql.Pagination, db *gorm.DB) (gin.H, int, error) {
+	var invitation models.Invitation
+
+	invitations, paginationResponse, err := invitation.GetOrgInvitations(db, orgID, status, pagination)
+	if err != nil {
+		if errors.Is(err, models.ErrInvalidInvitationStatus) {
+			return nil, http.StatusBadRequest, err
+		}
+		return nil, http.StatusInternalServerError, err
+	}
+
+	invitationsResp := []models.OrgInvitationResponse{}
+	for _, inv := range invitations {
+		invitationsResp = append(invitationsResp, inv.Response())
+	}
+
+	return gin.H{
+		"invitations": invitationsResp,
+		"pagination":  paginationResponse,
+	}, http.StatusOK, nil
+}
+
+// RevokeInvitation withdraws a pending invitation so its link stops working.
+func RevokeInvitation(orgID, invitationID string, db *gorm.DB) (gin.H, int, error) {
+	var invitation models.Invitation
+
+	invitation, err := invitation.GetOrgInvitationByID(db, orgID, invitationID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, http.StatusNotFound, errors.New("invitation not found")
+		}
+		return nil, http.StatusInternalServerError, err
+	}
+
+	if invitation.Status != models.InvitationStatusPending {
+		return nil, http.StatusBadRequest, errors.New("only pending invitations can be revoked")
+	}
+
+	if err := invitation.Respond(db, models.InvitationStatusRevoked); err != nil {
+		return nil, http.StatusInternalServerError, err
+	}
+
+	resp := invitation.Response()
+	return gin.H{"invitation": resp}, http.StatusOK, nil
+}
+
+// ResendInvitation mails the invitation again under a fresh token and
+// expiry. Any invitation that has not been accepted can be resent, which
+// also reopens declined, revoked and expired ones.
+func ResendInvitation(orgID, invitationID, userID string, db *gorm.DB) (gin.H, int, error) {
+	var (
+		invitation models.Invitation
+		org        models.Organisation
+	)
+
+	invitation, err := invitation.GetOrgInvitationByID(db, orgID, invitationID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, http.StatusNotFound, errors.New("invitation not found")
+		}
+		return nil, http.StatusInternalServerError, err
+	}
+
+	if invitation.Status == models.InvitationStatusAccepted {
+		return nil, http.StatusBadRequest, errors.New("invitation has already been accepted")
+	}
+
+	org, err = org.GetOrgByID(db, orgID)
+	if err != nil {
+		return nil, http.StatusInternalServerError, err
+	}
+
+	inviter, code, err := user.GetUser(userID, db)
+	if err != nil {
+		return nil, code, err
+	}
+
+	token, err := GenerateInvitationToken()
+	if err != nil {
+		return nil, http.StatusInternalServerError, err
+	}
+
+	if err := invitation.Reissue(db, token, org.InvitationTTL()); err != nil {
+		return nil, http.StatusInternalServerError, err
+	}
+
+	if err := queueInvitationMail(invitation, org, inviter); err != nil {
+		return nil, http.StatusInternalServerError, err
+	}
+
+	resp := invitation.Response()
+	return gin.H{"invitation": resp}, http.StatusOK, nil
+}
+
+// DeclineInvitation lets the invitee turn an invitation down. Holding the
+// link is enough, so invitees without an account can decline too.
+func DeclineInvitation(req models.InvitationDeclineReq, db *gorm.DB) (gin.H, int, error) {
+	invitation, err := GetInvitationDetails(ExtractTokenFromInvitationLink(req.InvitationLink), db)
+	if err != nil {
+		return nil, http.StatusBadRequest, errInvalidInvitation
+	}
+
+	if !invitation.IsValid || invitation.CurrentStatus() != models.InvitationStatusPending {
+		return nil, http.StatusBadRequest, errInvalidInvitation
+	}
+
+	if err := invitation.Respond(db, models.InvitationStatusDeclined); err != nil {
+		return nil, http.StatusInternalServerError, err
+	}
+
+	return gin.H{}, http.StatusOK, nil
+}
+
+func queueInvitationMail(invitation models.Invitation, org models.Organisation, inviter models.User) error {
+	mailReq := models.SendInvitationMail{
+		Email:            invitation.Email,
+		OrganisationName: org.Name,
+		InviterName:      inviter.Name,
+		Token:            invitation.Token,
+		ExpiresAt:        invitation.ExpiresAt,
+	}
+
+	return actions.AddNotificationToQueue(storage.DB.Redis, names.SendInvitationMail, mailReq)
+}
@@ -1,8 +1,10 @@
 package key
 
 import (
+	"crypto/rand"
 	"errors"
 	"log"
+	"math/big"
 	"net/http"
 
 	"github.com/gin-gonic/gin"
@@ -61,9 +63,15 @@ func CreateKey(db *gorm.DB, c *gin.Context) (gin.H, int, error) {
 		return nil, http.StatusInternalServerError, err
 	}
 
+	recoveryCodes, err := issueRecoveryCodes(db, userID)
+	if err != nil {
+		return nil, http.StatusInternalServerError, err
+	}
+
 	return gin.H{
-		"secret":  secret.Secret(),
-		"qr_code": png,
+		"secret":         secret.Secret(),
+		"qr_code":        png,
+		"recovery_codes": recoveryCodes,
 	}, http.StatusCreated, nil
 }
 
@@ -82,7 +90,173 @@ func VerifyKey(req models.VerifyKeyRequestModel, db *gorm.DB, c *gin.Context) (g
 		return nil, http.StatusUnauthorized, errors.New("Invalid key")
 	}
 
+	if err := EnableTwoFA(db, userID); err != nil {
+		return nil, http.StatusInternalServerError, err
+	}
+
 	return gin.H{
 		"message": "Key verified successfully",
 	}, http.StatusOK, nil
 }
+
+// EnableTwoFA switches 2FA on once the user has proven their authenticator works,
+// after which LoginUser requires a TOTP code or a passkey.
+func EnableTwoFA(db *gorm.DB, userID string) error {
+	var privacyData models.DataPrivacySettings
+
+	settings, err := privacyData.GetUserDataPrivacySettingsByID(db, userID)
+	if err != nil {
+		if !errors.Is(err, gorm.ErrRecordNotFound) {
+			return err
+		}
+		settings = models.DataPrivacySettings{UserID: userID, Enable2FA: true}
+		return settings.CreateDataPrivacySettings(db)
+	}
+
+	settings.Enable2FA = true
+	return settings.UpdateDataPrivacySettings(db)
+}
+
+func RegenerateRecoveryCodes(req models.TwoFAPasswordRequestModel, db *gorm.DB, c *gin.Context) (gin.H, int, error) {
+	var key models.Key
+
+	userID, code, err := reauthenticate(req.Password, db, c)
+	if err != nil {
+		return nil, code, err
+	}
+
+	if _, err := key.GetKeyByUserID(db, userID); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, http.StatusBadRequest, errors.New("two factor authentication is not set up")
+		}
+		return nil, http.StatusInternalServerError, err
+	}
+
+	recoveryCodes, err := issueRecoveryCodes(db, userID)
+	if err != nil {
+		return nil, http.StatusInternalServerError, err
+	}
+
+	return gin.H{
+		"recovery_codes": recoveryCodes,
+	}, http.StatusOK, nil
+}
+
+func DisableTwoFA(req models.TwoFAPasswordRequestModel, db *gorm.DB, c *gin.Context) (gin.H, int, error) {
+	var (
+		key          models.Key
+		recoveryCode models.RecoveryCode
+		privacyData  models.DataPrivacySettings
+	)
+
+	userID, code, err := reauthenticate(req.Password, db, c)
+	if err != nil {
+		return nil, code, err
+	}
+
+	err = db.Transaction(func(tx *gorm.DB) error {
+		if err := key.DeleteKeyByUserID(tx, userID); err != nil {
+			return err
+		}
+
+		if err := recoveryCode.DeleteRecoveryCodes(tx, userID); err != nil {
+			return err
+		}
+
+		settings, err := privacyData.GetUserDataPrivacySettingsByID(tx, userID)
+		if err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return nil
+			}
+			return err
+		}
+
+		settings.Enable2FA = false
+		return settings.UpdateDataPrivacySettings(tx)
+	})
+	if err != nil {
+		return nil, http.StatusInternalServerError, err
+	}
+
+	return gin.H{}, http.StatusOK, nil
+}
+
+// reauthenticate confirms the caller still knows their password before a sensitive 2FA change.
+func reauthenticate(password string, db *gorm.DB, c *gin.Context) (string, int, error) {
+	var user models.User
+
+	userID, _ := middleware.GetIdFromToken(c)
+	if userID == "" {
+		return "", http.StatusBadRequest, errors.New("User is not authenticated")
+	}
+
+	user, err := user.GetUserByID(db, userID)
+	if err != nil {
+		return "", http.StatusNotFound, errors.New("User not found")
+	}
+
+	if !utility.CompareHash(password, user.Password) {
+		return "", http.StatusUnauthorized, errors.New("password is incorrect")
+	}
+
+	return userID, http.StatusOK, nil
+}
+
+const (
+	recoveryCodeCount    = 10
+	recoveryCodeAlphabet = "abcdefghjkmnpqrstuvwxyz23456789"
+)
+
+// issueRecoveryCodes replaces the user's recovery codes and returns the new plain codes.
+// Only bcrypt hashes are stored, so this is the only time the codes are visible.
+func issueRecoveryCodes(db *gorm.DB, userID string) ([]string, error) {
+	var (
+		recoveryCode models.RecoveryCode
+		plainCodes   = make([]string, 0, recoveryCodeCount)
+		records      = make([]models.RecoveryCode, 0, recoveryCodeCount)
+	)
+
+	for i := 0; i < recoveryCodeCount; i++ {
+		plain, err := generateRecoveryCode()
+		if err != nil {
+			return nil, err
+		}
+
+		hashed, err := utility.HashPassword(plain)
+		if err != nil {
+			return nil, err
+		}
+
+		plainCodes = append(plainCodes, plain)
+		records = append(records, models.RecoveryCode{
+			ID:       utility.GenerateUUID(),
+			UserID:   userID,
+			CodeHash: hashed,
+		})
+	}
+
+	if err := recoveryCode.ReplaceRecoveryCodes(db, userID, records); err != nil {
+		return nil, err
+	}
+
+	return plainCodes, nil
+}
+
+// generateRecoveryCode returns a random code formatted as xxxxx-xxxxx.
+func generateRecoveryCode() (string, error) {
+	code := make([]byte, 0, 11)
+	max := big.NewInt(int64(len(recoveryCodeAlphabet)))
+
+	for i := 0; i < 10; i++ {
+		if i == 5 {
+			code = append(code, '-')
+		}
+		n, err := rand.Int(rand.Reader, max)
+		if err != nil {
+			return "", err
+		}
+		code = append(code, recoveryCodeAlphabet[n.Int64()])
+	}
+
+	return string(code), nil
+}
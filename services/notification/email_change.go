@@ -0,0 +1,66 @@
+package notifications
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/hngprojects/hng_boilerplate_golang_web/internal/config"
+	"github.com/hngprojects/hng_boilerplate_golang_web/internal/models"
+	"github.com/hngprojects/hng_boilerplate_golang_web/services/send"
+)
+
+// SendEmailChangeConfirmMail goes to the new address, which has no account yet,
+// so the name travels with the notification.
+func (n NotificationObject) SendEmailChangeConfirmMail() error {
+	var (
+		notificationData     = models.SendEmailChangeMail{}
+		subject              = "Subject: Confirm your new email address"
+		templateFileName     = "email_change_confirm.html"
+		baseTemplateFileName = ""
+		configData           = config.GetConfig()
+	)
+
+	err := json.Unmarshal([]byte(n.Notification.Data), &notificationData)
+	if err != nil {
+		return fmt.Errorf("error decoding saved notification data, %v", err)
+	}
+
+	confirmUrl := fmt.Sprintf("%v/email-change/confirm?token=%v", configData.App.Url, notificationData.Token)
+
+	data, err := ConvertToMapAndAddExtraData(notificationData, map[string]interface{}{"firstname": thisOrThatStr(notificationData.Name, notificationData.Email), "confirm_url": confirmUrl})
+	if err != nil {
+		return fmt.Errorf("error converting data to map, %v", err)
+	}
+
+	return send.SendEmail(n.ExtReq, notificationData.Email, subject, templateFileName, baseTemplateFileName, data)
+}
+
+func (n NotificationObject) SendEmailChangeCancelMail() error {
+	var (
+		notificationData     = models.SendEmailChangeMail{}
+		subject              = "Subject: A change of your email address was requested"
+		templateFileName     = "email_change_cancel.html"
+		baseTemplateFileName = ""
+		configData           = config.GetConfig()
+		user                 models.User
+	)
+
+	err := json.Unmarshal([]byte(n.Notification.Data), &notificationData)
+	if err != nil {
+		return fmt.Errorf("error decoding saved notification data, %v", err)
+	}
+
+	user, err = user.GetUserByEmail(n.Db, notificationData.Email)
+	if err != nil {
+		return fmt.Errorf("error getting user with account id %v, %v", notificationData.Email, err)
+	}
+
+	cancelUrl := fmt.Sprintf("%v/email-change/cancel?token=%v", configData.App.Url, notificationData.Token)
+
+	data, err := ConvertToMapAndAddExtraData(notificationData, map[string]interface{}{"firstname": thisOrThatStr(user.Profile.FirstName, user.Email), "cancel_url": cancelUrl})
+	if err != nil {
+		return fmt.Errorf("error converting data to map, %v", err)
+	}
+
+	return send.SendEmail(n.ExtReq, user.Email, subject, templateFileName, baseTemplateFileName, data)
+}
@@ -0,0 +1,40 @@
+package notifications
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/hngprojects/hng_boilerplate_golang_web/internal/config"
+	"github.com/hngprojects/hng_boilerplate_golang_web/internal/models"
+	"github.com/hngprojects/hng_boilerplate_golang_web/services/send"
+)
+
+func (n NotificationObject) SendInvitationMail() error {
+	var (
+		notificationData     = models.SendInvitationMail{}
+		subject              = "Subject: You have been invited to join an organisation"
+		templateFileName     = "invitation.html"
+		baseTemplateFileName = ""
+		configData           = config.GetConfig()
+	)
+
+	err := json.Unmarshal([]byte(n.Notification.Data), &notificationData)
+	if err != nil {
+		return fmt.Errorf("error decoding saved notification data, %v", err)
+	}
+
+	// the invitee may not have an account yet, so there is no user to look up
+	inviteUrl := fmt.Sprintf("%v/invite/accept/%v", configData.App.Url, notificationData.Token)
+	declineUrl := fmt.Sprintf("%v/invite/decline/%v", configData.App.Url, notificationData.Token)
+
+	data, err := ConvertToMapAndAddExtraData(notificationData, map[string]interface{}{
+		"invite_url":  inviteUrl,
+		"decline_url": declineUrl,
+		"expires_at":  notificationData.ExpiresAt.Format("2 January 2006 15:04 MST"),
+	})
+	if err != nil {
+		return fmt.Errorf("error converting data to map, %v", err)
+	}
+
+	return send.SendEmail(n.ExtReq, notificationData.Email, subject, templateFileName, baseTemplateFileName, data)
+}
@@ -0,0 +1,40 @@
+package notifications
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/hngprojects/hng_boilerplate_golang_web/internal/config"
+	"github.com/hngprojects/hng_boilerplate_golang_web/internal/models"
+	"github.com/hngprojects/hng_boilerplate_golang_web/services/send"
+)
+
+func (n NotificationObject) SendOwnershipTransferMail() error {
+	var (
+		notificationData     = models.SendOwnershipTransferMail{}
+		subject              = "Subject: You have been asked to take over an organisation"
+		templateFileName     = "ownership_transfer.html"
+		baseTemplateFileName = ""
+		configData           = config.GetConfig()
+		user                 models.User
+	)
+
+	err := json.Unmarshal([]byte(n.Notification.Data), &notificationData)
+	if err != nil {
+		return fmt.Errorf("error decoding saved notification data, %v", err)
+	}
+
+	user, err = user.GetUserByEmail(n.Db, notificationData.Email)
+	if err != nil {
+		return fmt.Errorf("error getting user with account id %v, %v", notificationData.Email, err)
+	}
+
+	acceptUrl := fmt.Sprintf("%v/organisations/transfer/accept?token=%v", configData.App.Url, notificationData.Token)
+
+	data, err := ConvertToMapAndAddExtraData(notificationData, map[string]interface{}{"firstname": thisOrThatStr(user.Profile.FirstName, user.Email), "accept_url": acceptUrl})
+	if err != nil {
+		return fmt.Errorf("error converting data to map, %v", err)
+	}
+
+	return send.SendEmail(n.ExtReq, user.Email, subject, templateFileName, baseTemplateFileName, data)
+}
@@ -29,7 +29,7 @@ func (n NotificationObject) SendEmailVerificationMail() error {
 		return fmt.Errorf("error getting user with account id %v, %v", notificationData.Email, err)
 	}
 
-	verificationUrl := fmt.Sprintf("%v/email-verify/", configData.App.Url)
+	verificationUrl := fmt.Sprintf("%v/email-verify?token=%v", configData.App.Url, notificationData.Token)
 
 	data, err := ConvertToMapAndAddExtraData(notificationData, map[string]interface{}{"firstname": thisOrThatStr(user.Profile.FirstName, user.Email), "verification_url": verificationUrl})
 	if err != nil {
@@ -38,3 +38,30 @@ func (n NotificationObject) SendEmailVerificationMail() error {
 
 	return send.SendEmail(n.ExtReq, user.Email, subject, templateFileName, baseTemplateFileName, data)
 }
+
+func (n NotificationObject) SendEmailVerifiedMail() error {
+	var (
+		notificationData     = models.SendEmailVerifiedMail{}
+		subject              = "Subject: Your email address has been verified"
+		templateFileName     = "email_verified.html"
+		baseTemplateFileName = ""
+		user                 models.User
+	)
+
+	err := json.Unmarshal([]byte(n.Notification.Data), &notificationData)
+	if err != nil {
+		return fmt.Errorf("error decoding saved notification data, %v", err)
+	}
+
+	user, err = user.GetUserByEmail(n.Db, notificationData.Email)
+	if err != nil {
+		return fmt.Errorf("error getting user with account id %v, %v", notificationData.Email, err)
+	}
+
+	data, err := ConvertToMapAndAddExtraData(notificationData, map[string]interface{}{"firstname": thisOrThatStr(user.Profile.FirstName, user.Email)})
+	if err != nil {
+		return fmt.Errorf("error converting data to map, %v", err)
+	}
+
+	return send.SendEmail(n.ExtReq, user.Email, subject, templateFileName, baseTemplateFileName, data)
+}
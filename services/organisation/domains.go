@@ -0,0 +1,164 @@
+package organisation
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+
+	"github.com/hngprojects/hng_boilerplate_golang_web/external/thirdparty/dns"
+	"github.com/hngprojects/hng_boilerplate_golang_web/internal/models"
+	"github.com/hngprojects/hng_boilerplate_golang_web/utility"
+)
+
+// domainLookupTimeout bounds the TXT lookup made when verifying a domain.
+const domainLookupTimeout = 10 * time.Second
+
+var (
+	errDomainNotFound     = errors.New("domain not found")
+	errDomainVerifiedElse = errors.New("the domain is already verified by another organisation")
+)
+
+// ClaimOrgDomain records the organisation's claim on an email domain and
+// returns the TXT record to publish on it before verifying.
+func ClaimOrgDomain(req models.ClaimOrgDomainRequestModel, orgID string, db *gorm.DB) (gin.H, int, error) {
+	var orgDomain models.OrgDomain
+
+	domain := strings.TrimSuffix(strings.ToLower(req.Domain), ".")
+
+	if orgDomain.HasClaim(db, orgID, domain) {
+		return nil, http.StatusConflict, errors.New("the organisation has already claimed this domain")
+	}
+
+	verified, err := orgDomain.GetVerifiedDomain(db, domain)
+	if err != nil {
+		return nil, http.StatusInternalServerError, err
+	}
+	if verified != nil {
+		return nil, http.StatusConflict, errDomainVerifiedElse
+	}
+
+	token, err := utility.GenerateSecureToken(24)
+	if err != nil {
+		return nil, http.StatusInternalServerError, err
+	}
+
+	policy := req.JoinPolicy
+	if policy == "" {
+		policy = models.DomainJoinAuto
+	}
+
+	orgDomain = models.OrgDomain{
+		ID:                utility.GenerateUUID(),
+		OrganisationID:    orgID,
+		Domain:            domain,
+		VerificationToken: token,
+		JoinPolicy:        policy,
+	}
+
+	if err := orgDomain.CreateOrgDomain(db); err != nil {
+		return nil, http.StatusInternalServerError, err
+	}
+
+	return gin.H{"domain": orgDomain.Response()}, http.StatusCreated, nil
+}
+
+func GetOrgDomains(orgID string, db *gorm.DB) (gin.H, int, error) {
+	var orgDomain models.OrgDomain
+
+	domains, err := orgDomain.GetOrgDomains(db, orgID)
+	if err != nil {
+		return nil, http.StatusInternalServerError, err
+	}
+
+	domainsResp := []models.OrgDomainResponse{}
+	for _, domain := range domains {
+		domainsResp = append(domainsResp, domain.Response())
+	}
+
+	return gin.H{"domains": domainsResp}, http.StatusOK, nil
+}
+
+// VerifyOrgDomain looks for the claim's TXT record on the domain and marks
+// the claim verified once it is published.
+func VerifyOrgDomain(orgID, domainID string, db *gorm.DB) (gin.H, int, error) {
+	var orgDomain models.OrgDomain
+
+	orgDomain, err := orgDomain.GetOrgDomain(db, orgID, domainID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, http.StatusNotFound, errDomainNotFound
+		}
+		return nil, http.StatusInternalServerError, err
+	}
+
+	if orgDomain.VerifiedAt != nil {
+		return gin.H{"domain": orgDomain.Response()}, http.StatusOK, nil
+	}
+
+	verified, err := orgDomain.GetVerifiedDomain(db, orgDomain.Domain)
+	if err != nil {
+		return nil, http.StatusInternalServerError, err
+	}
+	if verified != nil {
+		return nil, http.StatusConflict, errDomainVerifiedElse
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), domainLookupTimeout)
+	defer cancel()
+
+	found, err := dns.HasTXTRecord(ctx, orgDomain.Domain, orgDomain.TXTRecord())
+	if err != nil {
+		return nil, http.StatusBadGateway, errors.New("unable to look up the domain's TXT records")
+	}
+	if !found {
+		return nil, http.StatusBadRequest, errors.New("the verification TXT record was not found on the domain")
+	}
+
+	if err := orgDomain.MarkVerified(db); err != nil {
+		return nil, http.StatusInternalServerError, err
+	}
+
+	return gin.H{"domain": orgDomain.Response()}, http.StatusOK, nil
+}
+
+func UpdateOrgDomain(req models.UpdateOrgDomainRequestModel, orgID, domainID string, db *gorm.DB) (gin.H, int, error) {
+	var orgDomain models.OrgDomain
+
+	orgDomain, err := orgDomain.GetOrgDomain(db, orgID, domainID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, http.StatusNotFound, errDomainNotFound
+		}
+		return nil, http.StatusInternalServerError, err
+	}
+
+	if err := orgDomain.UpdateJoinPolicy(db, req.JoinPolicy); err != nil {
+		return nil, http.StatusInternalServerError, err
+	}
+
+	return gin.H{"domain": orgDomain.Response()}, http.StatusOK, nil
+}
+
+// DeleteOrgDomain drops the claim. Members who joined through it stay.
+func DeleteOrgDomain(orgID, domainID string, db *gorm.DB) (gin.H, int, error) {
+	var orgDomain models.OrgDomain
+
+	orgDomain, err := orgDomain.GetOrgDomain(db, orgID, domainID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, http.StatusNotFound, errDomainNotFound
+		}
+		return nil, http.StatusInternalServerError, err
+	}
+
+	if err := orgDomain.DeleteOrgDomain(db); err != nil {
+		return nil, http.StatusInternalServerError, err
+	}
+
+	return gin.H{}, http.StatusOK, nil
+}
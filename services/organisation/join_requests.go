@@ -0,0 +1,92 @@
+package organisation
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+
+	"github.com/hngprojects/hng_boilerplate_golang_web/internal/models"
+)
+
+// GetJoinRequests lists the organisation's join requests, optionally only
+// those with the given status.
+func GetJoinRequests(orgID, status string, db *gorm.DB) (gin.H, int, error) {
+	var joinRequest models.OrgJoinRequest
+
+	switch status {
+	case "", models.JoinRequestPending, models.JoinRequestApproved, models.JoinRequestRejected:
+	default:
+		return nil, http.StatusBadRequest, errors.New("status must be one of pending, approved or rejected")
+	}
+
+	requests, err := joinRequest.GetOrgJoinRequests(db, orgID, status)
+	if err != nil {
+		return nil, http.StatusInternalServerError, err
+	}
+
+	return gin.H{"join_requests": requests}, http.StatusOK, nil
+}
+
+// ReviewJoinRequest approves or rejects a pending join request. An approved
+// user joins with the organisation's default role.
+func ReviewJoinRequest(orgID, requestID, reviewerID string, approve bool, db *gorm.DB) (gin.H, int, error) {
+	var (
+		org         models.Organisation
+		user        models.User
+		joinRequest models.OrgJoinRequest
+	)
+
+	joinRequest, err := joinRequest.GetOrgJoinRequest(db, orgID, requestID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, http.StatusNotFound, errors.New("join request not found")
+		}
+		return nil, http.StatusInternalServerError, err
+	}
+
+	if joinRequest.Status != models.JoinRequestPending {
+		return nil, http.StatusBadRequest, errors.New("the join request has already been reviewed")
+	}
+
+	if !approve {
+		if err := joinRequest.Review(db, models.JoinRequestRejected, reviewerID); err != nil {
+			return nil, http.StatusInternalServerError, err
+		}
+		return gin.H{"join_request": joinRequest}, http.StatusOK, nil
+	}
+
+	orgData, err := org.GetOrgByID(db, orgID)
+	if err != nil {
+		return nil, http.StatusInternalServerError, err
+	}
+
+	user, err = user.GetUserByID(db, joinRequest.UserID)
+	if err != nil {
+		return nil, http.StatusNotFound, errors.New("user not found")
+	}
+
+	isMember, err := orgData.CheckUserIsMemberOfOrg(user.ID, orgID, db)
+	if err != nil {
+		return nil, http.StatusInternalServerError, err
+	}
+
+	err = db.Transaction(func(tx *gorm.DB) error {
+		if !isMember {
+			if err := user.AddUserToOrganisation(tx, &user, []interface{}{&orgData}); err != nil {
+				return err
+			}
+			if err := orgData.GrantDefaultRole(tx, user.ID); err != nil {
+				return err
+			}
+		}
+
+		return joinRequest.Review(tx, models.JoinRequestApproved, reviewerID)
+	})
+	if err != nil {
+		return nil, http.StatusInternalServerError, err
+	}
+
+	return gin.H{"join_request": joinRequest}, http.StatusOK, nil
+}
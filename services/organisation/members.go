@@ -0,0 +1,169 @@
+package organisation
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+
+	"github.com/hngprojects/hng_boilerplate_golang_web/internal/models"
+)
+
+var (
+	errOwnerRole    = errors.New("the owner role belongs to the organisation owner only")
+	errTemplateRole = errors.New("built-in roles cannot be renamed or deleted")
+)
+
+// AssignMemberRole gives a member of the organisation one of its org roles,
+// replacing the role they held before. adminID must hold every permission
+// of the role and of the member, and cannot change their own.
+func AssignMemberRole(req models.AssignOrgRoleRequestModel, orgID, userID, adminID string, db *gorm.DB) (gin.H, int, error) {
+	var (
+		org        models.Organisation
+		role       models.OrgRole
+		membership models.UserOrganisation
+	)
+
+	orgData, err := org.CheckOrgExists(orgID, db)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, http.StatusNotFound, errors.New("organisation not found")
+		}
+		return nil, http.StatusBadRequest, err
+	}
+
+	if orgData.OwnerID == userID {
+		return nil, http.StatusBadRequest, errors.New("the owner holds every permission and cannot be given a role")
+	}
+
+	if userID == adminID {
+		return nil, http.StatusForbidden, errOwnRole
+	}
+
+	membership, err = membership.GetMembership(db, orgID, userID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, http.StatusNotFound, models.ErrNotOrgMember
+		}
+		return nil, http.StatusInternalServerError, err
+	}
+
+	roleData, err := role.GetAOrgRole(db, orgID, req.RoleID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, http.StatusNotFound, errors.New("role not found")
+		}
+		return nil, http.StatusInternalServerError, err
+	}
+
+	if roleData.Name == models.OrgRoleOwner {
+		return nil, http.StatusBadRequest, errOwnerRole
+	}
+
+	if code, err := checkCanManageMember(db, orgID, adminID, userID); err != nil {
+		return nil, code, err
+	}
+
+	if code, err := checkCanGrant(db, orgID, adminID, roleData.Permissions.PermissionList); err != nil {
+		return nil, code, err
+	}
+
+	if err := membership.AssignRole(db, &roleData.ID); err != nil {
+		return nil, http.StatusInternalServerError, err
+	}
+
+	return gin.H{
+		"user_id":         userID,
+		"organisation_id": orgID,
+		"role_id":         roleData.ID,
+		"role":            roleData.Name,
+	}, http.StatusOK, nil
+}
+
+// RemoveMemberRole takes the org role away from a member, leaving them with
+// no permissions in the organisation. adminID must hold every permission of
+// the member and cannot remove their own.
+func RemoveMemberRole(orgID, userID, adminID string, db *gorm.DB) (gin.H, int, error) {
+	var (
+		org        models.Organisation
+		membership models.UserOrganisation
+	)
+
+	_, err := org.CheckOrgExists(orgID, db)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, http.StatusNotFound, errors.New("organisation not found")
+		}
+		return nil, http.StatusBadRequest, err
+	}
+
+	if userID == adminID {
+		return nil, http.StatusForbidden, errOwnRole
+	}
+
+	membership, err = membership.GetMembership(db, orgID, userID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, http.StatusNotFound, models.ErrNotOrgMember
+		}
+		return nil, http.StatusInternalServerError, err
+	}
+
+	if code, err := checkCanManageMember(db, orgID, adminID, userID); err != nil {
+		return nil, code, err
+	}
+
+	if err := membership.AssignRole(db, nil); err != nil {
+		return nil, http.StatusInternalServerError, err
+	}
+
+	return gin.H{
+		"user_id":         userID,
+		"organisation_id": orgID,
+	}, http.StatusOK, nil
+}
+
+// SetDefaultOrgRole chooses the org role members get when they join through an
+// invitation or are added to the organisation. adminID must hold every
+// permission of the role.
+func SetDefaultOrgRole(orgID, roleID, adminID string, db *gorm.DB) (gin.H, int, error) {
+	var (
+		org  models.Organisation
+		role models.OrgRole
+	)
+
+	orgData, err := org.CheckOrgExists(orgID, db)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, http.StatusNotFound, errors.New("organisation not found")
+		}
+		return nil, http.StatusBadRequest, err
+	}
+
+	roleData, err := role.GetAOrgRole(db, orgID, roleID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, http.StatusNotFound, errors.New("role not found")
+		}
+		return nil, http.StatusInternalServerError, err
+	}
+
+	if roleData.Name == models.OrgRoleOwner {
+		return nil, http.StatusBadRequest, errOwnerRole
+	}
+
+	if code, err := checkCanGrant(db, orgID, adminID, roleData.Permissions.PermissionList); err != nil {
+		return nil, code, err
+	}
+
+	if err := orgData.SetDefaultRole(db, &roleData.ID); err != nil {
+		return nil, http.StatusInternalServerError, err
+	}
+
+	return gin.H{
+		"organisation_id": orgID,
+		"default_role_id": roleData.ID,
+		"default_role":    roleData.Name,
+	}, http.StatusOK, nil
+}
@@ -2,35 +2,19 @@ package organisation
 
 import (
 	"errors"
+	"fmt"
 	"net/http"
 	"strings"
 
 	"github.com/gin-gonic/gin"
 	"github.com/hngprojects/hng_boilerplate_golang_web/internal/models"
-	"github.com/hngprojects/hng_boilerplate_golang_web/pkg/middleware"
-	"github.com/hngprojects/hng_boilerplate_golang_web/services/user"
 	"github.com/hngprojects/hng_boilerplate_golang_web/utility"
 	"gorm.io/gorm"
 )
 
-func CreateOrgRoles(req models.OrgRole, orgID string, db *gorm.DB, c *gin.Context) (gin.H, int, error) {
+func CreateOrgRoles(req models.OrgRole, orgID, userID string, db *gorm.DB, c *gin.Context) (gin.H, int, error) {
 	var org models.Organisation
 
-	userId, err := middleware.GetUserClaims(c, db, "user_id")
-	if err != nil {
-		return nil, http.StatusNotFound, err
-	}
-
-	currentUserID, ok := userId.(string)
-	if !ok {
-		return nil, http.StatusBadRequest, errors.New("user_id is not of type string")
-	}
-
-	currentUser, code, err := user.GetUser(currentUserID, db)
-	if err != nil {
-		return nil, code, err
-	}
-
 	orgData, err := org.CheckOrgExists(orgID, db)
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
@@ -39,18 +23,24 @@ func CreateOrgRoles(req models.OrgRole, orgID string, db *gorm.DB, c *gin.Contex
 		return gin.H{}, http.StatusBadRequest, err
 	}
 
-	isOwner, err := org.IsOwnerOfOrganisation(db, currentUser.ID, orgData.ID)
-	if err != nil {
-		return nil, http.StatusBadRequest, err
+	if unknown := req.Permissions.PermissionList.UnknownKeys(); len(unknown) > 0 {
+		return gin.H{}, http.StatusUnprocessableEntity, fmt.Errorf("unknown permissions: %v", strings.Join(unknown, ", "))
 	}
 
-	if !isOwner {
-		return nil, http.StatusForbidden, errors.New("not organization owner")
+	if code, err := checkCanGrant(db, orgData.ID, userID, req.Permissions.PermissionList); err != nil {
+		return gin.H{}, code, err
 	}
 
 	req.ID = utility.GenerateUUID()
 	req.OrganisationID = orgData.ID
 
+	if len(req.Permissions.PermissionList) > 0 {
+		req.Permissions.ID = utility.GenerateUUID()
+		if req.Permissions.Category == "" {
+			req.Permissions.Category = req.Name
+		}
+	}
+
 	if err := req.CreateOrgRole(db); err != nil {
 		if strings.Contains(err.Error(), "duplicate key value") {
 			return gin.H{}, http.StatusConflict, errors.New("role name already exists")
@@ -75,22 +65,7 @@ func GetOrgRoles(db *gorm.DB, orgID string, c *gin.Context) ([]models.OrgRole, i
 		rolesData []models.OrgRole
 	)
 
-	userId, err := middleware.GetUserClaims(c, db, "user_id")
-	if err != nil {
-		return nil, http.StatusNotFound, err
-	}
-
-	currentUserID, ok := userId.(string)
-	if !ok {
-		return nil, http.StatusBadRequest, errors.New("user_id is not of type string")
-	}
-
-	currentUser, code, err := user.GetUser(currentUserID, db)
-	if err != nil {
-		return nil, code, err
-	}
-
-	orgData, err := org.CheckOrgExists(orgID, db)
+	_, err := org.CheckOrgExists(orgID, db)
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
 			return nil, http.StatusNotFound, errors.New("organisation not found")
@@ -98,15 +73,6 @@ func GetOrgRoles(db *gorm.DB, orgID string, c *gin.Context) ([]models.OrgRole, i
 		return nil, http.StatusBadRequest, err
 	}
 
-	isOwner, err := org.IsOwnerOfOrganisation(db, currentUser.ID, orgData.ID)
-	if err != nil {
-		return nil, http.StatusBadRequest, err
-	}
-
-	if !isOwner {
-		return nil, http.StatusForbidden, errors.New("not organization owner")
-	}
-
 	rolesData, err = role.GetOrgRoles(db, orgID)
 	if err != nil {
 		return nil, http.StatusBadRequest, err
@@ -122,22 +88,7 @@ func GetAOrgRole(db *gorm.DB, orgID, roleID string, c *gin.Context) (*models.Org
 		rolesData models.OrgRole
 	)
 
-	userId, err := middleware.GetUserClaims(c, db, "user_id")
-	if err != nil {
-		return nil, http.StatusNotFound, err
-	}
-
-	currentUserID, ok := userId.(string)
-	if !ok {
-		return nil, http.StatusBadRequest, errors.New("user_id is not of type string")
-	}
-
-	currentUser, code, err := user.GetUser(currentUserID, db)
-	if err != nil {
-		return nil, code, err
-	}
-
-	orgData, err := org.CheckOrgExists(orgID, db)
+	_, err := org.CheckOrgExists(orgID, db)
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
 			return nil, http.StatusNotFound, errors.New("organisation not found")
@@ -145,15 +96,6 @@ func GetAOrgRole(db *gorm.DB, orgID, roleID string, c *gin.Context) (*models.Org
 		return nil, http.StatusBadRequest, err
 	}
 
-	isOwner, err := org.IsOwnerOfOrganisation(db, currentUser.ID, orgData.ID)
-	if err != nil {
-		return nil, http.StatusBadRequest, err
-	}
-
-	if !isOwner {
-		return nil, http.StatusForbidden, errors.New("not organization owner")
-	}
-
 	rolesData, err = role.GetAOrgRole(db, orgID, roleID)
 	if err != nil {
 		return nil, http.StatusBadRequest, err
@@ -164,26 +106,13 @@ func GetAOrgRole(db *gorm.DB, orgID, roleID string, c *gin.Context) (*models.Org
 
 func DeleteOrgRole(db *gorm.DB, orgID, roleID string, c *gin.Context) (int, error) {
 	var (
-		org      models.Organisation
-		role     models.OrgRole
-		roleData models.OrgRole
+		org        models.Organisation
+		role       models.OrgRole
+		roleData   models.OrgRole
+		membership models.UserOrganisation
+		team       models.Team
 	)
 
-	userId, err := middleware.GetUserClaims(c, db, "user_id")
-	if err != nil {
-		return http.StatusNotFound, err
-	}
-
-	currentUserID, ok := userId.(string)
-	if !ok {
-		return http.StatusBadRequest, errors.New("user_id is not of type string")
-	}
-
-	currentUser, code, err := user.GetUser(currentUserID, db)
-	if err != nil {
-		return code, err
-	}
-
 	orgData, err := org.CheckOrgExists(orgID, db)
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
@@ -192,19 +121,37 @@ func DeleteOrgRole(db *gorm.DB, orgID, roleID string, c *gin.Context) (int, erro
 		return http.StatusBadRequest, err
 	}
 
-	isOwner, err := org.IsOwnerOfOrganisation(db, currentUser.ID, orgData.ID)
+	roleData, err = role.GetAOrgRole(db, orgID, roleID)
 	if err != nil {
 		return http.StatusBadRequest, err
 	}
 
-	if !isOwner {
-		return http.StatusForbidden, errors.New("not organization owner")
+	if roleData.IsTemplate() {
+		return http.StatusBadRequest, errTemplateRole
 	}
 
-	roleData, err = role.GetAOrgRole(db, orgID, roleID)
+	holders, err := membership.CountMembersWithRole(db, roleData.ID)
 	if err != nil {
-		return http.StatusBadRequest, err
+		return http.StatusInternalServerError, err
 	}
+	if holders > 0 {
+		return http.StatusConflict, errors.New("role is assigned to members, assign them another role first")
+	}
+
+	teams, err := team.CountTeamsWithRole(db, roleData.ID)
+	if err != nil {
+		return http.StatusInternalServerError, err
+	}
+	if teams > 0 {
+		return http.StatusConflict, errors.New("role is granted to teams, take it from them first")
+	}
+
+	if orgData.DefaultRoleID != nil && *orgData.DefaultRoleID == roleData.ID {
+		if err := orgData.SetDefaultRole(db, nil); err != nil {
+			return http.StatusInternalServerError, err
+		}
+	}
+
 	err = roleData.DeleteOrgRole(db)
 	if err != nil {
 		return http.StatusBadRequest, err
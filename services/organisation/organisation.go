@@ -51,21 +51,37 @@ func CreateOrganisation(req models.CreateOrgRequestModel, db *gorm.DB, userId st
 		Country:     strings.ToLower(req.Country),
 	}
 
-	err := org.CreateOrganisation(db)
+	var user models.User
+
+	user, err := user.GetUserByID(db, userId)
 
 	if err != nil {
 		return nil, err
 	}
 
-	var user models.User
+	err = db.Transaction(func(tx *gorm.DB) error {
+		if err := org.CreateOrganisation(tx); err != nil {
+			return err
+		}
 
-	user, err = user.GetUserByID(db, userId)
+		if err := user.AddUserToOrganisation(tx, &user, []interface{}{&org}); err != nil {
+			return err
+		}
 
-	if err != nil {
-		return nil, err
-	}
+		roles, err := org.CreateRoleTemplates(tx)
+		if err != nil {
+			return err
+		}
 
-	err = user.AddUserToOrganisation(db, &user, []interface{}{&org})
+		ownerRole := roles[models.OrgRoleOwner]
+		membership := models.UserOrganisation{UserID: user.ID, OrganisationID: org.ID}
+		if err := membership.AssignRole(tx, &ownerRole.ID); err != nil {
+			return err
+		}
+
+		memberRole := roles[models.OrgRoleMember]
+		return org.SetDefaultRole(tx, &memberRole.ID)
+	})
 
 	if err != nil {
 		return nil, err
@@ -184,7 +200,7 @@ func AddUserToOrganisation(orgId string, req models.AddUserToOrgRequestModel, db
 		return err
 	}
 
-	return nil
+	return org.GrantDefaultRole(db, user.ID)
 
 }
 
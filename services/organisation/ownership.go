@@ -0,0 +1,257 @@
+package organisation
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+
+	"github.com/hngprojects/hng_boilerplate_golang_web/internal/models"
+	"github.com/hngprojects/hng_boilerplate_golang_web/pkg/repository/storage"
+	"github.com/hngprojects/hng_boilerplate_golang_web/services/actions"
+	"github.com/hngprojects/hng_boilerplate_golang_web/services/actions/names"
+	"github.com/hngprojects/hng_boilerplate_golang_web/utility"
+)
+
+// ownershipTransferDuration is how long the nominee has to accept.
+const ownershipTransferDuration = 72 * time.Hour
+
+var (
+	errInvalidOwnershipTransfer = errors.New("invalid or expired ownership transfer link")
+	errNotOrgOwner              = errors.New("only the owner can transfer the organisation")
+	errNewOwnerNotMember        = errors.New("the new owner must be a member of the organisation")
+)
+
+// RequestOwnershipTransfer nominates a member as the new owner and mails them
+// a link to accept. A new nomination replaces the pending one.
+func RequestOwnershipTransfer(req models.TransferOwnershipRequestModel, orgID, ownerID string, db *gorm.DB) (gin.H, int, error) {
+	var (
+		org      models.Organisation
+		owner    models.User
+		nominee  models.User
+		transfer models.OwnershipTransfer
+	)
+
+	orgData, err := org.CheckOrgExists(orgID, db)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, http.StatusNotFound, errors.New("organisation not found")
+		}
+		return nil, http.StatusBadRequest, err
+	}
+
+	if orgData.OwnerID != ownerID {
+		return nil, http.StatusForbidden, errNotOrgOwner
+	}
+
+	if code, err := checkNewOwner(db, orgData, req.UserID); err != nil {
+		return nil, code, err
+	}
+
+	owner, err = owner.GetUserByID(db, ownerID)
+	if err != nil {
+		return nil, http.StatusNotFound, errors.New("user not found")
+	}
+
+	nominee, err = nominee.GetUserByID(db, req.UserID)
+	if err != nil {
+		return nil, http.StatusNotFound, errors.New("user not found")
+	}
+
+	token, err := utility.GenerateSecureToken(32)
+	if err != nil {
+		return nil, http.StatusInternalServerError, err
+	}
+
+	transfer = models.OwnershipTransfer{
+		ID:             utility.GenerateUUID(),
+		OrganisationID: orgData.ID,
+		FromUserID:     ownerID,
+		ToUserID:       nominee.ID,
+		TokenHash:      utility.HashToken(token),
+		ExpiresAt:      time.Now().Add(ownershipTransferDuration),
+	}
+
+	if err := transfer.ReplaceOwnershipTransfer(db); err != nil {
+		return nil, http.StatusInternalServerError, err
+	}
+
+	mailReq := models.SendOwnershipTransferMail{
+		Email:            nominee.Email,
+		OrganisationName: orgData.Name,
+		OwnerName:        owner.Name,
+		Token:            token,
+	}
+
+	err = actions.AddNotificationToQueue(storage.DB.Redis, names.SendOwnershipTransferMail, mailReq)
+	if err != nil {
+		return nil, http.StatusInternalServerError, err
+	}
+
+	return gin.H{
+		"organisation_id": orgData.ID,
+		"user_id":         nominee.ID,
+		"expires_at":      transfer.ExpiresAt,
+	}, http.StatusOK, nil
+}
+
+// CancelOwnershipTransfer withdraws the owner's pending nomination.
+func CancelOwnershipTransfer(orgID, ownerID string, db *gorm.DB) (gin.H, int, error) {
+	var (
+		org      models.Organisation
+		transfer models.OwnershipTransfer
+	)
+
+	orgData, err := org.CheckOrgExists(orgID, db)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, http.StatusNotFound, errors.New("organisation not found")
+		}
+		return nil, http.StatusBadRequest, err
+	}
+
+	if orgData.OwnerID != ownerID {
+		return nil, http.StatusForbidden, errNotOrgOwner
+	}
+
+	deleted, err := transfer.DeleteOrgTransfers(db, orgID)
+	if err != nil {
+		return nil, http.StatusInternalServerError, err
+	}
+
+	if !deleted {
+		return nil, http.StatusNotFound, errors.New("no pending ownership transfer")
+	}
+
+	return gin.H{}, http.StatusOK, nil
+}
+
+// AcceptOwnershipTransfer completes a transfer from the link mailed to the
+// nominee, who must be the one signed in.
+func AcceptOwnershipTransfer(req models.OwnershipTransferTokenRequestModel, userID string, db *gorm.DB) (gin.H, int, error) {
+	var (
+		org      models.Organisation
+		transfer models.OwnershipTransfer
+	)
+
+	transfer, err := transfer.GetByTokenHash(db, utility.HashToken(req.Token))
+	if err != nil {
+		return nil, http.StatusBadRequest, errInvalidOwnershipTransfer
+	}
+
+	if transfer.ToUserID != userID {
+		return nil, http.StatusForbidden, errors.New("this ownership transfer was offered to another user")
+	}
+
+	orgData, err := org.CheckOrgExists(transfer.OrganisationID, db)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, http.StatusNotFound, errors.New("organisation not found")
+		}
+		return nil, http.StatusBadRequest, err
+	}
+
+	// the link is void once the organisation has changed hands another way
+	if orgData.OwnerID != transfer.FromUserID {
+		return nil, http.StatusBadRequest, errInvalidOwnershipTransfer
+	}
+
+	if code, err := checkNewOwner(db, orgData, userID); err != nil {
+		return nil, code, err
+	}
+
+	err = db.Transaction(func(tx *gorm.DB) error {
+		deleted, err := transfer.DeleteOwnershipTransfer(tx)
+		if err != nil {
+			return err
+		}
+		if !deleted {
+			return errInvalidOwnershipTransfer
+		}
+
+		return orgData.TransferOwnership(tx, userID)
+	})
+	switch {
+	case errors.Is(err, errInvalidOwnershipTransfer):
+		return nil, http.StatusBadRequest, err
+	case err != nil:
+		return nil, http.StatusInternalServerError, err
+	}
+
+	return gin.H{
+		"organisation_id":   orgData.ID,
+		"owner_id":          userID,
+		"previous_owner_id": transfer.FromUserID,
+	}, http.StatusOK, nil
+}
+
+// ForceOwnershipTransfer lets a superadmin move an organisation to one of its
+// members without the owner, for example when the owner has left. The
+// transfer is recorded in the audit log.
+func ForceOwnershipTransfer(req models.TransferOwnershipRequestModel, orgID, adminID string, db *gorm.DB, client models.SessionClient) (gin.H, int, error) {
+	var org models.Organisation
+
+	orgData, err := org.CheckOrgExists(orgID, db)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, http.StatusNotFound, errors.New("organisation not found")
+		}
+		return nil, http.StatusBadRequest, err
+	}
+
+	if code, err := checkNewOwner(db, orgData, req.UserID); err != nil {
+		return nil, code, err
+	}
+
+	previousOwnerID := orgData.OwnerID
+
+	err = db.Transaction(func(tx *gorm.DB) error {
+		if err := orgData.TransferOwnership(tx, req.UserID); err != nil {
+			return err
+		}
+
+		auditLog := models.AuditLog{
+			ID:         utility.GenerateUUID(),
+			ActorID:    adminID,
+			Action:     models.AuditActionOwnershipForced,
+			TargetType: "organisation",
+			TargetID:   orgData.ID,
+			Details:    fmt.Sprintf("owner %v replaced by %v", previousOwnerID, req.UserID),
+			IPAddress:  client.IPAddress,
+			UserAgent:  client.UserAgent,
+		}
+		return auditLog.CreateAuditLog(tx)
+	})
+	if err != nil {
+		return nil, http.StatusInternalServerError, err
+	}
+
+	return gin.H{
+		"organisation_id":   orgData.ID,
+		"owner_id":          req.UserID,
+		"previous_owner_id": previousOwnerID,
+	}, http.StatusOK, nil
+}
+
+// checkNewOwner checks that newOwnerID is a member of the organisation who
+// does not already own it.
+func checkNewOwner(db *gorm.DB, org models.Organisation, newOwnerID string) (int, error) {
+	var membership models.UserOrganisation
+
+	if org.OwnerID == newOwnerID {
+		return http.StatusBadRequest, errors.New("the user already owns this organisation")
+	}
+
+	_, err := membership.GetMembership(db, org.ID, newOwnerID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return http.StatusBadRequest, errNewOwnerNotMember
+		}
+		return http.StatusInternalServerError, err
+	}
+
+	return http.StatusOK, nil
+}
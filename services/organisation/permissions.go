@@ -0,0 +1,90 @@
+package organisation
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"gorm.io/gorm"
+
+	"github.com/hngprojects/hng_boilerplate_golang_web/internal/models"
+)
+
+var errOwnRole = errors.New("you cannot change your own role")
+
+// GetPermissionCatalog returns the permission keys org roles can hold, grouped
+// by category.
+func GetPermissionCatalog() ([]models.PermissionCategory, int, error) {
+	return models.PermissionCatalog, http.StatusOK, nil
+}
+
+// missingPermissions returns the permissions of the list the user does not
+// hold, with the status to report when the lookup fails.
+func missingPermissions(db *gorm.DB, orgID, userID string, list models.PermissionList) ([]string, int, error) {
+	var org models.Organisation
+
+	missing, err := org.MissingPermissions(db, orgID, userID, list)
+	if err != nil {
+		if errors.Is(err, models.ErrNotOrgMember) {
+			return nil, http.StatusForbidden, err
+		}
+		return nil, http.StatusInternalServerError, err
+	}
+	return missing, http.StatusOK, nil
+}
+
+// checkCanGrant refuses to let the user hand out, through a role they create,
+// edit or assign, permissions they do not hold themselves. The owner may grant
+// any of them.
+func checkCanGrant(db *gorm.DB, orgID, userID string, list models.PermissionList) (int, error) {
+	missing, code, err := missingPermissions(db, orgID, userID, list)
+	if err != nil {
+		return code, err
+	}
+	if len(missing) > 0 {
+		return http.StatusForbidden, fmt.Errorf("you cannot grant permissions you do not hold: %v", strings.Join(missing, ", "))
+	}
+	return http.StatusOK, nil
+}
+
+// checkCanEditRole refuses to let the user change the permissions of a role
+// that holds permissions they do not, which would let them take access away
+// from the members above them who hold it.
+func checkCanEditRole(db *gorm.DB, orgID, userID string, role models.OrgRole) (int, error) {
+	missing, code, err := missingPermissions(db, orgID, userID, role.Permissions.PermissionList)
+	if err != nil {
+		return code, err
+	}
+	if len(missing) > 0 {
+		return http.StatusForbidden, fmt.Errorf("you cannot edit a role holding permissions you do not hold: %v", strings.Join(missing, ", "))
+	}
+	return http.StatusOK, nil
+}
+
+// checkCanManageMember refuses to let the user change the role of, or remove,
+// a member who holds permissions the user does not, so nobody can act on a
+// member above them. The owner may manage anyone.
+func checkCanManageMember(db *gorm.DB, orgID, userID, memberID string) (int, error) {
+	var org models.Organisation
+
+	permissions, isOwner, err := org.GetMemberPermissions(db, orgID, memberID)
+	if err != nil {
+		if errors.Is(err, models.ErrNotOrgMember) {
+			return http.StatusNotFound, err
+		}
+		return http.StatusInternalServerError, err
+	}
+	if isOwner {
+		return http.StatusForbidden, errors.New("the organisation owner cannot be managed by members")
+	}
+
+	missing, code, err := missingPermissions(db, orgID, userID, permissions)
+	if err != nil {
+		return code, err
+	}
+	if len(missing) > 0 {
+		return http.StatusForbidden, fmt.Errorf("you cannot manage a member holding permissions you do not hold: %v", strings.Join(missing, ", "))
+	}
+	return http.StatusOK, nil
+}
@@ -0,0 +1,182 @@
+package organisation
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+
+	"github.com/hngprojects/hng_boilerplate_golang_web/internal/models"
+	"github.com/hngprojects/hng_boilerplate_golang_web/pkg/repository/storage"
+	"github.com/hngprojects/hng_boilerplate_golang_web/services/actions"
+	"github.com/hngprojects/hng_boilerplate_golang_web/services/actions/names"
+	"github.com/hngprojects/hng_boilerplate_golang_web/utility"
+)
+
+var errReassignToNonMember = errors.New("resources can only be reassigned to a member of the organisation")
+
+// RemoveMember takes a member out of the organisation and emails them. The
+// products they created in the organisation's catalog are reassigned, to the
+// owner unless another member is named, or kept by the departing user,
+// following req.Resources. adminID must hold every permission of the member.
+func RemoveMember(req models.RemoveMemberRequestModel, orgID, userID, adminID string, db *gorm.DB, client models.SessionClient) (gin.H, int, error) {
+	var (
+		org  models.Organisation
+		user models.User
+	)
+
+	orgData, err := org.CheckOrgExists(orgID, db)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, http.StatusNotFound, errors.New("organisation not found")
+		}
+		return nil, http.StatusBadRequest, err
+	}
+
+	if orgData.OwnerID == userID {
+		return nil, http.StatusBadRequest, errors.New("the owner cannot be removed, ownership must be transferred first")
+	}
+
+	user, err = user.GetUserByID(db, userID)
+	if err != nil {
+		return nil, http.StatusNotFound, errors.New("user not found")
+	}
+
+	if adminID != userID {
+		if code, err := checkCanManageMember(db, orgID, adminID, userID); err != nil {
+			return nil, code, err
+		}
+	}
+
+	policy := req.Resources
+	if policy == "" {
+		policy = models.MemberResourcesReassign
+	}
+
+	reassignTo := req.ReassignTo
+	if reassignTo == "" {
+		reassignTo = orgData.OwnerID
+	}
+
+	respData, code, err := removeMember(db, orgData, userID, policy, reassignTo, models.AuditLog{
+		ActorID: adminID,
+		Action:  models.AuditActionMemberRemoved,
+		Details: fmt.Sprintf("member %v removed, resources %v", userID, policy),
+	}, client)
+	if err != nil {
+		return nil, code, err
+	}
+
+	if adminID != userID {
+		mailReq := models.SendMemberRemovedMail{
+			Email:            user.Email,
+			OrganisationName: orgData.Name,
+		}
+
+		err = actions.AddNotificationToQueue(storage.DB.Redis, names.SendMemberRemovedMail, mailReq)
+		if err != nil {
+			return respData, http.StatusInternalServerError, err
+		}
+	}
+
+	return respData, http.StatusOK, nil
+}
+
+// LeaveOrganisation lets a member leave the organisation. The products they
+// created stay in its catalog under the owner. The owner has to transfer the
+// organisation before leaving it.
+func LeaveOrganisation(orgID, userID string, db *gorm.DB, client models.SessionClient) (gin.H, int, error) {
+	var org models.Organisation
+
+	orgData, err := org.CheckOrgExists(orgID, db)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, http.StatusNotFound, errors.New("organisation not found")
+		}
+		return nil, http.StatusBadRequest, err
+	}
+
+	if orgData.OwnerID == userID {
+		return nil, http.StatusBadRequest, errors.New("the owner must transfer ownership before leaving the organisation")
+	}
+
+	return removeMember(db, orgData, userID, models.MemberResourcesReassign, orgData.OwnerID, models.AuditLog{
+		ActorID: userID,
+		Action:  models.AuditActionMemberLeft,
+		Details: fmt.Sprintf("member %v left", userID),
+	}, client)
+}
+
+// removeMember ends the membership and deals with the member's products in
+// one transaction, recording auditLog against the organisation.
+func removeMember(db *gorm.DB, org models.Organisation, userID, policy, reassignTo string, auditLog models.AuditLog, client models.SessionClient) (gin.H, int, error) {
+	var (
+		membership models.UserOrganisation
+		product    models.Product
+		moved      int64
+	)
+
+	_, err := membership.GetMembership(db, org.ID, userID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, http.StatusNotFound, models.ErrNotOrgMember
+		}
+		return nil, http.StatusInternalServerError, err
+	}
+
+	if policy == models.MemberResourcesReassign && reassignTo != org.OwnerID {
+		if reassignTo == userID {
+			return nil, http.StatusBadRequest, errReassignToNonMember
+		}
+
+		_, err := membership.GetMembership(db, org.ID, reassignTo)
+		if err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return nil, http.StatusBadRequest, errReassignToNonMember
+			}
+			return nil, http.StatusInternalServerError, err
+		}
+	}
+
+	err = db.Transaction(func(tx *gorm.DB) error {
+		var err error
+
+		switch policy {
+		case models.MemberResourcesKeep:
+			moved, err = product.ReleaseOrgProducts(tx, org.ID, userID)
+		default:
+			moved, err = product.ReassignOrgProducts(tx, org.ID, userID, reassignTo)
+		}
+		if err != nil {
+			return err
+		}
+
+		if err := org.RemoveMember(tx, userID); err != nil {
+			return err
+		}
+
+		auditLog.ID = utility.GenerateUUID()
+		auditLog.TargetType = "organisation"
+		auditLog.TargetID = org.ID
+		auditLog.IPAddress = client.IPAddress
+		auditLog.UserAgent = client.UserAgent
+		return auditLog.CreateAuditLog(tx)
+	})
+	if err != nil {
+		return nil, http.StatusInternalServerError, err
+	}
+
+	respData := gin.H{
+		"user_id":         userID,
+		"organisation_id": org.ID,
+		"resources":       policy,
+		"products":        moved,
+	}
+	if policy == models.MemberResourcesReassign {
+		respData["reassigned_to"] = reassignTo
+	}
+
+	return respData, http.StatusOK, nil
+}
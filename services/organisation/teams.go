@@ -0,0 +1,521 @@
+package organisation
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+
+	"github.com/hngprojects/hng_boilerplate_golang_web/internal/models"
+	"github.com/hngprojects/hng_boilerplate_golang_web/pkg/repository/storage/postgresql"
+	"github.com/hngprojects/hng_boilerplate_golang_web/utility"
+)
+
+var (
+	errTeamNotFound       = errors.New("team not found")
+	errTeamNameTaken      = errors.New("the organisation already has a team with this name")
+	errCannotManageTeam   = errors.New("only team leads and members allowed to manage teams can do this")
+	errTeamMemberNotFound = errors.New("user is not a member of this team")
+)
+
+// getTeam loads a team of the organisation for the user and reports whether
+// they may manage it, as one of its leads or with PermManageTeams. Users
+// outside the organisation are refused.
+func getTeam(db *gorm.DB, orgID, teamID, userID string) (models.Team, bool, int, error) {
+	var (
+		org        models.Organisation
+		team       models.Team
+		teamMember models.TeamMember
+	)
+
+	_, err := org.CheckOrgExists(orgID, db)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return team, false, http.StatusNotFound, errors.New("organisation not found")
+		}
+		return team, false, http.StatusBadRequest, err
+	}
+
+	canManage, err := org.HasPermission(db, orgID, userID, models.PermManageTeams)
+	if err != nil {
+		if errors.Is(err, models.ErrNotOrgMember) {
+			return team, false, http.StatusForbidden, err
+		}
+		return team, false, http.StatusInternalServerError, err
+	}
+
+	team, err = team.GetTeam(db, orgID, teamID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return team, false, http.StatusNotFound, errTeamNotFound
+		}
+		return team, false, http.StatusInternalServerError, err
+	}
+
+	if !canManage {
+		member, err := teamMember.GetTeamMember(db, team.ID, userID)
+		if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+			return team, false, http.StatusInternalServerError, err
+		}
+		canManage = err == nil && member.IsLead
+	}
+
+	return team, canManage, http.StatusOK, nil
+}
+
+func CreateTeam(req models.CreateTeamRequestModel, orgID string, db *gorm.DB) (gin.H, int, error) {
+	var team models.Team
+
+	name := strings.TrimSpace(req.Name)
+	if name == "" {
+		return nil, http.StatusBadRequest, errors.New("team name cannot be empty")
+	}
+
+	if team.NameTaken(db, orgID, name) {
+		return nil, http.StatusConflict, errTeamNameTaken
+	}
+
+	team = models.Team{
+		ID:             utility.GenerateUUID(),
+		OrganisationID: orgID,
+		Name:           name,
+		Description:    req.Description,
+	}
+
+	if err := team.CreateTeam(db); err != nil {
+		return nil, http.StatusInternalServerError, err
+	}
+
+	return gin.H{"team": team}, http.StatusCreated, nil
+}
+
+// GetTeams lists the organisation's teams to its members.
+func GetTeams(orgID, userID string, db *gorm.DB) (gin.H, int, error) {
+	var (
+		org  models.Organisation
+		team models.Team
+	)
+
+	_, err := org.CheckOrgExists(orgID, db)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, http.StatusNotFound, errors.New("organisation not found")
+		}
+		return nil, http.StatusBadRequest, err
+	}
+
+	_, _, err = org.GetMemberRole(db, orgID, userID)
+	if err != nil {
+		if errors.Is(err, models.ErrNotOrgMember) {
+			return nil, http.StatusForbidden, err
+		}
+		return nil, http.StatusInternalServerError, err
+	}
+
+	teams, err := team.GetTeams(db, orgID)
+	if err != nil {
+		return nil, http.StatusInternalServerError, err
+	}
+
+	return gin.H{"teams": teams}, http.StatusOK, nil
+}
+
+func GetTeam(orgID, teamID, userID string, db *gorm.DB) (gin.H, int, error) {
+	var teamMember models.TeamMember
+
+	team, _, code, err := getTeam(db, orgID, teamID, userID)
+	if err != nil {
+		return nil, code, err
+	}
+
+	members, err := teamMember.GetTeamMembers(db, team.ID)
+	if err != nil {
+		return nil, http.StatusInternalServerError, err
+	}
+
+	return gin.H{"team": team, "members": members}, http.StatusOK, nil
+}
+
+func UpdateTeam(req models.UpdateTeamRequestModel, orgID, teamID string, db *gorm.DB) (gin.H, int, error) {
+	var team models.Team
+
+	team, err := team.GetTeam(db, orgID, teamID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, http.StatusNotFound, errTeamNotFound
+		}
+		return nil, http.StatusInternalServerError, err
+	}
+
+	updates := map[string]interface{}{}
+	if req.Name != nil {
+		name := strings.TrimSpace(*req.Name)
+		if name == "" {
+			return nil, http.StatusBadRequest, errors.New("team name cannot be empty")
+		}
+		if team.NameTaken(db, orgID, name) {
+			return nil, http.StatusConflict, errTeamNameTaken
+		}
+		updates["name"] = name
+		team.Name = name
+	}
+	if req.Description != nil {
+		updates["description"] = *req.Description
+		team.Description = *req.Description
+	}
+
+	if len(updates) == 0 {
+		return nil, http.StatusBadRequest, errors.New("nothing to update")
+	}
+
+	if err := team.UpdateTeam(db, updates); err != nil {
+		return nil, http.StatusInternalServerError, err
+	}
+
+	return gin.H{"team": team}, http.StatusOK, nil
+}
+
+// DeleteTeam removes the team. Its members stay in the organisation and
+// resources shared with it stay where they are.
+func DeleteTeam(orgID, teamID string, db *gorm.DB) (gin.H, int, error) {
+	var team models.Team
+
+	team, err := team.GetTeam(db, orgID, teamID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, http.StatusNotFound, errTeamNotFound
+		}
+		return nil, http.StatusInternalServerError, err
+	}
+
+	err = db.Transaction(func(tx *gorm.DB) error {
+		return team.DeleteTeam(tx)
+	})
+	if err != nil {
+		return nil, http.StatusInternalServerError, err
+	}
+
+	return gin.H{}, http.StatusOK, nil
+}
+
+// SetTeamRole grants one of the organisation's roles to the team, so its
+// members hold the role's permissions on top of their own. The actor must
+// hold every permission of the role.
+func SetTeamRole(req models.AssignOrgRoleRequestModel, orgID, teamID, actorID string, db *gorm.DB) (gin.H, int, error) {
+	var (
+		team models.Team
+		role models.OrgRole
+	)
+
+	team, err := team.GetTeam(db, orgID, teamID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, http.StatusNotFound, errTeamNotFound
+		}
+		return nil, http.StatusInternalServerError, err
+	}
+
+	roleData, err := role.GetAOrgRole(db, orgID, req.RoleID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, http.StatusNotFound, errors.New("role not found")
+		}
+		return nil, http.StatusInternalServerError, err
+	}
+
+	if roleData.Name == models.OrgRoleOwner {
+		return nil, http.StatusBadRequest, errOwnerRole
+	}
+
+	if code, err := checkCanGrant(db, orgID, actorID, roleData.Permissions.PermissionList); err != nil {
+		return nil, code, err
+	}
+
+	if err := team.SetRole(db, &roleData.ID); err != nil {
+		return nil, http.StatusInternalServerError, err
+	}
+
+	return gin.H{
+		"team_id": team.ID,
+		"role_id": roleData.ID,
+		"role":    roleData.Name,
+	}, http.StatusOK, nil
+}
+
+func RemoveTeamRole(orgID, teamID string, db *gorm.DB) (gin.H, int, error) {
+	var team models.Team
+
+	team, err := team.GetTeam(db, orgID, teamID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, http.StatusNotFound, errTeamNotFound
+		}
+		return nil, http.StatusInternalServerError, err
+	}
+
+	if team.OrgRoleID == nil {
+		return nil, http.StatusBadRequest, errors.New("the team has no role")
+	}
+
+	if err := team.SetRole(db, nil); err != nil {
+		return nil, http.StatusInternalServerError, err
+	}
+
+	return gin.H{"team_id": team.ID}, http.StatusOK, nil
+}
+
+func GetTeamMembers(orgID, teamID, userID string, db *gorm.DB) (gin.H, int, error) {
+	var teamMember models.TeamMember
+
+	team, _, code, err := getTeam(db, orgID, teamID, userID)
+	if err != nil {
+		return nil, code, err
+	}
+
+	members, err := teamMember.GetTeamMembers(db, team.ID)
+	if err != nil {
+		return nil, http.StatusInternalServerError, err
+	}
+
+	return gin.H{"members": members}, http.StatusOK, nil
+}
+
+// AddTeamMember puts a member of the organisation in the team. Leads can add
+// members but only holders of PermManageTeams appoint leads, and since a
+// team's role reaches everyone in it, adding to a team with a role also
+// takes PermManageMembers and every permission of the role.
+func AddTeamMember(req models.AddTeamMemberRequestModel, orgID, teamID, actorID string, db *gorm.DB) (gin.H, int, error) {
+	var (
+		org        models.Organisation
+		teamMember models.TeamMember
+	)
+
+	team, canManage, code, err := getTeam(db, orgID, teamID, actorID)
+	if err != nil {
+		return nil, code, err
+	}
+	if !canManage {
+		return nil, http.StatusForbidden, errCannotManageTeam
+	}
+
+	if req.IsLead {
+		allowed, err := org.HasPermission(db, orgID, actorID, models.PermManageTeams)
+		if err != nil {
+			return nil, http.StatusInternalServerError, err
+		}
+		if !allowed {
+			return nil, http.StatusForbidden, fmt.Errorf("missing organisation permission: %v", models.PermManageTeams)
+		}
+	}
+
+	if team.OrgRoleID != nil {
+		allowed, err := org.HasPermission(db, orgID, actorID, models.PermManageMembers)
+		if err != nil {
+			return nil, http.StatusInternalServerError, err
+		}
+		if !allowed {
+			return nil, http.StatusForbidden, fmt.Errorf("missing organisation permission: %v", models.PermManageMembers)
+		}
+
+		var role models.OrgRole
+		role, err = role.GetAOrgRole(db, orgID, *team.OrgRoleID)
+		if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, http.StatusInternalServerError, err
+		}
+		if code, err := checkCanGrant(db, orgID, actorID, role.Permissions.PermissionList); err != nil {
+			return nil, code, err
+		}
+	}
+
+	_, _, err = org.GetMemberRole(db, orgID, req.UserID)
+	if err != nil {
+		if errors.Is(err, models.ErrNotOrgMember) {
+			return nil, http.StatusBadRequest, errors.New("only members of the organisation can join its teams")
+		}
+		return nil, http.StatusInternalServerError, err
+	}
+
+	_, err = teamMember.GetTeamMember(db, team.ID, req.UserID)
+	if err == nil {
+		return nil, http.StatusConflict, errors.New("user is already a member of this team")
+	}
+	if !errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, http.StatusInternalServerError, err
+	}
+
+	teamMember = models.TeamMember{
+		TeamID: team.ID,
+		UserID: req.UserID,
+		IsLead: req.IsLead,
+	}
+
+	if err := teamMember.AddTeamMember(db); err != nil {
+		return nil, http.StatusInternalServerError, err
+	}
+
+	return gin.H{"member": teamMember}, http.StatusCreated, nil
+}
+
+// UpdateTeamMember appoints or stands down a team lead.
+func UpdateTeamMember(req models.UpdateTeamMemberRequestModel, orgID, teamID, userID string, db *gorm.DB) (gin.H, int, error) {
+	var (
+		team       models.Team
+		teamMember models.TeamMember
+	)
+
+	team, err := team.GetTeam(db, orgID, teamID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, http.StatusNotFound, errTeamNotFound
+		}
+		return nil, http.StatusInternalServerError, err
+	}
+
+	teamMember, err = teamMember.GetTeamMember(db, team.ID, userID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, http.StatusNotFound, errTeamMemberNotFound
+		}
+		return nil, http.StatusInternalServerError, err
+	}
+
+	if err := teamMember.SetLead(db, req.IsLead); err != nil {
+		return nil, http.StatusInternalServerError, err
+	}
+
+	return gin.H{"member": teamMember}, http.StatusOK, nil
+}
+
+// RemoveTeamMember takes a user out of the team. Leads and holders of
+// PermManageTeams can remove anyone, and members can leave on their own.
+func RemoveTeamMember(orgID, teamID, userID, actorID string, db *gorm.DB) (gin.H, int, error) {
+	var teamMember models.TeamMember
+
+	team, canManage, code, err := getTeam(db, orgID, teamID, actorID)
+	if err != nil {
+		return nil, code, err
+	}
+	if !canManage && userID != actorID {
+		return nil, http.StatusForbidden, errCannotManageTeam
+	}
+
+	teamMember, err = teamMember.GetTeamMember(db, team.ID, userID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, http.StatusNotFound, errTeamMemberNotFound
+		}
+		return nil, http.StatusInternalServerError, err
+	}
+
+	if err := teamMember.RemoveTeamMember(db); err != nil {
+		return nil, http.StatusInternalServerError, err
+	}
+
+	return gin.H{"team_id": team.ID, "user_id": userID}, http.StatusOK, nil
+}
+
+func GetTeamResources(orgID, teamID, userID string, db *gorm.DB) (gin.H, int, error) {
+	var share models.TeamResource
+
+	team, _, code, err := getTeam(db, orgID, teamID, userID)
+	if err != nil {
+		return nil, code, err
+	}
+
+	resources, err := share.GetTeamResources(db, team.ID)
+	if err != nil {
+		return nil, http.StatusInternalServerError, err
+	}
+
+	return gin.H{"resources": resources}, http.StatusOK, nil
+}
+
+// ShareTeamResource shares a product from the organisation's catalog, which
+// the actor must be able to manage, or a template with the team.
+func ShareTeamResource(req models.ShareTeamResourceRequestModel, orgID, teamID, actorID string, db *gorm.DB) (gin.H, int, error) {
+	var share models.TeamResource
+
+	team, canManage, code, err := getTeam(db, orgID, teamID, actorID)
+	if err != nil {
+		return nil, code, err
+	}
+	if !canManage {
+		return nil, http.StatusForbidden, errCannotManageTeam
+	}
+
+	switch req.ResourceType {
+	case models.TeamResourceProduct:
+		var product models.Product
+
+		product, err := product.GetProduct(db, req.ResourceID)
+		if err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return nil, http.StatusNotFound, errors.New("product not found")
+			}
+			return nil, http.StatusInternalServerError, err
+		}
+
+		if product.OrganisationID == nil || *product.OrganisationID != orgID {
+			return nil, http.StatusBadRequest, errors.New("only products in the organisation's catalog can be shared with its teams")
+		}
+
+		allowed, err := product.CanBeManagedBy(db, actorID)
+		if err != nil {
+			return nil, http.StatusInternalServerError, err
+		}
+		if !allowed {
+			return nil, http.StatusForbidden, errors.New("you cannot manage this product")
+		}
+	case models.TeamResourceTemplate:
+		if !postgresql.CheckExists(db, &models.EmailTemplate{}, "id = ?", req.ResourceID) {
+			return nil, http.StatusNotFound, errors.New("template not found")
+		}
+	}
+
+	if share.IsShared(db, team.ID, req.ResourceType, req.ResourceID) {
+		return nil, http.StatusConflict, fmt.Errorf("the %v is already shared with this team", req.ResourceType)
+	}
+
+	share = models.TeamResource{
+		ID:           utility.GenerateUUID(),
+		TeamID:       team.ID,
+		ResourceType: req.ResourceType,
+		ResourceID:   req.ResourceID,
+		SharedBy:     actorID,
+	}
+
+	if err := share.ShareResource(db); err != nil {
+		return nil, http.StatusInternalServerError, err
+	}
+
+	return gin.H{"resource": share}, http.StatusCreated, nil
+}
+
+func UnshareTeamResource(orgID, teamID, shareID, actorID string, db *gorm.DB) (gin.H, int, error) {
+	var share models.TeamResource
+
+	team, canManage, code, err := getTeam(db, orgID, teamID, actorID)
+	if err != nil {
+		return nil, code, err
+	}
+	if !canManage {
+		return nil, http.StatusForbidden, errCannotManageTeam
+	}
+
+	share, err = share.GetTeamResource(db, team.ID, shareID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, http.StatusNotFound, errors.New("shared resource not found")
+		}
+		return nil, http.StatusInternalServerError, err
+	}
+
+	if err := share.UnshareResource(db); err != nil {
+		return nil, http.StatusInternalServerError, err
+	}
+
+	return gin.H{}, http.StatusOK, nil
+}
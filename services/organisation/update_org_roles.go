@@ -2,13 +2,12 @@ package organisation
 
 import (
 	"errors"
+	"fmt"
 	"net/http"
 	"strings"
 
 	"github.com/gin-gonic/gin"
 	"github.com/hngprojects/hng_boilerplate_golang_web/internal/models"
-	"github.com/hngprojects/hng_boilerplate_golang_web/pkg/middleware"
-	"github.com/hngprojects/hng_boilerplate_golang_web/services/user"
 	"github.com/hngprojects/hng_boilerplate_golang_web/utility"
 	"gorm.io/gorm"
 )
@@ -20,22 +19,7 @@ func UpdateOrgRoles(req models.OrgRole, orgID, roleID string, db *gorm.DB, c *gi
 		role     models.OrgRole
 	)
 
-	userId, err := middleware.GetUserClaims(c, db, "user_id")
-	if err != nil {
-		return nil, http.StatusNotFound, err
-	}
-
-	currentUserID, ok := userId.(string)
-	if !ok {
-		return nil, http.StatusBadRequest, errors.New("user_id is not of type string")
-	}
-
-	currentUser, code, err := user.GetUser(currentUserID, db)
-	if err != nil {
-		return nil, code, err
-	}
-
-	orgData, err := org.CheckOrgExists(orgID, db)
+	_, err := org.CheckOrgExists(orgID, db)
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
 			return gin.H{}, http.StatusNotFound, errors.New("organisation not found")
@@ -43,18 +27,13 @@ func UpdateOrgRoles(req models.OrgRole, orgID, roleID string, db *gorm.DB, c *gi
 		return gin.H{}, http.StatusBadRequest, err
 	}
 
-	isOwner, err := org.IsOwnerOfOrganisation(db, currentUser.ID, orgData.ID)
+	roleData, err = role.GetAOrgRole(db, orgID, roleID)
 	if err != nil {
 		return nil, http.StatusBadRequest, err
 	}
 
-	if !isOwner {
-		return nil, http.StatusForbidden, errors.New("not organization owner")
-	}
-
-	roleData, err = role.GetAOrgRole(db, orgID, roleID)
-	if err != nil {
-		return nil, http.StatusBadRequest, err
+	if roleData.IsTemplate() && req.Name != roleData.Name {
+		return nil, http.StatusBadRequest, errTemplateRole
 	}
 
 	roleData.Name = req.Name
@@ -76,29 +55,14 @@ func UpdateOrgRoles(req models.OrgRole, orgID, roleID string, db *gorm.DB, c *gi
 	return theResp, http.StatusOK, nil
 }
 
-func UpdateOrgPermissions(req models.Permission, orgID, roleID string, db *gorm.DB, c *gin.Context) (int, error) {
+func UpdateOrgPermissions(req models.Permission, orgID, roleID, userID string, db *gorm.DB, c *gin.Context) (int, error) {
 	var (
 		org      models.Organisation
 		roleData models.OrgRole
 		role     models.OrgRole
 	)
 
-	userId, err := middleware.GetUserClaims(c, db, "user_id")
-	if err != nil {
-		return http.StatusNotFound, err
-	}
-
-	currentUserID, ok := userId.(string)
-	if !ok {
-		return http.StatusBadRequest, errors.New("user_id is not of type string")
-	}
-
-	currentUser, code, err := user.GetUser(currentUserID, db)
-	if err != nil {
-		return code, err
-	}
-
-	orgData, err := org.CheckOrgExists(orgID, db)
+	_, err := org.CheckOrgExists(orgID, db)
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
 			return http.StatusNotFound, errors.New("organisation not found")
@@ -106,18 +70,21 @@ func UpdateOrgPermissions(req models.Permission, orgID, roleID string, db *gorm.
 		return http.StatusBadRequest, err
 	}
 
-	isOwner, err := org.IsOwnerOfOrganisation(db, currentUser.ID, orgData.ID)
+	roleData, err = role.GetAOrgRole(db, orgID, roleID)
 	if err != nil {
 		return http.StatusBadRequest, err
 	}
 
-	if !isOwner {
-		return http.StatusForbidden, errors.New("not organization owner")
+	if unknown := req.PermissionList.UnknownKeys(); len(unknown) > 0 {
+		return http.StatusUnprocessableEntity, fmt.Errorf("unknown permissions: %v", strings.Join(unknown, ", "))
 	}
 
-	roleData, err = role.GetAOrgRole(db, orgID, roleID)
-	if err != nil {
-		return http.StatusBadRequest, err
+	if code, err := checkCanEditRole(db, orgID, userID, roleData); err != nil {
+		return code, err
+	}
+
+	if code, err := checkCanGrant(db, orgID, userID, req.PermissionList); err != nil {
+		return code, err
 	}
 
 	req.ID = utility.GenerateUUID()
@@ -33,6 +33,29 @@ func CreateProduct(req models.CreateProductRequestModel, db *gorm.DB, c *gin.Con
 	)
 	owner_id, _ := middleware.GetIdFromToken(c)
 
+	var organisationID *string
+	if req.OrganisationID != "" {
+		var org models.Organisation
+		if _, err := org.CheckOrgExists(req.OrganisationID, db); err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return nil, http.StatusNotFound, errors.New("organisation not found")
+			}
+			return nil, http.StatusInternalServerError, err
+		}
+
+		allowed, err := org.HasPermission(db, req.OrganisationID, owner_id, models.PermManageProducts)
+		if err != nil {
+			if errors.Is(err, models.ErrNotOrgMember) {
+				return nil, http.StatusForbidden, err
+			}
+			return nil, http.StatusInternalServerError, err
+		}
+		if !allowed {
+			return nil, http.StatusForbidden, errors.New("you are not authorized to add products to this organisation")
+		}
+		organisationID = &req.OrganisationID
+	}
+
 	tx := db.Begin()
 	defer func() {
 		if r := recover(); r != nil {
@@ -59,11 +82,12 @@ func CreateProduct(req models.CreateProductRequestModel, db *gorm.DB, c *gin.Con
 	}
 
 	product := models.Product{
-		ID:          utility.GenerateUUID(),
-		Name:        name,
-		Description: description,
-		Price:       price,
-		OwnerID:     owner_id,
+		ID:             utility.GenerateUUID(),
+		Name:           name,
+		Description:    description,
+		Price:          price,
+		OwnerID:        owner_id,
+		OrganisationID: organisationID,
 	}
 
 	if err := tx.Create(&product).Error; err != nil {
@@ -81,12 +105,13 @@ func CreateProduct(req models.CreateProductRequestModel, db *gorm.DB, c *gin.Con
 	}
 
 	responseData = gin.H{
-		"name":        product.Name,
-		"description": product.Description,
-		"price":       product.Price,
-		"owner_id":    product.OwnerID,
-		"category":    category.Name,
-		"product_id":  product.ID,
+		"name":            product.Name,
+		"description":     product.Description,
+		"price":           product.Price,
+		"owner_id":        product.OwnerID,
+		"organisation_id": product.OrganisationID,
+		"category":        category.Name,
+		"product_id":      product.ID,
 	}
 	return responseData, http.StatusCreated, nil
 }
@@ -114,7 +139,13 @@ func DeleteProduct(req models.DeleteProductRequestModel, db *gorm.DB, ctx *gin.C
 		return nil, http.StatusUnauthorized, errors.New("failed to get owner ID from token")
 	}
 
-	if product.OwnerID != ownerID {
+	allowed, err := product.CanBeManagedBy(tx, ownerID)
+	if err != nil {
+		tx.Rollback()
+		return nil, http.StatusInternalServerError, err
+	}
+
+	if !allowed {
 		tx.Rollback()
 		return nil, http.StatusForbidden, errors.New("you are not authorized to delete this product")
 	}
@@ -139,7 +170,7 @@ func DeleteProduct(req models.DeleteProductRequestModel, db *gorm.DB, ctx *gin.C
 	return responseData, http.StatusOK, nil
 }
 
-func GetProduct(productId string, db *gorm.DB) (gin.H, int, error) {
+func GetProduct(productId string, db *gorm.DB, ctx *gin.Context) (gin.H, int, error) {
 	product := models.Product{}
 	product, err := product.GetProduct(db, productId)
 	if err != nil {
@@ -149,14 +180,27 @@ func GetProduct(productId string, db *gorm.DB) (gin.H, int, error) {
 		return nil, http.StatusInternalServerError, err
 	}
 
+	userID, _ := middleware.GetIdFromToken(ctx)
+	visible, err := product.IsVisibleTo(db, userID)
+	if err != nil {
+		return nil, http.StatusInternalServerError, err
+	}
+
+	// products outside the user's catalog are reported as missing
+	if !visible {
+		return nil, http.StatusNotFound, gorm.ErrRecordNotFound
+	}
+
 	responseData := gin.H{
-		"id":          product.ID,
-		"name":        product.Name,
-		"description": product.Description,
-		"price":       product.Price,
-		"categories":  product.Category,
-		"created_at":  product.CreatedAt,
-		"updated_at":  product.UpdatedAt,
+		"id":              product.ID,
+		"name":            product.Name,
+		"description":     product.Description,
+		"price":           product.Price,
+		"owner_id":        product.OwnerID,
+		"organisation_id": product.OrganisationID,
+		"categories":      product.Category,
+		"created_at":      product.CreatedAt,
+		"updated_at":      product.UpdatedAt,
 	}
 	return responseData, http.StatusOK, nil
 }
@@ -173,7 +217,12 @@ func UpdateProduct(req models.UpdateProductRequestModel, db *gorm.DB, ctx *gin.C
 
 	ownerID, _ := middleware.GetIdFromToken(ctx)
 
-	if product.OwnerID != ownerID {
+	allowed, err := product.CanBeManagedBy(db, ownerID)
+	if err != nil {
+		return nil, http.StatusInternalServerError, err
+	}
+
+	if !allowed {
 		return nil, http.StatusForbidden, errors.New("you are not authorized to update this product")
 	}
 
@@ -207,7 +256,12 @@ func GetProductsInCategory(categoryName string, db *gorm.DB, c *gin.Context) (gi
 		return nil, http.StatusInternalServerError, err
 	}
 
-	if err := db.Model(&category).Offset(offset).Limit(pageSize).Association("Products").Find(&products); err != nil {
+	scope, code, err := productScope(db, c)
+	if err != nil {
+		return nil, code, err
+	}
+
+	if err := db.Model(&category).Scopes(scope).Offset(offset).Limit(pageSize).Association("Products").Find(&products); err != nil {
 		return nil, http.StatusInternalServerError, err
 	}
 
@@ -232,7 +286,12 @@ func GetAllProducts(db *gorm.DB, c *gin.Context) (gin.H, int, error) {
 
 	offset := (page - 1) * pageSize
 
-	if err := db.Offset(offset).Limit(pageSize).Find(&products).Error; err != nil {
+	scope, code, err := productScope(db, c)
+	if err != nil {
+		return nil, code, err
+	}
+
+	if err := db.Scopes(scope).Offset(offset).Limit(pageSize).Find(&products).Error; err != nil {
 		return nil, http.StatusInternalServerError, err
 	}
 
@@ -250,7 +309,12 @@ func FilterProducts(price float64, category string, db *gorm.DB, ctx *gin.Contex
 	var products []models.Product
 	var totalCount int64
 
-	query := db
+	scope, code, err := productScope(db, ctx)
+	if err != nil {
+		return nil, code, err
+	}
+
+	query := db.Scopes(scope)
 
 	if price > 0 {
 		query = query.Where("price <= ?", price)
@@ -284,7 +348,7 @@ func FilterProducts(price float64, category string, db *gorm.DB, ctx *gin.Contex
 	return responseData, http.StatusOK, nil
 }
 
-func UploadImage(productID string, image *multipart.FileHeader, db *gorm.DB) (gin.H, int, error) {
+func UploadImage(productID string, image *multipart.FileHeader, db *gorm.DB, ctx *gin.Context) (gin.H, int, error) {
 	product := models.Product{}
 	if err := db.First(&product, "id = ?", productID).Error; err != nil {
 		if err == gorm.ErrRecordNotFound {
@@ -293,6 +357,16 @@ func UploadImage(productID string, image *multipart.FileHeader, db *gorm.DB) (gi
 		return gin.H{"error": "Database error"}, http.StatusInternalServerError, err
 	}
 
+	ownerID, _ := middleware.GetIdFromToken(ctx)
+	allowed, err := product.CanBeManagedBy(db, ownerID)
+	if err != nil {
+		return gin.H{"error": "Database error"}, http.StatusInternalServerError, err
+	}
+
+	if !allowed {
+		return gin.H{"error": "Forbidden"}, http.StatusForbidden, errors.New("you are not authorized to update this product")
+	}
+
 	if image == nil {
 		return gin.H{"error": "No image file provided"}, http.StatusBadRequest, errors.New("no image file")
 	}
@@ -313,6 +387,38 @@ func UploadImage(productID string, image *multipart.FileHeader, db *gorm.DB) (gi
 	return gin.H{"message": "Image uploaded successfully"}, http.StatusOK, nil
 }
 
+// productScope limits a listing to the catalog the user may see, narrowed by
+// the scope and organisation_id query parameters.
+func productScope(db *gorm.DB, c *gin.Context) (func(*gorm.DB) *gorm.DB, int, error) {
+	var (
+		scope = c.Query("scope")
+		orgID = c.Query("organisation_id")
+	)
+	userID, _ := middleware.GetIdFromToken(c)
+
+	switch scope {
+	case "", models.ProductScopeMine, models.ProductScopeOrganisation:
+	default:
+		return nil, http.StatusBadRequest, models.ErrInvalidProductScope
+	}
+
+	if orgID != "" {
+		if _, err := uuid.Parse(orgID); err != nil {
+			return nil, http.StatusBadRequest, errors.New("invalid organisation id format")
+		}
+
+		var org models.Organisation
+		if _, _, err := org.GetMemberRole(db, orgID, userID); err != nil {
+			if errors.Is(err, models.ErrNotOrgMember) {
+				return nil, http.StatusForbidden, err
+			}
+			return nil, http.StatusInternalServerError, err
+		}
+	}
+
+	return models.VisibleProducts(userID, scope, orgID), http.StatusOK, nil
+}
+
 // Helper function to save the uploaded file
 func saveUploadedFile(file *multipart.FileHeader, dst string) error {
 	src, err := file.Open()
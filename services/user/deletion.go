@@ -0,0 +1,325 @@
+package user
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+
+	"github.com/hngprojects/hng_boilerplate_golang_web/internal/config"
+	"github.com/hngprojects/hng_boilerplate_golang_web/internal/models"
+	"github.com/hngprojects/hng_boilerplate_golang_web/pkg/middleware"
+	"github.com/hngprojects/hng_boilerplate_golang_web/pkg/repository/storage"
+	"github.com/hngprojects/hng_boilerplate_golang_web/services/actions"
+	"github.com/hngprojects/hng_boilerplate_golang_web/services/actions/names"
+)
+
+const defaultDeletionGraceDays = 14
+
+// ErrOwnsSharedOrganisation blocks deleting a user who still owns an
+// organisation other people belong to; ownership has to move first.
+var ErrOwnsSharedOrganisation = errors.New("transfer ownership of your organisations that have other members before deleting the account")
+
+func ownsSharedOrganisation(db *gorm.DB, userID string) (string, error) {
+	var org models.Organisation
+
+	shared, _, err := org.GetOwnedOrganisations(db, userID)
+	if err != nil {
+		return "", err
+	}
+
+	orgNames := make([]string, 0, len(shared))
+	for _, org := range shared {
+		orgNames = append(orgNames, org.Name)
+	}
+
+	return strings.Join(orgNames, ", "), nil
+}
+
+// ScheduleAccountDeletion starts the grace period after which the purge job
+// removes the account. The user can still sign in and cancel until then.
+func ScheduleAccountDeletion(user models.User, db *gorm.DB) (gin.H, int, error) {
+	graceDays := config.GetConfig().App.AccountDeletionGraceDays
+	if graceDays <= 0 {
+		graceDays = defaultDeletionGraceDays
+	}
+
+	if user.DeletionAt != nil {
+		return nil, http.StatusConflict, errors.New("account is already scheduled for deletion")
+	}
+
+	shared, err := ownsSharedOrganisation(db, user.ID)
+	if err != nil {
+		return nil, http.StatusInternalServerError, err
+	}
+	if shared != "" {
+		return gin.H{"organisations": shared}, http.StatusConflict, ErrOwnsSharedOrganisation
+	}
+
+	deletionAt := time.Now().AddDate(0, 0, graceDays)
+	if err := user.ScheduleDeletion(db, deletionAt); err != nil {
+		return nil, http.StatusInternalServerError, err
+	}
+
+	deletionReq := models.SendAccountDeletionMail{
+		Email:      user.Email,
+		DeletionAt: deletionAt.Format("2 January 2006"),
+	}
+
+	err = actions.AddNotificationToQueue(storage.DB.Redis, names.SendAccountDeletionMail, deletionReq)
+	if err != nil {
+		return nil, http.StatusInternalServerError, err
+	}
+
+	return gin.H{"deletion_at": deletionAt}, http.StatusAccepted, nil
+}
+
+// CancelAccountDeletion restores an account scheduled for deletion. Users can
+// cancel their own deletion; superadmins can cancel anyone's.
+func CancelAccountDeletion(userIDStr string, db *gorm.DB, c *gin.Context) (gin.H, int, error) {
+	userId, err := middleware.GetUserClaims(c, db, "user_id")
+	if err != nil {
+		return nil, http.StatusNotFound, err
+	}
+
+	currentUserID, ok := userId.(string)
+	if !ok {
+		return nil, http.StatusBadRequest, errors.New("user_id is not of type string")
+	}
+
+	currentUser, code, err := GetUser(currentUserID, db)
+	if err != nil {
+		return nil, code, err
+	}
+
+	if currentUserID != userIDStr && !currentUser.CheckUserIsAdmin(db) {
+		return nil, http.StatusForbidden, errors.New("user does not have permission to restore this user")
+	}
+
+	targetUser, code, err := GetUser(userIDStr, db)
+	if err != nil {
+		return nil, code, err
+	}
+
+	cancelled, err := targetUser.CancelDeletion(db)
+	if err != nil {
+		return nil, http.StatusInternalServerError, err
+	}
+	if !cancelled {
+		return nil, http.StatusBadRequest, errors.New("account is not scheduled for deletion")
+	}
+
+	return gin.H{}, http.StatusOK, nil
+}
+
+// PurgeUser removes everything personal the user left behind and anonymises
+// the user row, which stays soft deleted so blogs and audit logs keep a valid
+// reference. Organisations only the user belongs to are deleted with it, along
+// with their teams, domains, join requests, subscriptions and products. The
+// products the user added to other organisations' catalogs pass to the owners
+// of those organisations.
+func PurgeUser(db *gorm.DB, userID string) error {
+	var (
+		user models.User
+		org  models.Organisation
+	)
+
+	if err := db.Where("id = ?", userID).First(&user).Error; err != nil {
+		return err
+	}
+
+	shared, solo, err := org.GetOwnedOrganisations(db, userID)
+	if err != nil {
+		return err
+	}
+	if len(shared) > 0 {
+		return ErrOwnsSharedOrganisation
+	}
+
+	soloIDs := make([]string, 0, len(solo))
+	for _, org := range solo {
+		soloIDs = append(soloIDs, org.ID)
+	}
+
+	return db.Transaction(func(tx *gorm.DB) error {
+		var (
+			product     models.Product
+			catalogOrgs []models.Organisation
+		)
+
+		catalogs := tx.Model(&models.Product{}).Select("organisation_id").Where("owner_id = ? AND organisation_id IS NOT NULL", userID)
+		if err := tx.Unscoped().Where("id IN (?) AND owner_id <> ?", catalogs, userID).Find(&catalogOrgs).Error; err != nil {
+			return err
+		}
+		for _, catalogOrg := range catalogOrgs {
+			if _, err := product.ReassignOrgProducts(tx, catalogOrg.ID, userID, catalogOrg.OwnerID); err != nil {
+				return err
+			}
+		}
+
+		for _, org := range solo {
+			if err := tx.Exec("DELETE FROM user_organisations WHERE organisation_id = ?", org.ID).Error; err != nil {
+				return err
+			}
+
+			orgTeams := tx.Model(&models.Team{}).Select("id").Where("organisation_id = ?", org.ID)
+			for _, record := range []interface{}{
+				&models.TeamMember{},
+				&models.TeamResource{},
+			} {
+				if err := tx.Where("team_id IN (?)", orgTeams).Delete(record).Error; err != nil {
+					return err
+				}
+			}
+
+			for _, record := range []interface{}{
+				&models.Team{},
+				&models.OrgDomain{},
+				&models.OrgJoinRequest{},
+				&models.OwnershipTransfer{},
+				&models.Subscription{},
+				&models.Invitation{},
+			} {
+				if err := tx.Where("organisation_id = ?", org.ID).Delete(record).Error; err != nil {
+					return err
+				}
+			}
+			if err := tx.Unscoped().Where("organisation_id = ?", org.ID).Delete(&models.OrgRole{}).Error; err != nil {
+				return err
+			}
+			if err := tx.Unscoped().Delete(&org).Error; err != nil {
+				return err
+			}
+		}
+
+		if err := tx.Exec("DELETE FROM user_organisations WHERE user_id = ?", userID).Error; err != nil {
+			return err
+		}
+
+		for _, record := range []interface{}{
+			&models.TeamMember{},
+			&models.OrgJoinRequest{},
+		} {
+			if err := tx.Where("user_id = ?", userID).Delete(record).Error; err != nil {
+				return err
+			}
+		}
+		if err := tx.Where("from_user_id = ? OR to_user_id = ?", userID, userID).Delete(&models.OwnershipTransfer{}).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("email = ?", user.Email).Delete(&models.Invitation{}).Error; err != nil {
+			return err
+		}
+
+		// requests the user reviewed and resources they shared stay with their
+		// organisations, without pointing at the user
+		if err := tx.Model(&models.OrgJoinRequest{}).Where("reviewed_by = ?", userID).Update("reviewed_by", nil).Error; err != nil {
+			return err
+		}
+		if err := tx.Model(&models.TeamResource{}).Where("shared_by = ?", userID).Update("shared_by", nil).Error; err != nil {
+			return err
+		}
+
+		ownedProducts := tx.Model(&models.Product{}).Select("id").Where("owner_id = ? OR organisation_id IN ?", userID, soloIDs)
+		if err := tx.Exec("DELETE FROM product_categories WHERE product_id IN (?)", ownedProducts).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("resource_type = ? AND resource_id IN (?)", models.TeamResourceProduct, ownedProducts).Delete(&models.TeamResource{}).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("owner_id = ? OR organisation_id IN ?", userID, soloIDs).Delete(&models.Product{}).Error; err != nil {
+			return err
+		}
+
+		for _, record := range []interface{}{
+			&models.RefreshToken{},
+			&models.AccessToken{},
+		} {
+			if err := tx.Unscoped().Where("owner_id = ?", userID).Delete(record).Error; err != nil {
+				return err
+			}
+		}
+
+		for _, record := range []interface{}{
+			&models.Key{},
+			&models.RecoveryCode{},
+			&models.DataPrivacySettings{},
+			&models.UserRegionTimezoneLanguage{},
+			&models.Notification{},
+			&models.NotificationSettings{},
+			&models.PersonalAccessToken{},
+			&models.SocialIdentity{},
+			&models.WebAuthnCredential{},
+			&models.PasswordHistory{},
+			&models.EmailChange{},
+			&models.Testimonial{},
+			&models.Invitation{},
+		} {
+			if err := tx.Unscoped().Where("user_id = ?", userID).Delete(record).Error; err != nil {
+				return err
+			}
+		}
+
+		if err := tx.Unscoped().Where("userid = ?", userID).Delete(&models.Profile{}).Error; err != nil {
+			return err
+		}
+
+		for _, record := range []interface{}{
+			&models.EmailVerification{},
+			&models.PasswordReset{},
+			&models.MagicLink{},
+		} {
+			if err := tx.Unscoped().Where("email = ?", user.Email).Delete(record).Error; err != nil {
+				return err
+			}
+		}
+
+		err := tx.Model(&models.User{}).Where("id = ?", userID).Updates(map[string]interface{}{
+			"name":        "deleted user",
+			"email":       fmt.Sprintf("deleted-%v@deleted.invalid", userID),
+			"password":    "",
+			"is_verified": false,
+			"verified_at": nil,
+			"deletion_at": nil,
+		}).Error
+		if err != nil {
+			return err
+		}
+
+		return tx.Delete(&models.User{}, "id = ?", userID).Error
+	})
+}
+
+// PurgeDueAccounts purges every account whose grace period has ended. Users
+// who meanwhile became owners of shared organisations stay scheduled and are
+// reported in the returned error.
+func PurgeDueAccounts(db *gorm.DB) (int, error) {
+	var (
+		user   models.User
+		failed []string
+	)
+
+	users, err := user.GetUsersDueForDeletion(db)
+	if err != nil {
+		return 0, err
+	}
+
+	purged := 0
+	for _, user := range users {
+		if err := PurgeUser(db, user.ID); err != nil {
+			failed = append(failed, fmt.Sprintf("%v: %v", user.ID, err))
+			continue
+		}
+		purged++
+	}
+
+	if len(failed) > 0 {
+		return purged, fmt.Errorf("could not purge %v", strings.Join(failed, "; "))
+	}
+
+	return purged, nil
+}
@@ -118,7 +118,9 @@ func GetAUserOrganisation(userIDStr string, db *gorm.DB, c *gin.Context) (*[]mod
 	return &orgResp, http.StatusOK, nil
 }
 
-func DeleteAUser(userIDStr string, db *gorm.DB, c *gin.Context) (int, error) {
+// DeleteAUser schedules the deletion of the caller's own account, or purges
+// another account right away when the caller is a superadmin.
+func DeleteAUser(userIDStr string, db *gorm.DB, c *gin.Context) (gin.H, int, error) {
 	var (
 		currentUser models.User
 		targetUser  models.User
@@ -126,35 +128,41 @@ func DeleteAUser(userIDStr string, db *gorm.DB, c *gin.Context) (int, error) {
 
 	userId, err := middleware.GetUserClaims(c, db, "user_id")
 	if err != nil {
-		return http.StatusNotFound, err
+		return nil, http.StatusNotFound, err
 	}
 
 	currentUserID, ok := userId.(string)
 	if !ok {
-		return http.StatusBadRequest, errors.New("user_id is not of type string")
+		return nil, http.StatusBadRequest, errors.New("user_id is not of type string")
 	}
 
 	currentUser, code, err := GetUser(currentUserID, db)
 	if err != nil {
-		return code, err
+		return nil, code, err
 	}
 
 	targetUser, code, err = GetUser(userIDStr, db)
 	if err != nil {
-		return code, err
+		return nil, code, err
+	}
+
+	if currentUserID == userIDStr {
+		return ScheduleAccountDeletion(targetUser, db)
 	}
 
 	isSuperAdmin := currentUser.CheckUserIsAdmin(db)
-	if isSuperAdmin || currentUserID == userIDStr {
+	if !isSuperAdmin {
+		return nil, http.StatusForbidden, errors.New("user does not have permission to delete this user")
+	}
 
-		if err := targetUser.DeleteAUser(db); err != nil {
-			return http.StatusInternalServerError, err
+	if err := PurgeUser(db, targetUser.ID); err != nil {
+		if errors.Is(err, ErrOwnsSharedOrganisation) {
+			return nil, http.StatusConflict, errors.New("the user owns organisations with other members, transfer their ownership first")
 		}
-	} else {
-		return http.StatusForbidden, errors.New("user does not have permission to delete this user")
+		return nil, http.StatusInternalServerError, err
 	}
 
-	return http.StatusOK, nil
+	return nil, http.StatusOK, nil
 }
 
 func UpdateAUser(userData models.UpdateUserRequestModel, userIDStr string, db *gorm.DB, c *gin.Context) (*models.User, int, error) {
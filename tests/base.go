@@ -7,9 +7,11 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"net/url"
+	"strings"
 	"testing"
 
 	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
 
 	"github.com/hngprojects/hng_boilerplate_golang_web/internal/config"
 	"github.com/hngprojects/hng_boilerplate_golang_web/internal/models"
@@ -106,12 +108,14 @@ func SignupUser(t *testing.T, r *gin.Engine, auth auth.Controller, userSignUpDat
 	r.ServeHTTP(rr, req)
 }
 
-// help to fetch user token
-
-func GetLoginToken(t *testing.T, r *gin.Engine, auth auth.Controller, loginData models.LoginRequestModel) string {
+// GetLoginToken logs the user in and returns their access token. It serves
+// the login on its own engine, so it can be called any number of times
+// without registering routes on the router under test.
+func GetLoginToken(t *testing.T, auth auth.Controller, loginData models.LoginRequestModel) string {
 	var (
 		loginPath = "/api/v1/auth/login"
 		loginURI  = url.URL{Path: loginPath}
+		r         = gin.New()
 	)
 	r.POST(loginPath, auth.LoginUser)
 	var b bytes.Buffer
@@ -136,6 +140,65 @@ func GetLoginToken(t *testing.T, r *gin.Engine, auth auth.Controller, loginData
 	return token
 }
 
+// TestUserPassword is the password CreateTestUser gives users.
+const TestUserPassword = "password"
+
+// CreateTestUser stores the user with TestUserPassword, filling in the ID, a
+// unique email made from the name and the user role when they are not set.
+func CreateTestUser(t *testing.T, db *gorm.DB, user models.User) models.User {
+	t.Helper()
+
+	if user.ID == "" {
+		user.ID = utility.GenerateUUID()
+	}
+	if user.Email == "" {
+		user.Email = fmt.Sprintf("%v%v@qa.team", strings.ToLower(strings.ReplaceAll(user.Name, " ", "")), utility.GenerateUUID())
+	}
+	if user.Role == 0 {
+		user.Role = int(models.RoleIdentity.User)
+	}
+
+	password, err := utility.HashPassword(TestUserPassword)
+	if err != nil {
+		t.Fatal(err)
+	}
+	user.Password = password
+
+	if err := db.Create(&user).Error; err != nil {
+		t.Fatalf("creating user failed: %v", err)
+	}
+	return user
+}
+
+// CreateLoggedInUser creates the user as CreateTestUser does and returns it
+// with an access token.
+func CreateLoggedInUser(t *testing.T, auth auth.Controller, user models.User) (models.User, string) {
+	t.Helper()
+
+	user = CreateTestUser(t, auth.Db.Postgresql, user)
+	token := GetLoginToken(t, auth, models.LoginRequestModel{Email: user.Email, Password: TestUserPassword})
+	return user, token
+}
+
+// PerformRequest sends body as JSON to the router, with token as the bearer
+// token when it is set, and returns the recorded response.
+func PerformRequest(r *gin.Engine, method, url, token string, body interface{}) *httptest.ResponseRecorder {
+	var reqBody bytes.Buffer
+	if body != nil {
+		json.NewEncoder(&reqBody).Encode(body)
+	}
+
+	req, _ := http.NewRequest(method, url, &reqBody)
+	req.Header.Set("Content-Type", "application/json")
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+
+	rr := httptest.NewRecorder()
+	r.ServeHTTP(rr, req)
+	return rr
+}
+
 // helper to create an organisation
 func CreateOrganisation(t *testing.T, r *gin.Engine, db *storage.Database, org organisation.Controller, orgData models.CreateOrgRequestModel, token string) string {
 	var (
@@ -373,7 +373,7 @@ func TestLogout(t *testing.T) {
 	r := gin.Default()
 	tst.SignupUser(t, r, authen, userSignUpData, false)
 
-	token := tst.GetLoginToken(t, r, authen, loginData)
+	token := tst.GetLoginToken(t, authen, loginData)
 
 	tests := []struct {
 		Name         string
@@ -31,9 +31,57 @@ func SetupAuthTestRouter() (*gin.Engine, *auth.Controller) {
 func SetupAuthRoutes(r *gin.Engine, authController *auth.Controller) {
 	r.PUT("/api/v1/auth/change-password",
 		middleware.Authorize(authController.Db.Postgresql, models.RoleIdentity.SuperAdmin, models.RoleIdentity.User),
+		middleware.BlockImpersonation(),
 		authController.ChangePassword)
 	r.POST("/api/v1/auth/password-reset", authController.ResetPassword)
 	r.POST("/api/v1/auth/password-reset/verify", authController.VerifyResetToken)
 	r.POST("/api/v1/auth/magick-link", authController.RequestMagicLink)
 	r.POST("/api/v1/auth/magick-link/verify", authController.VerifyMagicLink)
+	r.POST("/api/v1/auth/otp/request", authController.RequestLoginOTP)
+	r.POST("/api/v1/auth/otp/verify", authController.VerifyLoginOTP)
+	r.POST("/api/v1/auth/2fa/login", authController.VerifyTwoFALogin)
+	r.POST("/api/v1/auth/2fa/webauthn/begin", authController.BeginWebAuthnTwoFA)
+	r.POST("/api/v1/auth/2fa/webauthn/finish", authController.FinishWebAuthnTwoFA)
+	r.POST("/api/v1/auth/webauthn/login/begin", authController.BeginWebAuthnLogin)
+	r.POST("/api/v1/auth/webauthn/login/finish", authController.FinishWebAuthnLogin)
+	r.POST("/api/v1/auth/token/refresh", authController.RefreshAccessToken)
+	r.POST("/api/v1/auth/email/verify", authController.VerifyEmail)
+	r.POST("/api/v1/auth/email/verify/resend", authController.ResendEmailVerification)
+	r.POST("/api/v1/auth/email/change/confirm", authController.ConfirmEmailChange)
+	r.POST("/api/v1/auth/email/change/cancel", authController.CancelEmailChange)
+	r.POST("/api/v1/auth/unlock", authController.UnlockAccount)
+	r.GET("/api/v1/auth/oauth/providers", authController.GetOAuthProviders)
+	r.GET("/api/v1/auth/oauth/:provider/authorize", authController.AuthorizeOAuth)
+	r.POST("/api/v1/auth/oauth/callback", authController.OAuthCallback)
+
+	sessionUrl := r.Group("/api/v1/auth",
+		middleware.Authorize(authController.Db.Postgresql, models.RoleIdentity.SuperAdmin, models.RoleIdentity.User),
+		middleware.RequireSession())
+	sessionUrl.POST("/email/change", middleware.BlockImpersonation(), authController.RequestEmailChange)
+	sessionUrl.GET("/sessions", authController.GetSessions)
+	sessionUrl.DELETE("/sessions", authController.RevokeOtherSessions)
+	sessionUrl.DELETE("/sessions/:session_id", authController.RevokeSession)
+	sessionUrl.POST("/personal-access-tokens", middleware.BlockImpersonation(), authController.CreatePersonalAccessToken)
+	sessionUrl.GET("/personal-access-tokens", authController.GetPersonalAccessTokens)
+	sessionUrl.DELETE("/personal-access-tokens/:token_id", authController.RevokePersonalAccessToken)
+	sessionUrl.POST("/oauth/:provider/link", middleware.BlockImpersonation(), authController.LinkOAuth)
+	sessionUrl.POST("/oauth/link/callback", middleware.BlockImpersonation(), authController.LinkOAuthCallback)
+	sessionUrl.GET("/identities", authController.GetSocialIdentities)
+	sessionUrl.DELETE("/identities/:identity_id", middleware.BlockImpersonation(), authController.UnlinkSocialIdentity)
+	sessionUrl.POST("/webauthn/register/begin", middleware.BlockImpersonation(), authController.BeginWebAuthnRegistration)
+	sessionUrl.POST("/webauthn/register/finish", middleware.BlockImpersonation(), authController.FinishWebAuthnRegistration)
+	sessionUrl.GET("/webauthn/credentials", authController.GetWebAuthnCredentials)
+	sessionUrl.PATCH("/webauthn/credentials/:credential_id", middleware.BlockImpersonation(), authController.RenameWebAuthnCredential)
+	sessionUrl.DELETE("/webauthn/credentials/:credential_id", middleware.BlockImpersonation(), authController.DeleteWebAuthnCredential)
+	sessionUrl.POST("/logout", authController.LogoutUser)
+	sessionUrl.POST("/impersonation/stop", authController.StopImpersonation)
+
+	adminSessionUrl := r.Group("/api/v1/auth",
+		middleware.Authorize(authController.Db.Postgresql, models.RoleIdentity.SuperAdmin),
+		middleware.RequireSession())
+	adminSessionUrl.GET("/users/:user_id/sessions", authController.GetUserSessions)
+	adminSessionUrl.DELETE("/users/:user_id/sessions", authController.RevokeAllUserSessions)
+	adminSessionUrl.DELETE("/users/:user_id/sessions/:session_id", authController.RevokeUserSession)
+	adminSessionUrl.POST("/users/:user_id/unlock", authController.UnlockUserAccount)
+	adminSessionUrl.POST("/users/:user_id/impersonate", authController.StartImpersonation)
 }
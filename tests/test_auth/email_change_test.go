@@ -0,0 +1,101 @@
+package test_auth
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/hngprojects/hng_boilerplate_golang_web/internal/models"
+	"github.com/hngprojects/hng_boilerplate_golang_web/tests"
+	"github.com/hngprojects/hng_boilerplate_golang_web/utility"
+)
+
+func TestEmailChange(t *testing.T) {
+	router, authController := SetupAuthTestRouter()
+	db := authController.Db.Postgresql
+	currUUID := utility.GenerateUUID()
+
+	router.POST("/api/v1/auth/login", authController.LoginUser)
+
+	login := func(email string) string {
+		resp := tests.PerformRequest(router, http.MethodPost, "/api/v1/auth/login", "", models.LoginRequestModel{Email: email, Password: "password"})
+		tests.AssertStatusCode(t, resp.Code, http.StatusOK)
+		return tests.ParseResponse(resp)["data"].(map[string]interface{})["access_token"].(string)
+	}
+
+	// requestChange starts a change and replaces the mailed tokens with known ones
+	requestChange := func(t *testing.T, token, newEmail string) (string, string) {
+		resp := tests.PerformRequest(router, http.MethodPost, "/api/v1/auth/email/change", token, models.ChangeEmailRequestModel{NewEmail: newEmail, Password: "password"})
+		tests.AssertStatusCode(t, resp.Code, http.StatusOK)
+
+		confirmToken, cancelToken := utility.GenerateUUID(), utility.GenerateUUID()
+		db.Model(&models.EmailChange{}).Where("new_email = ?", newEmail).Updates(map[string]interface{}{
+			"confirm_token_hash": utility.HashToken(confirmToken),
+			"cancel_token_hash":  utility.HashToken(cancelToken),
+		})
+		return confirmToken, cancelToken
+	}
+
+	t.Run("Wrong Password", func(t *testing.T) {
+		user := tests.CreateTestUser(t, db, models.User{Name: "wrongpassword", IsVerified: true})
+
+		resp := tests.PerformRequest(router, http.MethodPost, "/api/v1/auth/email/change", login(user.Email), models.ChangeEmailRequestModel{
+			NewEmail: fmt.Sprintf("testemailchangenew%v@qa.team", currUUID),
+			Password: "wrong",
+		})
+		tests.AssertStatusCode(t, resp.Code, http.StatusUnauthorized)
+	})
+
+	t.Run("Email In Use", func(t *testing.T) {
+		user := tests.CreateTestUser(t, db, models.User{Name: "inuse", IsVerified: true})
+		other := tests.CreateTestUser(t, db, models.User{Name: "taken", IsVerified: true})
+
+		resp := tests.PerformRequest(router, http.MethodPost, "/api/v1/auth/email/change", login(user.Email), models.ChangeEmailRequestModel{NewEmail: other.Email, Password: "password"})
+		tests.AssertStatusCode(t, resp.Code, http.StatusConflict)
+	})
+
+	t.Run("Confirm Change", func(t *testing.T) {
+		user := tests.CreateTestUser(t, db, models.User{Name: "confirm", IsVerified: true})
+		newEmail := fmt.Sprintf("testemailchangeconfirmed%v@qa.team", currUUID)
+		currentSession := login(user.Email)
+		otherSession := login(user.Email)
+
+		confirmToken, _ := requestChange(t, currentSession, newEmail)
+
+		var stored models.User
+		db.Where("id = ?", user.ID).First(&stored)
+		tests.AssertResponseMessage(t, stored.Email, user.Email)
+
+		resp := tests.PerformRequest(router, http.MethodPost, "/api/v1/auth/email/change/confirm", "", models.EmailChangeTokenRequestModel{Token: confirmToken})
+		tests.AssertStatusCode(t, resp.Code, http.StatusOK)
+
+		db.Where("id = ?", user.ID).First(&stored)
+		tests.AssertResponseMessage(t, stored.Email, newEmail)
+
+		resp = tests.PerformRequest(router, http.MethodGet, "/api/v1/auth/sessions", currentSession, nil)
+		tests.AssertStatusCode(t, resp.Code, http.StatusOK)
+
+		resp = tests.PerformRequest(router, http.MethodGet, "/api/v1/auth/sessions", otherSession, nil)
+		tests.AssertStatusCode(t, resp.Code, http.StatusUnauthorized)
+
+		resp = tests.PerformRequest(router, http.MethodPost, "/api/v1/auth/email/change/confirm", "", models.EmailChangeTokenRequestModel{Token: confirmToken})
+		tests.AssertStatusCode(t, resp.Code, http.StatusBadRequest)
+	})
+
+	t.Run("Cancel Change", func(t *testing.T) {
+		user := tests.CreateTestUser(t, db, models.User{Name: "cancel", IsVerified: true})
+		newEmail := fmt.Sprintf("testemailchangecancelled%v@qa.team", currUUID)
+
+		confirmToken, cancelToken := requestChange(t, login(user.Email), newEmail)
+
+		resp := tests.PerformRequest(router, http.MethodPost, "/api/v1/auth/email/change/cancel", "", models.EmailChangeTokenRequestModel{Token: cancelToken})
+		tests.AssertStatusCode(t, resp.Code, http.StatusOK)
+
+		resp = tests.PerformRequest(router, http.MethodPost, "/api/v1/auth/email/change/confirm", "", models.EmailChangeTokenRequestModel{Token: confirmToken})
+		tests.AssertStatusCode(t, resp.Code, http.StatusBadRequest)
+
+		var stored models.User
+		db.Where("id = ?", user.ID).First(&stored)
+		tests.AssertResponseMessage(t, stored.Email, user.Email)
+	})
+}
@@ -0,0 +1,128 @@
+package test_auth
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/hngprojects/hng_boilerplate_golang_web/internal/config"
+	"github.com/hngprojects/hng_boilerplate_golang_web/internal/models"
+	"github.com/hngprojects/hng_boilerplate_golang_web/tests"
+	"github.com/hngprojects/hng_boilerplate_golang_web/utility"
+)
+
+func TestEmailVerification(t *testing.T) {
+	router, authController := SetupAuthTestRouter()
+	db := authController.Db.Postgresql
+	currUUID := utility.GenerateUUID()
+	password, _ := utility.HashPassword("password")
+
+	router.POST("/api/v1/auth/register", authController.CreateUser)
+	router.POST("/api/v1/auth/login", authController.LoginUser)
+
+	isVerified := func(email string) bool {
+		var user models.User
+		db.Where("email = ?", email).First(&user)
+		return user.IsVerified
+	}
+
+	email := fmt.Sprintf("testverify%v@qa.team", currUUID)
+
+	t.Run("Register Issues Verification", func(t *testing.T) {
+		resp := tests.PerformRequest(router, http.MethodPost, "/api/v1/auth/register", "", models.CreateUserRequestModel{
+			Email:     email,
+			Password:  "password",
+			FirstName: "verify",
+			LastName:  "user",
+		})
+		tests.AssertStatusCode(t, resp.Code, http.StatusCreated)
+		tests.AssertBool(t, isVerified(email), false)
+
+		var verification models.EmailVerification
+		if err := db.Where("email = ?", email).First(&verification).Error; err != nil {
+			t.Fatalf("expected a pending verification: %v", err)
+		}
+	})
+
+	t.Run("Resend Is Rate Limited", func(t *testing.T) {
+		resp := tests.PerformRequest(router, http.MethodPost, "/api/v1/auth/email/verify/resend", "", models.ResendEmailVerificationRequestModel{Email: email})
+		tests.AssertStatusCode(t, resp.Code, http.StatusTooManyRequests)
+	})
+
+	t.Run("Wrong Code", func(t *testing.T) {
+		resp := tests.PerformRequest(router, http.MethodPost, "/api/v1/auth/email/verify", "", models.VerifyEmailRequestModel{Email: email, Code: "000000x"})
+		tests.AssertStatusCode(t, resp.Code, http.StatusBadRequest)
+		response := tests.ParseResponse(resp)
+		tests.AssertResponseMessage(t, response["message"].(string), "invalid or expired verification code")
+		tests.AssertBool(t, isVerified(email), false)
+	})
+
+	t.Run("Verify With Code", func(t *testing.T) {
+		var verification models.EmailVerification
+		db.Where("email = ?", email).First(&verification)
+
+		resp := tests.PerformRequest(router, http.MethodPost, "/api/v1/auth/email/verify", "", models.VerifyEmailRequestModel{Email: email, Code: verification.Code})
+		tests.AssertStatusCode(t, resp.Code, http.StatusOK)
+		response := tests.ParseResponse(resp)
+		tests.AssertResponseMessage(t, response["message"].(string), "email verified successfully")
+		tests.AssertBool(t, isVerified(email), true)
+
+		resp = tests.PerformRequest(router, http.MethodPost, "/api/v1/auth/email/verify", "", models.VerifyEmailRequestModel{Email: email, Code: verification.Code})
+		tests.AssertStatusCode(t, resp.Code, http.StatusBadRequest)
+	})
+
+	t.Run("Resend For Verified Email", func(t *testing.T) {
+		resp := tests.PerformRequest(router, http.MethodPost, "/api/v1/auth/email/verify/resend", "", models.ResendEmailVerificationRequestModel{Email: email})
+		tests.AssertStatusCode(t, resp.Code, http.StatusBadRequest)
+		response := tests.ParseResponse(resp)
+		tests.AssertResponseMessage(t, response["message"].(string), "email address is already verified")
+	})
+
+	unverified := models.User{
+		ID:       utility.GenerateUUID(),
+		Name:     "unverified user",
+		Email:    fmt.Sprintf("testunverified%v@qa.team", currUUID),
+		Password: password,
+		Role:     int(models.RoleIdentity.User),
+	}
+	db.Create(&unverified)
+
+	t.Run("Login Blocked While Enforced", func(t *testing.T) {
+		app := &config.GetConfig().App
+		previous := app.EmailVerificationRequired
+		app.EmailVerificationRequired = config.EmailVerificationOnLogin
+		defer func() { app.EmailVerificationRequired = previous }()
+
+		resp := tests.PerformRequest(router, http.MethodPost, "/api/v1/auth/login", "", models.LoginRequestModel{Email: unverified.Email, Password: "password"})
+		tests.AssertStatusCode(t, resp.Code, http.StatusForbidden)
+		response := tests.ParseResponse(resp)
+		tests.AssertResponseMessage(t, response["message"].(string), "email address is not verified")
+
+		resp = tests.PerformRequest(router, http.MethodPost, "/api/v1/auth/login", "", models.LoginRequestModel{Email: email, Password: "password"})
+		tests.AssertStatusCode(t, resp.Code, http.StatusOK)
+	})
+
+	t.Run("Verify With Link Token", func(t *testing.T) {
+		token, _ := utility.GenerateSecureToken(32)
+		db.Create(&models.EmailVerification{
+			ID:        utility.GenerateUUID(),
+			Email:     unverified.Email,
+			TokenHash: utility.HashToken(token),
+			Code:      "123456",
+			ExpiresAt: time.Now().Add(time.Hour),
+		})
+
+		resp := tests.PerformRequest(router, http.MethodPost, "/api/v1/auth/email/verify", "", models.VerifyEmailRequestModel{Token: "not-the-token"})
+		tests.AssertStatusCode(t, resp.Code, http.StatusBadRequest)
+
+		resp = tests.PerformRequest(router, http.MethodPost, "/api/v1/auth/email/verify", "", models.VerifyEmailRequestModel{Token: token})
+		tests.AssertStatusCode(t, resp.Code, http.StatusOK)
+		tests.AssertBool(t, isVerified(unverified.Email), true)
+	})
+
+	t.Run("Missing Token And Code", func(t *testing.T) {
+		resp := tests.PerformRequest(router, http.MethodPost, "/api/v1/auth/email/verify", "", models.VerifyEmailRequestModel{})
+		tests.AssertStatusCode(t, resp.Code, http.StatusUnprocessableEntity)
+	})
+}
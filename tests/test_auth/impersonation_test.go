@@ -0,0 +1,152 @@
+package test_auth
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/hngprojects/hng_boilerplate_golang_web/internal/models"
+	"github.com/hngprojects/hng_boilerplate_golang_web/tests"
+	"github.com/hngprojects/hng_boilerplate_golang_web/utility"
+)
+
+func TestImpersonation(t *testing.T) {
+	router, authController := SetupAuthTestRouter()
+	db := authController.Db.Postgresql
+	currUUID := utility.GenerateUUID()
+	password, _ := utility.HashPassword("password")
+
+	router.POST("/api/v1/auth/login", authController.LoginUser)
+
+	adminData := models.User{
+		ID:       utility.GenerateUUID(),
+		Name:     "support admin",
+		Email:    fmt.Sprintf("testimpersonationadmin%v@qa.team", currUUID),
+		Password: password,
+		Role:     int(models.RoleIdentity.SuperAdmin),
+	}
+	otherAdminData := models.User{
+		ID:       utility.GenerateUUID(),
+		Name:     "other admin",
+		Email:    fmt.Sprintf("testimpersonationother%v@qa.team", currUUID),
+		Password: password,
+		Role:     int(models.RoleIdentity.SuperAdmin),
+	}
+	userData := models.User{
+		ID:       utility.GenerateUUID(),
+		Name:     "customer",
+		Email:    fmt.Sprintf("testimpersonationuser%v@qa.team", currUUID),
+		Password: password,
+		Role:     int(models.RoleIdentity.User),
+	}
+	db.Create(&adminData)
+	db.Create(&otherAdminData)
+	db.Create(&userData)
+
+	login := func(email string) string {
+		resp := tests.PerformRequest(router, http.MethodPost, "/api/v1/auth/login", "", models.LoginRequestModel{Email: email, Password: "password"})
+		tests.AssertStatusCode(t, resp.Code, http.StatusOK)
+		return tests.ParseResponse(resp)["data"].(map[string]interface{})["access_token"].(string)
+	}
+
+	adminSession := login(adminData.Email)
+	userSession := login(userData.Email)
+
+	impersonateURL := func(userID string) string {
+		return fmt.Sprintf("/api/v1/auth/users/%v/impersonate", userID)
+	}
+	reason := models.ImpersonateUserRequestModel{Reason: "ticket 4512, checkout page broken"}
+
+	auditActions := func() []string {
+		var (
+			auditLog models.AuditLog
+			actions  []string
+		)
+		logs, _ := auditLog.GetTargetAuditLogs(db, "user", userData.ID)
+		for _, log := range logs {
+			if log.ActorID == adminData.ID {
+				actions = append(actions, log.Action)
+			}
+		}
+		return actions
+	}
+
+	t.Run("Reason Required", func(t *testing.T) {
+		resp := tests.PerformRequest(router, http.MethodPost, impersonateURL(userData.ID), adminSession, models.ImpersonateUserRequestModel{})
+		tests.AssertStatusCode(t, resp.Code, http.StatusUnprocessableEntity)
+	})
+
+	t.Run("Users Cannot Impersonate", func(t *testing.T) {
+		resp := tests.PerformRequest(router, http.MethodPost, impersonateURL(adminData.ID), userSession, reason)
+		tests.AssertStatusCode(t, resp.Code, http.StatusUnauthorized)
+	})
+
+	t.Run("Superadmins Cannot Be Impersonated", func(t *testing.T) {
+		resp := tests.PerformRequest(router, http.MethodPost, impersonateURL(otherAdminData.ID), adminSession, reason)
+		tests.AssertStatusCode(t, resp.Code, http.StatusForbidden)
+	})
+
+	t.Run("Unknown User", func(t *testing.T) {
+		resp := tests.PerformRequest(router, http.MethodPost, impersonateURL(utility.GenerateUUID()), adminSession, reason)
+		tests.AssertStatusCode(t, resp.Code, http.StatusNotFound)
+	})
+
+	t.Run("Impersonate And Stop", func(t *testing.T) {
+		resp := tests.PerformRequest(router, http.MethodPost, impersonateURL(userData.ID), adminSession, reason)
+		tests.AssertStatusCode(t, resp.Code, http.StatusCreated)
+
+		data := tests.ParseResponse(resp)["data"].(map[string]interface{})
+		tests.AssertResponseMessage(t, data["impersonator_id"].(string), adminData.ID)
+		token := data["access_token"].(string)
+
+		if actions := auditActions(); len(actions) != 1 || actions[0] != models.AuditActionImpersonationStart {
+			t.Fatalf("expected the start to be audited, got %v", actions)
+		}
+
+		resp = tests.PerformRequest(router, http.MethodGet, "/api/v1/auth/sessions", token, nil)
+		tests.AssertStatusCode(t, resp.Code, http.StatusOK)
+
+		impersonated := false
+		for _, session := range tests.ParseResponse(resp)["data"].([]interface{}) {
+			session := session.(map[string]interface{})
+			if session["current"].(bool) {
+				impersonated = session["impersonated"].(bool)
+			}
+		}
+		tests.AssertBool(t, impersonated, true)
+
+		resp = tests.PerformRequest(router, http.MethodPut, "/api/v1/auth/change-password", token, models.ChangePasswordRequestModel{OldPassword: "password", NewPassword: "newpassword"})
+		tests.AssertStatusCode(t, resp.Code, http.StatusForbidden)
+
+		resp = tests.PerformRequest(router, http.MethodPost, "/api/v1/auth/personal-access-tokens", token, models.CreatePersonalAccessTokenRequestModel{Name: "escape", Scopes: []string{models.PersonalAccessTokenScopeWrite}})
+		tests.AssertStatusCode(t, resp.Code, http.StatusForbidden)
+
+		resp = tests.PerformRequest(router, http.MethodPost, "/api/v1/auth/impersonation/stop", token, nil)
+		tests.AssertStatusCode(t, resp.Code, http.StatusOK)
+
+		resp = tests.PerformRequest(router, http.MethodGet, "/api/v1/auth/sessions", token, nil)
+		tests.AssertStatusCode(t, resp.Code, http.StatusUnauthorized)
+
+		if actions := auditActions(); len(actions) != 2 || actions[0] != models.AuditActionImpersonationStop {
+			t.Errorf("expected the stop to be audited, got %v", actions)
+		}
+	})
+
+	t.Run("Logout Stops Impersonation", func(t *testing.T) {
+		resp := tests.PerformRequest(router, http.MethodPost, impersonateURL(userData.ID), adminSession, reason)
+		tests.AssertStatusCode(t, resp.Code, http.StatusCreated)
+		token := tests.ParseResponse(resp)["data"].(map[string]interface{})["access_token"].(string)
+
+		resp = tests.PerformRequest(router, http.MethodPost, "/api/v1/auth/logout", token, nil)
+		tests.AssertStatusCode(t, resp.Code, http.StatusOK)
+
+		if actions := auditActions(); len(actions) != 4 || actions[0] != models.AuditActionImpersonationStop {
+			t.Errorf("expected the logout to be audited as a stop, got %v", actions)
+		}
+	})
+
+	t.Run("Stop Without Impersonation", func(t *testing.T) {
+		resp := tests.PerformRequest(router, http.MethodPost, "/api/v1/auth/impersonation/stop", userSession, nil)
+		tests.AssertStatusCode(t, resp.Code, http.StatusBadRequest)
+	})
+}
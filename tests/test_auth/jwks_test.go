@@ -0,0 +1,155 @@
+package test_auth
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/json"
+	"encoding/pem"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/hngprojects/hng_boilerplate_golang_web/internal/config"
+	"github.com/hngprojects/hng_boilerplate_golang_web/internal/models"
+	"github.com/hngprojects/hng_boilerplate_golang_web/pkg/middleware"
+	"github.com/hngprojects/hng_boilerplate_golang_web/tests"
+	"github.com/hngprojects/hng_boilerplate_golang_web/utility"
+)
+
+func generateRSAKeyPEM(t *testing.T) string {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	block := &pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(key)}
+	return string(pem.EncodeToMemory(block))
+}
+
+func generateEd25519KeyPEM(t *testing.T) string {
+	_, key, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	der, err := x509.MarshalPKCS8PrivateKey(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return string(pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der}))
+}
+
+func TestAsymmetricSigningKeys(t *testing.T) {
+	router, authController := SetupAuthTestRouter()
+	router.GET("/.well-known/jwks.json", authController.JWKS)
+
+	server := config.GetConfig().Server
+	t.Cleanup(func() {
+		if err := middleware.LoadSigningKeys(server); err != nil {
+			t.Fatal(err)
+		}
+	})
+
+	user := models.User{ID: utility.GenerateUUID(), Role: int(models.RoleIdentity.User)}
+
+	loadKeys := func(t *testing.T, algorithm, activeID, activeKey string, retiring map[string]string) {
+		keyConfig := server
+		keyConfig.JWTAlgorithm = algorithm
+		keyConfig.JWTActiveKeyID = activeID
+		keyConfig.JWTActiveKey = activeKey
+		keyConfig.JWTRetiringKeys = retiring
+		keyConfig.JWTAcceptLegacyHS256 = false
+		if err := middleware.LoadSigningKeys(keyConfig); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	sign := func(t *testing.T) string {
+		tokenData, err := middleware.CreateToken(user)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return tokenData.AccessToken
+	}
+
+	isValid := func(token string) bool {
+		_, err := middleware.TokenValid(token)
+		return err == nil
+	}
+
+	fetchJWKS := func(t *testing.T) middleware.JWKSet {
+		req, _ := http.NewRequest(http.MethodGet, "/.well-known/jwks.json", nil)
+		resp := httptest.NewRecorder()
+		router.ServeHTTP(resp, req)
+
+		tests.AssertStatusCode(t, resp.Code, http.StatusOK)
+		var jwks middleware.JWKSet
+		if err := json.Unmarshal(resp.Body.Bytes(), &jwks); err != nil {
+			t.Fatal(err)
+		}
+		return jwks
+	}
+
+	hmacToken := sign(t)
+	firstKey := generateRSAKeyPEM(t)
+	secondKey := generateRSAKeyPEM(t)
+
+	t.Run("RS256 Tokens Carry Kid", func(t *testing.T) {
+		loadKeys(t, "RS256", "key-1", firstKey, nil)
+
+		token := sign(t)
+		parsed, err := middleware.TokenValid(token)
+		if err != nil {
+			t.Fatal(err)
+		}
+		tests.AssertResponseMessage(t, parsed.Header["kid"].(string), "key-1")
+		tests.AssertResponseMessage(t, parsed.Header["alg"].(string), "RS256")
+
+		jwks := fetchJWKS(t)
+		tests.AssertStatusCode(t, len(jwks.Keys), 1)
+		tests.AssertResponseMessage(t, jwks.Keys[0].Kid, "key-1")
+		tests.AssertResponseMessage(t, jwks.Keys[0].Kty, "RSA")
+	})
+
+	t.Run("HMAC Tokens Need The Legacy Setting", func(t *testing.T) {
+		tests.AssertBool(t, isValid(hmacToken), false)
+
+		legacyConfig := server
+		legacyConfig.JWTAlgorithm = "RS256"
+		legacyConfig.JWTActiveKeyID = "key-1"
+		legacyConfig.JWTActiveKey = firstKey
+		legacyConfig.JWTAcceptLegacyHS256 = true
+		if err := middleware.LoadSigningKeys(legacyConfig); err != nil {
+			t.Fatal(err)
+		}
+		tests.AssertBool(t, isValid(hmacToken), true)
+	})
+
+	t.Run("Rotation Keeps Retiring Key Valid", func(t *testing.T) {
+		loadKeys(t, "RS256", "key-1", firstKey, nil)
+		oldToken := sign(t)
+
+		loadKeys(t, "RS256", "key-2", secondKey, map[string]string{"key-1": firstKey})
+		newToken := sign(t)
+
+		tests.AssertBool(t, isValid(oldToken), true)
+		tests.AssertBool(t, isValid(newToken), true)
+		tests.AssertStatusCode(t, len(fetchJWKS(t).Keys), 2)
+
+		loadKeys(t, "RS256", "key-2", secondKey, nil)
+		tests.AssertBool(t, isValid(oldToken), false)
+		tests.AssertBool(t, isValid(newToken), true)
+	})
+
+	t.Run("EdDSA Signing", func(t *testing.T) {
+		loadKeys(t, "EdDSA", "ed-1", generateEd25519KeyPEM(t), nil)
+
+		token := sign(t)
+		tests.AssertBool(t, isValid(token), true)
+
+		jwks := fetchJWKS(t)
+		tests.AssertStatusCode(t, len(jwks.Keys), 1)
+		tests.AssertResponseMessage(t, jwks.Keys[0].Kty, "OKP")
+		tests.AssertResponseMessage(t, jwks.Keys[0].Crv, "Ed25519")
+	})
+}
@@ -0,0 +1,109 @@
+package test_auth
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/hngprojects/hng_boilerplate_golang_web/internal/models"
+	"github.com/hngprojects/hng_boilerplate_golang_web/pkg/repository/storage"
+	"github.com/hngprojects/hng_boilerplate_golang_web/pkg/repository/storage/redis"
+	"github.com/hngprojects/hng_boilerplate_golang_web/tests"
+	"github.com/hngprojects/hng_boilerplate_golang_web/utility"
+)
+
+func TestLoginLockout(t *testing.T) {
+	router, authController := SetupAuthTestRouter()
+	db := authController.Db.Postgresql
+	currUUID := utility.GenerateUUID()
+	password, _ := utility.HashPassword("password")
+
+	router.POST("/api/v1/auth/login", authController.LoginUser)
+
+	userData := models.User{
+		ID:       utility.GenerateUUID(),
+		Name:     "lockout user",
+		Email:    fmt.Sprintf("testlockout%v@qa.team", currUUID),
+		Password: password,
+		Role:     int(models.RoleIdentity.User),
+	}
+	adminData := models.User{
+		ID:       utility.GenerateUUID(),
+		Name:     "lockout admin",
+		Email:    fmt.Sprintf("testlockoutadmin%v@qa.team", currUUID),
+		Password: password,
+		Role:     int(models.RoleIdentity.SuperAdmin),
+	}
+	db.Create(&userData)
+	db.Create(&adminData)
+
+	delayKey := "login:delay:account:" + strings.ToLower(userData.Email)
+
+	login := func(email, password string) *httptest.ResponseRecorder {
+		return tests.PerformRequest(router, http.MethodPost, "/api/v1/auth/login", "", models.LoginRequestModel{Email: email, Password: password})
+	}
+
+	// lockUser fails five logins, skipping the progressive delay between them
+	lockUser := func(t *testing.T) {
+		for i := 0; i < 5; i++ {
+			redis.RedisDelete(storage.DB.Redis, delayKey)
+			resp := login(userData.Email, "wrong-password")
+			tests.AssertStatusCode(t, resp.Code, http.StatusBadRequest)
+		}
+
+		resp := login(userData.Email, "password")
+		tests.AssertStatusCode(t, resp.Code, http.StatusLocked)
+	}
+
+	t.Run("Progressive Delay", func(t *testing.T) {
+		for i := 0; i < 3; i++ {
+			resp := login(userData.Email, "wrong-password")
+			tests.AssertStatusCode(t, resp.Code, http.StatusBadRequest)
+		}
+
+		resp := login(userData.Email, "password")
+		tests.AssertStatusCode(t, resp.Code, http.StatusTooManyRequests)
+
+		time.Sleep(1100 * time.Millisecond)
+
+		resp = login(userData.Email, "password")
+		tests.AssertStatusCode(t, resp.Code, http.StatusOK)
+	})
+
+	t.Run("Lockout After Max Attempts", func(t *testing.T) {
+		lockUser(t)
+	})
+
+	t.Run("Unlock With Token", func(t *testing.T) {
+		token, _ := utility.GenerateSecureToken(32)
+		redis.RedisSetWithExpiry(storage.DB.Redis, "login:unlock:"+utility.HashToken(token), strings.ToLower(userData.Email), time.Hour)
+
+		resp := tests.PerformRequest(router, http.MethodPost, "/api/v1/auth/unlock", "", models.UnlockAccountRequestModel{Token: token})
+		tests.AssertStatusCode(t, resp.Code, http.StatusOK)
+
+		resp = login(userData.Email, "password")
+		tests.AssertStatusCode(t, resp.Code, http.StatusOK)
+
+		resp = tests.PerformRequest(router, http.MethodPost, "/api/v1/auth/unlock", "", models.UnlockAccountRequestModel{Token: token})
+		tests.AssertStatusCode(t, resp.Code, http.StatusBadRequest)
+		response := tests.ParseResponse(resp)
+		tests.AssertResponseMessage(t, response["message"].(string), "invalid or expired unlock token")
+	})
+
+	t.Run("Admin Unlock", func(t *testing.T) {
+		resp := login(adminData.Email, "password")
+		tests.AssertStatusCode(t, resp.Code, http.StatusOK)
+		adminToken := tests.ParseResponse(resp)["data"].(map[string]interface{})["access_token"].(string)
+
+		lockUser(t)
+
+		resp = tests.PerformRequest(router, http.MethodPost, fmt.Sprintf("/api/v1/auth/users/%v/unlock", userData.ID), adminToken, nil)
+		tests.AssertStatusCode(t, resp.Code, http.StatusOK)
+
+		resp = login(userData.Email, "password")
+		tests.AssertStatusCode(t, resp.Code, http.StatusOK)
+	})
+}
@@ -0,0 +1,108 @@
+package test_auth
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/hngprojects/hng_boilerplate_golang_web/internal/config"
+	"github.com/hngprojects/hng_boilerplate_golang_web/internal/models"
+	"github.com/hngprojects/hng_boilerplate_golang_web/pkg/repository/storage"
+	"github.com/hngprojects/hng_boilerplate_golang_web/pkg/repository/storage/redis"
+	"github.com/hngprojects/hng_boilerplate_golang_web/tests"
+	"github.com/hngprojects/hng_boilerplate_golang_web/utility"
+)
+
+func TestLoginOTP(t *testing.T) {
+	router, authController := SetupAuthTestRouter()
+	db := authController.Db.Postgresql
+	currUUID := utility.GenerateUUID()
+
+	// setCode replaces the emailed code with a known one
+	setCode := func(email, code string) {
+		redis.RedisSetWithExpiry(storage.DB.Redis, "login:otp:code:"+strings.ToLower(email), utility.HashToken(code), time.Minute)
+		redis.RedisDelete(storage.DB.Redis, "login:otp:attempts:"+strings.ToLower(email))
+	}
+
+	verify := func(email, code string) *httptest.ResponseRecorder {
+		return tests.PerformRequest(router, http.MethodPost, "/api/v1/auth/otp/verify", "", models.VerifyLoginOTPRequestModel{Email: email, OTP: code})
+	}
+
+	t.Run("Request Code", func(t *testing.T) {
+		user := tests.CreateTestUser(t, db, models.User{Name: "request"})
+
+		resp := tests.PerformRequest(router, http.MethodPost, "/api/v1/auth/otp/request", "", models.LoginOTPRequestModel{Email: user.Email})
+		tests.AssertStatusCode(t, resp.Code, http.StatusOK)
+
+		resp = tests.PerformRequest(router, http.MethodPost, "/api/v1/auth/otp/request", "", models.LoginOTPRequestModel{Email: user.Email})
+		tests.AssertStatusCode(t, resp.Code, http.StatusTooManyRequests)
+	})
+
+	t.Run("Unknown Email", func(t *testing.T) {
+		email := fmt.Sprintf("testotpunknown%v@qa.team", currUUID)
+
+		resp := tests.PerformRequest(router, http.MethodPost, "/api/v1/auth/otp/request", "", models.LoginOTPRequestModel{Email: email})
+		tests.AssertStatusCode(t, resp.Code, http.StatusOK)
+
+		data := tests.ParseResponse(resp)["data"].(map[string]interface{})
+		if data["expires_in"] == nil {
+			t.Errorf("expected the same response as for a known email, got %v", data)
+		}
+
+		if _, err := redis.RedisGet(storage.DB.Redis, "login:otp:code:"+email); err == nil {
+			t.Errorf("expected no code to be stored for an unknown email")
+		}
+
+		resp = tests.PerformRequest(router, http.MethodPost, "/api/v1/auth/otp/request", "", models.LoginOTPRequestModel{Email: email})
+		tests.AssertStatusCode(t, resp.Code, http.StatusTooManyRequests)
+	})
+
+	t.Run("Invalid Code Format", func(t *testing.T) {
+		resp := verify("someone@qa.team", "abc")
+		tests.AssertStatusCode(t, resp.Code, http.StatusUnprocessableEntity)
+	})
+
+	t.Run("Login With Code", func(t *testing.T) {
+		user := tests.CreateTestUser(t, db, models.User{Name: "login"})
+		setCode(user.Email, "123456")
+
+		resp := verify(user.Email, "654321")
+		tests.AssertStatusCode(t, resp.Code, http.StatusBadRequest)
+
+		resp = verify(user.Email, "123456")
+		tests.AssertStatusCode(t, resp.Code, http.StatusOK)
+
+		data := tests.ParseResponse(resp)["data"].(map[string]interface{})
+		if data["access_token"] == nil || data["refresh_token"] == nil {
+			t.Fatalf("expected a session, got %v", data)
+		}
+
+		var stored models.User
+		db.Where("id = ?", user.ID).First(&stored)
+		tests.AssertBool(t, stored.IsVerified, true)
+
+		resp = verify(user.Email, "123456")
+		tests.AssertStatusCode(t, resp.Code, http.StatusBadRequest)
+	})
+
+	t.Run("Attempt Limit", func(t *testing.T) {
+		app := config.GetConfig().App
+		config.GetConfig().App.LoginOTPMaxAttempts = 1
+		defer func() { config.GetConfig().App = app }()
+
+		user := tests.CreateTestUser(t, db, models.User{Name: "attempts"})
+		setCode(user.Email, "123456")
+
+		resp := verify(user.Email, "000000")
+		tests.AssertStatusCode(t, resp.Code, http.StatusBadRequest)
+
+		resp = verify(user.Email, "000001")
+		tests.AssertStatusCode(t, resp.Code, http.StatusTooManyRequests)
+
+		resp = verify(user.Email, "123456")
+		tests.AssertStatusCode(t, resp.Code, http.StatusBadRequest)
+	})
+}
@@ -0,0 +1,363 @@
+package test_auth
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt"
+
+	"github.com/hngprojects/hng_boilerplate_golang_web/internal/config"
+	"github.com/hngprojects/hng_boilerplate_golang_web/internal/models"
+	"github.com/hngprojects/hng_boilerplate_golang_web/tests"
+	"github.com/hngprojects/hng_boilerplate_golang_web/utility"
+)
+
+// newFakeOIDCProvider serves discovery, key set, token and userinfo endpoints.
+// The code sent to the token endpoint is the key of the identity to sign in
+// and the nonce for its ID token, joined by "|". Identities whose key starts
+// with "forged" get an ID token signed with a key the provider never published.
+func newFakeOIDCProvider(t *testing.T, identities map[string]map[string]interface{}) *httptest.Server {
+	mux := http.NewServeMux()
+	server := httptest.NewServer(mux)
+
+	signingKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	forgeryKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	mux.HandleFunc("/.well-known/openid-configuration", func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(map[string]string{
+			"issuer":                 server.URL,
+			"jwks_uri":               server.URL + "/jwks",
+			"authorization_endpoint": server.URL + "/authorize",
+			"token_endpoint":         server.URL + "/token",
+			"userinfo_endpoint":      server.URL + "/userinfo",
+		})
+	})
+	mux.HandleFunc("/jwks", func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"keys": []map[string]string{{
+				"kty": "RSA",
+				"kid": "test-key",
+				"use": "sig",
+				"alg": "RS256",
+				"n":   base64.RawURLEncoding.EncodeToString(signingKey.N.Bytes()),
+				"e":   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(signingKey.E)).Bytes()),
+			}},
+		})
+	})
+	mux.HandleFunc("/token", func(w http.ResponseWriter, r *http.Request) {
+		r.ParseForm()
+		if r.Form.Get("code_verifier") == "" {
+			t.Errorf("token request is missing the pkce code_verifier")
+		}
+
+		key, nonce, _ := strings.Cut(r.Form.Get("code"), "|")
+		claims := jwt.MapClaims{
+			"iss":   server.URL,
+			"aud":   "client",
+			"iat":   time.Now().Unix(),
+			"exp":   time.Now().Add(time.Hour).Unix(),
+			"nonce": nonce,
+		}
+		for name, value := range identities[key] {
+			claims[name] = value
+		}
+
+		token := jwt.NewWithClaims(jwt.SigningMethodRS256, claims)
+		token.Header["kid"] = "test-key"
+		signer := signingKey
+		if strings.HasPrefix(key, "forged") {
+			signer = forgeryKey
+		}
+		idToken, err := token.SignedString(signer)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"access_token": key,
+			"id_token":     idToken,
+			"token_type":   "Bearer",
+			"expires_in":   3600,
+		})
+	})
+	mux.HandleFunc("/userinfo", func(w http.ResponseWriter, r *http.Request) {
+		claims, ok := identities[strings.TrimPrefix(r.Header.Get("Authorization"), "Bearer ")]
+		if !ok {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		json.NewEncoder(w).Encode(claims)
+	})
+
+	return server
+}
+
+func TestOIDCLogin(t *testing.T) {
+	router, authController := SetupAuthTestRouter()
+	db := authController.Db.Postgresql
+	currUUID := utility.GenerateUUID()
+
+	router.POST("/api/v1/auth/login", authController.LoginUser)
+
+	existingUser := tests.CreateTestUser(t, db, models.User{Name: "oidc existing"})
+
+	twoFAUser := tests.CreateTestUser(t, db, models.User{Name: "oidc 2fa", IsVerified: true})
+	db.Create(&models.Key{ID: utility.GenerateUUID(), UserID: twoFAUser.ID, Key: "JBSWY3DPEHPK3PXP"})
+	db.Create(&models.DataPrivacySettings{UserID: twoFAUser.ID, Enable2FA: true})
+
+	newEmail := fmt.Sprintf("testoidcnew%v@qa.team", currUUID)
+	provider := newFakeOIDCProvider(t, map[string]map[string]interface{}{
+		"new-user": {
+			"sub": "new-" + currUUID, "email": newEmail, "email_verified": true, "name": "OIDC New",
+		},
+		"existing-verified": {
+			"sub": "existing-" + currUUID, "email": existingUser.Email, "email_verified": true,
+		},
+		"existing-mixed-case": {
+			"sub": "mixed-" + currUUID, "email": " " + strings.ToUpper(existingUser.Email) + " ", "email_verified": true,
+		},
+		"existing-unverified": {
+			"sub": "unverified-" + currUUID, "email": existingUser.Email, "email_verified": false,
+		},
+		"link": {
+			"sub": "link-" + currUUID, "email": "someone@else.example", "email_verified": "true",
+		},
+		"two-factor": {
+			"sub": "2fa-" + currUUID, "email": twoFAUser.Email, "email_verified": true,
+		},
+		"forged": {
+			"sub": "existing-" + currUUID, "email": existingUser.Email, "email_verified": true,
+		},
+	})
+	defer provider.Close()
+
+	oauthConfig := config.GetConfig().OAuth
+	config.GetConfig().OAuth = config.OAuth{
+		RedirectURL: "http://localhost:3000/oauth/callback",
+		Providers: map[string]config.OAuthProvider{
+			"acme":   {Type: config.OAuthProviderOIDC, DisplayName: "Acme SSO", ClientID: "client", ClientSecret: "secret", DiscoveryURL: provider.URL + "/.well-known/openid-configuration"},
+			"broken": {Type: config.OAuthProviderOIDC, ClientID: "client"},
+		},
+	}
+	defer func() { config.GetConfig().OAuth = oauthConfig }()
+
+	beginLogin := func(t *testing.T, path, token string) (string, string) {
+		method := http.MethodGet
+		if token != "" {
+			method = http.MethodPost
+		}
+
+		resp := tests.PerformRequest(router, method, path, token, nil)
+		tests.AssertStatusCode(t, resp.Code, http.StatusOK)
+
+		data := tests.ParseResponse(resp)["data"].(map[string]interface{})
+		authURL := data["authorization_url"].(string)
+		if !strings.HasPrefix(authURL, provider.URL+"/authorize") || !strings.Contains(authURL, "code_challenge_method=S256") {
+			t.Fatalf("unexpected authorization url %v", authURL)
+		}
+
+		parsed, _ := url.Parse(authURL)
+		nonce := parsed.Query().Get("nonce")
+		if nonce == "" {
+			t.Fatalf("authorization url %v has no nonce", authURL)
+		}
+		return data["state"].(string), nonce
+	}
+
+	callback := func(identity, state, nonce string) *httptest.ResponseRecorder {
+		code := identity + "|" + nonce
+		return tests.PerformRequest(router, http.MethodPost, "/api/v1/auth/oauth/callback", "", models.OAuthCallbackRequestModel{Code: code, State: state})
+	}
+
+	linkCallback := func(identity, state, nonce, token string) *httptest.ResponseRecorder {
+		code := identity + "|" + nonce
+		return tests.PerformRequest(router, http.MethodPost, "/api/v1/auth/oauth/link/callback", token, models.OAuthCallbackRequestModel{Code: code, State: state})
+	}
+
+	t.Run("List Providers", func(t *testing.T) {
+		resp := tests.PerformRequest(router, http.MethodGet, "/api/v1/auth/oauth/providers", "", nil)
+		tests.AssertStatusCode(t, resp.Code, http.StatusOK)
+
+		providers := tests.ParseResponse(resp)["data"].([]interface{})
+		if len(providers) != 1 || providers[0].(map[string]interface{})["display_name"] != "Acme SSO" {
+			t.Errorf("expected only the configured acme provider, got %v", providers)
+		}
+	})
+
+	t.Run("Unknown Provider", func(t *testing.T) {
+		resp := tests.PerformRequest(router, http.MethodGet, "/api/v1/auth/oauth/unknown/authorize", "", nil)
+		tests.AssertStatusCode(t, resp.Code, http.StatusNotFound)
+	})
+
+	t.Run("Creates New User", func(t *testing.T) {
+		state, nonce := beginLogin(t, "/api/v1/auth/oauth/acme/authorize", "")
+
+		resp := callback("new-user", state, nonce)
+		tests.AssertStatusCode(t, resp.Code, http.StatusCreated)
+
+		data := tests.ParseResponse(resp)["data"].(map[string]interface{})
+		if data["access_token"] == "" || data["user"].(map[string]interface{})["email"] != newEmail {
+			t.Errorf("unexpected login response %v", data)
+		}
+
+		var user models.User
+		db.Where("email = ?", newEmail).First(&user)
+		tests.AssertBool(t, user.IsVerified, true)
+	})
+
+	t.Run("Reused State", func(t *testing.T) {
+		state, nonce := beginLogin(t, "/api/v1/auth/oauth/acme/authorize", "")
+
+		resp := callback("new-user", state, nonce)
+		tests.AssertStatusCode(t, resp.Code, http.StatusOK)
+
+		resp = callback("new-user", state, nonce)
+		tests.AssertStatusCode(t, resp.Code, http.StatusBadRequest)
+	})
+
+	t.Run("Unverified Email Does Not Match Account", func(t *testing.T) {
+		state, nonce := beginLogin(t, "/api/v1/auth/oauth/acme/authorize", "")
+
+		resp := callback("existing-unverified", state, nonce)
+		tests.AssertStatusCode(t, resp.Code, http.StatusConflict)
+	})
+
+	t.Run("Verified Email Matches Account", func(t *testing.T) {
+		state, nonce := beginLogin(t, "/api/v1/auth/oauth/acme/authorize", "")
+
+		resp := callback("existing-verified", state, nonce)
+		tests.AssertStatusCode(t, resp.Code, http.StatusOK)
+
+		data := tests.ParseResponse(resp)["data"].(map[string]interface{})
+		tests.AssertResponseMessage(t, data["user"].(map[string]interface{})["id"].(string), existingUser.ID)
+	})
+
+	t.Run("Email Is Matched Case Insensitively", func(t *testing.T) {
+		state, nonce := beginLogin(t, "/api/v1/auth/oauth/acme/authorize", "")
+
+		resp := callback("existing-mixed-case", state, nonce)
+		tests.AssertStatusCode(t, resp.Code, http.StatusOK)
+
+		data := tests.ParseResponse(resp)["data"].(map[string]interface{})
+		tests.AssertResponseMessage(t, data["user"].(map[string]interface{})["id"].(string), existingUser.ID)
+	})
+
+	t.Run("ID Token Nonce Must Match", func(t *testing.T) {
+		state, _ := beginLogin(t, "/api/v1/auth/oauth/acme/authorize", "")
+
+		resp := callback("existing-verified", state, "another-nonce")
+		tests.AssertStatusCode(t, resp.Code, http.StatusUnauthorized)
+	})
+
+	t.Run("Forged ID Token", func(t *testing.T) {
+		state, nonce := beginLogin(t, "/api/v1/auth/oauth/acme/authorize", "")
+
+		resp := callback("forged", state, nonce)
+		tests.AssertStatusCode(t, resp.Code, http.StatusUnauthorized)
+	})
+
+	t.Run("Two Factor Users Get A Challenge", func(t *testing.T) {
+		state, nonce := beginLogin(t, "/api/v1/auth/oauth/acme/authorize", "")
+
+		resp := callback("two-factor", state, nonce)
+		tests.AssertStatusCode(t, resp.Code, http.StatusOK)
+
+		response := tests.ParseResponse(resp)
+		tests.AssertResponseMessage(t, response["message"].(string), "2fa verification required")
+
+		data := response["data"].(map[string]interface{})
+		if data["challenge_token"] == nil || data["access_token"] != nil {
+			t.Errorf("expected a 2fa challenge instead of a session, got %v", data)
+		}
+	})
+
+	t.Run("Link And Unlink", func(t *testing.T) {
+		resp := tests.PerformRequest(router, http.MethodPost, "/api/v1/auth/login", "", models.LoginRequestModel{Email: existingUser.Email, Password: "password"})
+		tests.AssertStatusCode(t, resp.Code, http.StatusOK)
+		session := tests.ParseResponse(resp)["data"].(map[string]interface{})["access_token"].(string)
+
+		state, nonce := beginLogin(t, "/api/v1/auth/oauth/acme/link", session)
+
+		resp = linkCallback("link", state, nonce, session)
+		tests.AssertStatusCode(t, resp.Code, http.StatusCreated)
+
+		resp = tests.PerformRequest(router, http.MethodGet, "/api/v1/auth/identities", session, nil)
+		tests.AssertStatusCode(t, resp.Code, http.StatusOK)
+
+		identities := tests.ParseResponse(resp)["data"].([]interface{})
+		if len(identities) != 2 {
+			t.Fatalf("expected 2 linked identities, got %d", len(identities))
+		}
+
+		for _, identity := range identities {
+			id := identity.(map[string]interface{})["id"].(string)
+			resp = tests.PerformRequest(router, http.MethodDelete, "/api/v1/auth/identities/"+id, session, nil)
+			tests.AssertStatusCode(t, resp.Code, http.StatusOK)
+		}
+
+		resp = tests.PerformRequest(router, http.MethodDelete, "/api/v1/auth/identities/"+utility.GenerateUUID(), session, nil)
+		tests.AssertStatusCode(t, resp.Code, http.StatusNotFound)
+	})
+
+	t.Run("Link Needs The Session That Started It", func(t *testing.T) {
+		resp := tests.PerformRequest(router, http.MethodPost, "/api/v1/auth/login", "", models.LoginRequestModel{Email: existingUser.Email, Password: "password"})
+		tests.AssertStatusCode(t, resp.Code, http.StatusOK)
+		session := tests.ParseResponse(resp)["data"].(map[string]interface{})["access_token"].(string)
+
+		_, otherSession := tests.CreateLoggedInUser(t, *authController, models.User{Name: "oidc other"})
+
+		state, nonce := beginLogin(t, "/api/v1/auth/oauth/acme/link", session)
+		resp = callback("link", state, nonce)
+		tests.AssertStatusCode(t, resp.Code, http.StatusForbidden)
+
+		state, nonce = beginLogin(t, "/api/v1/auth/oauth/acme/link", session)
+		resp = linkCallback("link", state, nonce, otherSession)
+		tests.AssertStatusCode(t, resp.Code, http.StatusForbidden)
+
+		state, nonce = beginLogin(t, "/api/v1/auth/oauth/acme/authorize", "")
+		resp = linkCallback("link", state, nonce, session)
+		tests.AssertStatusCode(t, resp.Code, http.StatusForbidden)
+
+		var socialIdentity models.SocialIdentity
+		if _, err := socialIdentity.GetByProviderSubject(db, "acme", "link-"+currUUID); err == nil {
+			t.Errorf("expected the provider account to stay unlinked")
+		}
+	})
+
+	t.Run("Cannot Unlink Only Sign In Method", func(t *testing.T) {
+		state, nonce := beginLogin(t, "/api/v1/auth/oauth/acme/authorize", "")
+
+		resp := callback("new-user", state, nonce)
+		tests.AssertStatusCode(t, resp.Code, http.StatusOK)
+		session := tests.ParseResponse(resp)["data"].(map[string]interface{})["access_token"].(string)
+
+		resp = tests.PerformRequest(router, http.MethodGet, "/api/v1/auth/identities", session, nil)
+		tests.AssertStatusCode(t, resp.Code, http.StatusOK)
+
+		identities := tests.ParseResponse(resp)["data"].([]interface{})
+		if len(identities) != 1 {
+			t.Fatalf("expected 1 linked identity, got %d", len(identities))
+		}
+
+		id := identities[0].(map[string]interface{})["id"].(string)
+		resp = tests.PerformRequest(router, http.MethodDelete, "/api/v1/auth/identities/"+id, session, nil)
+		tests.AssertStatusCode(t, resp.Code, http.StatusConflict)
+	})
+}
@@ -0,0 +1,107 @@
+package test_auth
+
+import (
+	"crypto/sha1"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/hngprojects/hng_boilerplate_golang_web/internal/config"
+	"github.com/hngprojects/hng_boilerplate_golang_web/internal/models"
+	"github.com/hngprojects/hng_boilerplate_golang_web/tests"
+	"github.com/hngprojects/hng_boilerplate_golang_web/utility"
+)
+
+func TestPasswordPolicy(t *testing.T) {
+	router, authController := SetupAuthTestRouter()
+	db := authController.Db.Postgresql
+
+	router.POST("/api/v1/auth/register", authController.CreateUser)
+	router.POST("/api/v1/auth/login", authController.LoginUser)
+
+	app := &config.GetConfig().App
+	original := *app
+	defer func() { *app = original }()
+
+	app.PasswordMinLength = 10
+	app.PasswordRequireUpper = true
+	app.PasswordRequireDigit = true
+	app.PasswordHistoryCount = 3
+
+	breached := "Breached2024pass"
+	sum := sha1.Sum([]byte(breached))
+	listPath := filepath.Join(t.TempDir(), "breached.txt")
+	os.WriteFile(listPath, []byte(strings.ToUpper(hex.EncodeToString(sum[:]))+":42\n"), 0600)
+	app.PasswordBreachedList = listPath
+
+	userData := tests.CreateTestUser(t, db, models.User{Name: "policy user"})
+
+	register := func(password string) *httptest.ResponseRecorder {
+		return tests.PerformRequest(router, http.MethodPost, "/api/v1/auth/register", "", models.CreateUserRequestModel{
+			Email:     fmt.Sprintf("testpolicy%v@qa.team", utility.GenerateUUID()),
+			Password:  password,
+			FirstName: "policy",
+			LastName:  "user",
+		})
+	}
+
+	resp := tests.PerformRequest(router, http.MethodPost, "/api/v1/auth/login", "", models.LoginRequestModel{Email: userData.Email, Password: "password"})
+	tests.AssertStatusCode(t, resp.Code, http.StatusOK)
+	token := tests.ParseResponse(resp)["data"].(map[string]interface{})["access_token"].(string)
+
+	changePassword := func(oldPassword, newPassword string) *httptest.ResponseRecorder {
+		return tests.PerformRequest(router, http.MethodPut, "/api/v1/auth/change-password", token, models.ChangePasswordRequestModel{
+			OldPassword: oldPassword,
+			NewPassword: newPassword,
+		})
+	}
+
+	t.Run("Weak Password Rejected", func(t *testing.T) {
+		resp := register("short")
+		tests.AssertStatusCode(t, resp.Code, http.StatusUnprocessableEntity)
+		response := tests.ParseResponse(resp)
+		tests.AssertResponseMessage(t, response["message"].(string), "Validation failed")
+		tests.AssertValidationError(t, response, "CreateUserRequestModel.Password",
+			"Password must be at least 10 characters in length, Password must contain an uppercase letter, Password must contain a digit")
+	})
+
+	t.Run("Breached Password Rejected", func(t *testing.T) {
+		resp := register(breached)
+		tests.AssertStatusCode(t, resp.Code, http.StatusUnprocessableEntity)
+		tests.AssertValidationError(t, tests.ParseResponse(resp), "CreateUserRequestModel.Password",
+			"Password has appeared in a data breach and cannot be used")
+	})
+
+	t.Run("Strong Password Accepted", func(t *testing.T) {
+		resp := register("Strong2024password")
+		tests.AssertStatusCode(t, resp.Code, http.StatusCreated)
+	})
+
+	t.Run("Previous Passwords Cannot Be Reused", func(t *testing.T) {
+		resp := changePassword("password", "FirstChange1")
+		tests.AssertStatusCode(t, resp.Code, http.StatusOK)
+
+		resp = changePassword("FirstChange1", "SecondChange2")
+		tests.AssertStatusCode(t, resp.Code, http.StatusOK)
+
+		resp = changePassword("SecondChange2", "FirstChange1")
+		tests.AssertStatusCode(t, resp.Code, http.StatusUnprocessableEntity)
+		tests.AssertValidationError(t, tests.ParseResponse(resp), "ChangePasswordRequestModel.NewPassword",
+			"NewPassword must not match any of your last 3 passwords")
+
+		resp = changePassword("SecondChange2", "ThirdChange3")
+		tests.AssertStatusCode(t, resp.Code, http.StatusOK)
+
+		resp = changePassword("ThirdChange3", "FourthChange4")
+		tests.AssertStatusCode(t, resp.Code, http.StatusOK)
+
+		// only the last three passwords are kept
+		resp = changePassword("FourthChange4", "FirstChange1")
+		tests.AssertStatusCode(t, resp.Code, http.StatusOK)
+	})
+}
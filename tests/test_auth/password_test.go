@@ -36,7 +36,7 @@ func TestUpdateUserPassword(t *testing.T) {
 	}
 
 	auth := auth.Controller{Db: authController.Db, Validator: authController.Validator, Logger: authController.Logger}
-	token := tests.GetLoginToken(t, router, auth, loginData)
+	token := tests.GetLoginToken(t, auth, loginData)
 
 	t.Run("Successful Password Change", func(t *testing.T) {
 		changePasswordRequest := models.ChangePasswordRequestModel{
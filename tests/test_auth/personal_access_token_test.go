@@ -0,0 +1,128 @@
+package test_auth
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/hngprojects/hng_boilerplate_golang_web/internal/models"
+	"github.com/hngprojects/hng_boilerplate_golang_web/pkg/middleware"
+	"github.com/hngprojects/hng_boilerplate_golang_web/tests"
+	"github.com/hngprojects/hng_boilerplate_golang_web/utility"
+)
+
+func TestPersonalAccessTokens(t *testing.T) {
+	router, authController := SetupAuthTestRouter()
+	db := authController.Db.Postgresql
+
+	router.POST("/api/v1/auth/login", authController.LoginUser)
+
+	whoAmI := func(c *gin.Context) {
+		userID, _ := middleware.GetIdFromToken(c)
+		c.JSON(http.StatusOK, utility.BuildSuccessResponse(http.StatusOK, "ok", userID))
+	}
+	router.GET("/api/v1/pat-test", middleware.Authorize(db, models.RoleIdentity.User), whoAmI)
+	router.POST("/api/v1/pat-test", middleware.Authorize(db, models.RoleIdentity.User), whoAmI)
+	router.GET("/api/v1/pat-test/admin", middleware.Authorize(db, models.RoleIdentity.SuperAdmin), whoAmI)
+
+	userData := tests.CreateTestUser(t, db, models.User{Name: "pat user"})
+
+	resp := tests.PerformRequest(router, http.MethodPost, "/api/v1/auth/login", "", models.LoginRequestModel{Email: userData.Email, Password: "password"})
+	tests.AssertStatusCode(t, resp.Code, http.StatusOK)
+	session := tests.ParseResponse(resp)["data"].(map[string]interface{})["access_token"].(string)
+
+	createToken := func(t *testing.T, name string, scopes ...string) (string, string) {
+		resp := tests.PerformRequest(router, http.MethodPost, "/api/v1/auth/personal-access-tokens", session, models.CreatePersonalAccessTokenRequestModel{Name: name, Scopes: scopes, ExpiresInDays: 7})
+		tests.AssertStatusCode(t, resp.Code, http.StatusCreated)
+
+		data := tests.ParseResponse(resp)["data"].(map[string]interface{})
+		details := data["personal_access_token"].(map[string]interface{})
+		return data["token"].(string), details["id"].(string)
+	}
+
+	writeToken, writeTokenID := createToken(t, "ci deploy", models.PersonalAccessTokenScopeWrite)
+	readToken, _ := createToken(t, "dashboard", models.PersonalAccessTokenScopeRead)
+
+	t.Run("Invalid Scope", func(t *testing.T) {
+		resp := tests.PerformRequest(router, http.MethodPost, "/api/v1/auth/personal-access-tokens", session, models.CreatePersonalAccessTokenRequestModel{Name: "bad", Scopes: []string{"everything"}})
+		tests.AssertStatusCode(t, resp.Code, http.StatusUnprocessableEntity)
+	})
+
+	t.Run("List Tokens", func(t *testing.T) {
+		resp := tests.PerformRequest(router, http.MethodGet, "/api/v1/auth/personal-access-tokens", session, nil)
+		tests.AssertStatusCode(t, resp.Code, http.StatusOK)
+
+		tokens := tests.ParseResponse(resp)["data"].([]interface{})
+		if len(tokens) != 2 {
+			t.Fatalf("expected 2 tokens, got %d", len(tokens))
+		}
+		if _, exposed := tokens[0].(map[string]interface{})["token_hash"]; exposed {
+			t.Errorf("token hash must not be returned")
+		}
+	})
+
+	t.Run("Authorize With Token", func(t *testing.T) {
+		resp := tests.PerformRequest(router, http.MethodGet, "/api/v1/pat-test", writeToken, nil)
+		tests.AssertStatusCode(t, resp.Code, http.StatusOK)
+		tests.AssertResponseMessage(t, tests.ParseResponse(resp)["data"].(string), userData.ID)
+
+		resp = tests.PerformRequest(router, http.MethodPost, "/api/v1/pat-test", writeToken, nil)
+		tests.AssertStatusCode(t, resp.Code, http.StatusOK)
+
+		var stored models.PersonalAccessToken
+		db.Where("id = ?", writeTokenID).First(&stored)
+		if stored.LastUsedAt == nil {
+			t.Errorf("expected last_used_at to be recorded")
+		}
+	})
+
+	t.Run("Role Still Applies", func(t *testing.T) {
+		resp := tests.PerformRequest(router, http.MethodGet, "/api/v1/pat-test/admin", writeToken, nil)
+		tests.AssertStatusCode(t, resp.Code, http.StatusUnauthorized)
+	})
+
+	t.Run("Read Scope Cannot Write", func(t *testing.T) {
+		resp := tests.PerformRequest(router, http.MethodGet, "/api/v1/pat-test", readToken, nil)
+		tests.AssertStatusCode(t, resp.Code, http.StatusOK)
+
+		resp = tests.PerformRequest(router, http.MethodPost, "/api/v1/pat-test", readToken, nil)
+		tests.AssertStatusCode(t, resp.Code, http.StatusForbidden)
+	})
+
+	t.Run("Token Cannot Manage Account", func(t *testing.T) {
+		resp := tests.PerformRequest(router, http.MethodGet, "/api/v1/auth/personal-access-tokens", writeToken, nil)
+		tests.AssertStatusCode(t, resp.Code, http.StatusForbidden)
+	})
+
+	t.Run("Token Cannot Use Admin Routes", func(t *testing.T) {
+		_, adminSession := tests.CreateLoggedInUser(t, *authController, models.User{Name: "pat admin", Role: int(models.RoleIdentity.SuperAdmin)})
+
+		resp := tests.PerformRequest(router, http.MethodPost, "/api/v1/auth/personal-access-tokens", adminSession, models.CreatePersonalAccessTokenRequestModel{Name: "admin script", Scopes: []string{models.PersonalAccessTokenScopeWrite}, ExpiresInDays: 7})
+		tests.AssertStatusCode(t, resp.Code, http.StatusCreated)
+		adminToken := tests.ParseResponse(resp)["data"].(map[string]interface{})["token"].(string)
+
+		sessionsUrl := "/api/v1/auth/users/" + userData.ID + "/sessions"
+		resp = tests.PerformRequest(router, http.MethodGet, sessionsUrl, adminToken, nil)
+		tests.AssertStatusCode(t, resp.Code, http.StatusForbidden)
+
+		resp = tests.PerformRequest(router, http.MethodGet, sessionsUrl, adminSession, nil)
+		tests.AssertStatusCode(t, resp.Code, http.StatusOK)
+	})
+
+	t.Run("Revoke Token", func(t *testing.T) {
+		resp := tests.PerformRequest(router, http.MethodDelete, "/api/v1/auth/personal-access-tokens/"+writeTokenID, session, nil)
+		tests.AssertStatusCode(t, resp.Code, http.StatusOK)
+
+		resp = tests.PerformRequest(router, http.MethodGet, "/api/v1/pat-test", writeToken, nil)
+		tests.AssertStatusCode(t, resp.Code, http.StatusUnauthorized)
+
+		resp = tests.PerformRequest(router, http.MethodDelete, "/api/v1/auth/personal-access-tokens/"+writeTokenID, session, nil)
+		tests.AssertStatusCode(t, resp.Code, http.StatusNotFound)
+	})
+
+	t.Run("Unknown Token", func(t *testing.T) {
+		resp := tests.PerformRequest(router, http.MethodGet, "/api/v1/pat-test", "pat_doesnotexist", nil)
+		tests.AssertStatusCode(t, resp.Code, http.StatusUnauthorized)
+	})
+}
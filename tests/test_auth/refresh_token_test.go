@@ -0,0 +1,112 @@
+package test_auth
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/hngprojects/hng_boilerplate_golang_web/internal/models"
+	"github.com/hngprojects/hng_boilerplate_golang_web/tests"
+	"github.com/hngprojects/hng_boilerplate_golang_web/utility"
+)
+
+func TestRefreshAccessToken(t *testing.T) {
+	router, authController := SetupAuthTestRouter()
+	db := authController.Db.Postgresql
+	currUUID := utility.GenerateUUID()
+	password, _ := utility.HashPassword("password")
+
+	router.POST("/api/v1/auth/login", authController.LoginUser)
+
+	userData := models.User{
+		ID:       utility.GenerateUUID(),
+		Name:     "refresh user",
+		Email:    fmt.Sprintf("testrefresh%v@qa.team", currUUID),
+		Password: password,
+		Role:     int(models.RoleIdentity.User),
+	}
+	db.Create(&userData)
+
+	login := func(t *testing.T) map[string]interface{} {
+		reqBody, _ := json.Marshal(models.LoginRequestModel{Email: userData.Email, Password: "password"})
+		req, _ := http.NewRequest(http.MethodPost, "/api/v1/auth/login", bytes.NewBuffer(reqBody))
+		req.Header.Set("Content-Type", "application/json")
+
+		resp := httptest.NewRecorder()
+		router.ServeHTTP(resp, req)
+
+		tests.AssertStatusCode(t, resp.Code, http.StatusOK)
+		return tests.ParseResponse(resp)["data"].(map[string]interface{})
+	}
+
+	refresh := func(refreshToken string) *httptest.ResponseRecorder {
+		reqBody, _ := json.Marshal(models.RefreshTokenRequestModel{RefreshToken: refreshToken})
+		req, _ := http.NewRequest(http.MethodPost, "/api/v1/auth/token/refresh", bytes.NewBuffer(reqBody))
+		req.Header.Set("Content-Type", "application/json")
+
+		resp := httptest.NewRecorder()
+		router.ServeHTTP(resp, req)
+		return resp
+	}
+
+	// change-password sits behind Authorize, so an empty body tells a live
+	// session (400) apart from a rejected token (401).
+	authorized := func(token string) int {
+		req, _ := http.NewRequest(http.MethodPut, "/api/v1/auth/change-password", nil)
+		req.Header.Set("Authorization", "Bearer "+token)
+
+		resp := httptest.NewRecorder()
+		router.ServeHTTP(resp, req)
+		return resp.Code
+	}
+
+	t.Run("Successful Rotation", func(t *testing.T) {
+		data := login(t)
+		oldAccess := data["access_token"].(string)
+		oldRefresh := data["refresh_token"].(string)
+
+		resp := refresh(oldRefresh)
+		tests.AssertStatusCode(t, resp.Code, http.StatusOK)
+		response := tests.ParseResponse(resp)
+		tests.AssertResponseMessage(t, response["message"].(string), "access token refreshed successfully")
+
+		newData := response["data"].(map[string]interface{})
+		if newData["refresh_token"].(string) == oldRefresh {
+			t.Errorf("expected refresh token to be rotated")
+		}
+
+		tests.AssertStatusCode(t, authorized(newData["access_token"].(string)), http.StatusBadRequest)
+		tests.AssertStatusCode(t, authorized(oldAccess), http.StatusUnauthorized)
+	})
+
+	t.Run("Reuse Revokes Session", func(t *testing.T) {
+		oldRefresh := login(t)["refresh_token"].(string)
+
+		resp := refresh(oldRefresh)
+		tests.AssertStatusCode(t, resp.Code, http.StatusOK)
+		newData := tests.ParseResponse(resp)["data"].(map[string]interface{})
+
+		resp = refresh(oldRefresh)
+		tests.AssertStatusCode(t, resp.Code, http.StatusUnauthorized)
+		response := tests.ParseResponse(resp)
+		tests.AssertResponseMessage(t, response["message"].(string), "refresh token reuse detected, session revoked")
+
+		tests.AssertStatusCode(t, refresh(newData["refresh_token"].(string)).Code, http.StatusUnauthorized)
+		tests.AssertStatusCode(t, authorized(newData["access_token"].(string)), http.StatusUnauthorized)
+	})
+
+	t.Run("Invalid Refresh Token", func(t *testing.T) {
+		resp := refresh("not-a-real-token")
+		tests.AssertStatusCode(t, resp.Code, http.StatusUnauthorized)
+		response := tests.ParseResponse(resp)
+		tests.AssertResponseMessage(t, response["message"].(string), "invalid or expired refresh token")
+	})
+
+	t.Run("Missing Refresh Token", func(t *testing.T) {
+		resp := refresh("")
+		tests.AssertStatusCode(t, resp.Code, http.StatusUnprocessableEntity)
+	})
+}
@@ -0,0 +1,146 @@
+package test_auth
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/hngprojects/hng_boilerplate_golang_web/internal/models"
+	"github.com/hngprojects/hng_boilerplate_golang_web/tests"
+	"github.com/hngprojects/hng_boilerplate_golang_web/utility"
+)
+
+func TestSessionManagement(t *testing.T) {
+	router, authController := SetupAuthTestRouter()
+	db := authController.Db.Postgresql
+	currUUID := utility.GenerateUUID()
+	password, _ := utility.HashPassword("password")
+
+	router.POST("/api/v1/auth/login", authController.LoginUser)
+
+	userData := models.User{
+		ID:       utility.GenerateUUID(),
+		Name:     "session user",
+		Email:    fmt.Sprintf("testsession%v@qa.team", currUUID),
+		Password: password,
+		Role:     int(models.RoleIdentity.User),
+	}
+	adminData := models.User{
+		ID:       utility.GenerateUUID(),
+		Name:     "session admin",
+		Email:    fmt.Sprintf("testsessionadmin%v@qa.team", currUUID),
+		Password: password,
+		Role:     int(models.RoleIdentity.SuperAdmin),
+	}
+	db.Create(&userData)
+	db.Create(&adminData)
+
+	login := func(t *testing.T, email, userAgent string) string {
+		reqBody, _ := json.Marshal(models.LoginRequestModel{Email: email, Password: "password"})
+		req, _ := http.NewRequest(http.MethodPost, "/api/v1/auth/login", bytes.NewBuffer(reqBody))
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("User-Agent", userAgent)
+
+		resp := httptest.NewRecorder()
+		router.ServeHTTP(resp, req)
+
+		tests.AssertStatusCode(t, resp.Code, http.StatusOK)
+		data := tests.ParseResponse(resp)["data"].(map[string]interface{})
+		return data["access_token"].(string)
+	}
+
+	call := func(method, url, token string) *httptest.ResponseRecorder {
+		req, _ := http.NewRequest(method, url, nil)
+		req.Header.Set("Authorization", "Bearer "+token)
+
+		resp := httptest.NewRecorder()
+		router.ServeHTTP(resp, req)
+		return resp
+	}
+
+	listSessions := func(t *testing.T, token string) []interface{} {
+		resp := call(http.MethodGet, "/api/v1/auth/sessions", token)
+		tests.AssertStatusCode(t, resp.Code, http.StatusOK)
+		return tests.ParseResponse(resp)["data"].([]interface{})
+	}
+
+	laptop := login(t, userData.Email, "laptop-browser")
+	phone := login(t, userData.Email, "phone-app")
+
+	t.Run("List Sessions", func(t *testing.T) {
+		sessions := listSessions(t, laptop)
+		if len(sessions) != 2 {
+			t.Fatalf("expected 2 sessions, got %d", len(sessions))
+		}
+
+		var current int
+		for _, s := range sessions {
+			session := s.(map[string]interface{})
+			if session["current"].(bool) {
+				current++
+				tests.AssertResponseMessage(t, session["user_agent"].(string), "laptop-browser")
+				if session["last_used_at"] == nil {
+					t.Errorf("expected last_used_at to be recorded")
+				}
+			}
+		}
+		if current != 1 {
+			t.Errorf("expected exactly one current session, got %d", current)
+		}
+	})
+
+	t.Run("Revoke One Session", func(t *testing.T) {
+		var phoneSessionID string
+		for _, s := range listSessions(t, laptop) {
+			session := s.(map[string]interface{})
+			if !session["current"].(bool) {
+				phoneSessionID = session["id"].(string)
+			}
+		}
+
+		resp := call(http.MethodDelete, "/api/v1/auth/sessions/"+phoneSessionID, laptop)
+		tests.AssertStatusCode(t, resp.Code, http.StatusOK)
+
+		tests.AssertStatusCode(t, call(http.MethodGet, "/api/v1/auth/sessions", phone).Code, http.StatusUnauthorized)
+		tests.AssertStatusCode(t, len(listSessions(t, laptop)), 1)
+	})
+
+	t.Run("Cannot Revoke Another User's Session", func(t *testing.T) {
+		adminToken := login(t, adminData.Email, "admin-browser")
+		adminSessionID := listSessions(t, adminToken)[0].(map[string]interface{})["id"].(string)
+
+		resp := call(http.MethodDelete, "/api/v1/auth/sessions/"+adminSessionID, laptop)
+		tests.AssertStatusCode(t, resp.Code, http.StatusNotFound)
+		tests.AssertStatusCode(t, call(http.MethodGet, "/api/v1/auth/sessions", adminToken).Code, http.StatusOK)
+	})
+
+	t.Run("Revoke Other Sessions", func(t *testing.T) {
+		tablet := login(t, userData.Email, "tablet")
+
+		resp := call(http.MethodDelete, "/api/v1/auth/sessions", laptop)
+		tests.AssertStatusCode(t, resp.Code, http.StatusOK)
+
+		tests.AssertStatusCode(t, call(http.MethodGet, "/api/v1/auth/sessions", tablet).Code, http.StatusUnauthorized)
+		tests.AssertStatusCode(t, len(listSessions(t, laptop)), 1)
+	})
+
+	t.Run("Superadmin Manages User Sessions", func(t *testing.T) {
+		adminToken := login(t, adminData.Email, "admin-browser")
+		url := fmt.Sprintf("/api/v1/auth/users/%s/sessions", userData.ID)
+
+		resp := call(http.MethodGet, url, laptop)
+		tests.AssertStatusCode(t, resp.Code, http.StatusUnauthorized)
+
+		resp = call(http.MethodGet, url, adminToken)
+		tests.AssertStatusCode(t, resp.Code, http.StatusOK)
+		tests.AssertStatusCode(t, len(tests.ParseResponse(resp)["data"].([]interface{})), 1)
+
+		resp = call(http.MethodDelete, url, adminToken)
+		tests.AssertStatusCode(t, resp.Code, http.StatusOK)
+
+		tests.AssertStatusCode(t, call(http.MethodGet, "/api/v1/auth/sessions", laptop).Code, http.StatusUnauthorized)
+	})
+}
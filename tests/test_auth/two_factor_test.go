@@ -0,0 +1,239 @@
+package test_auth
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/pquerna/otp/totp"
+
+	"github.com/hngprojects/hng_boilerplate_golang_web/internal/models"
+	"github.com/hngprojects/hng_boilerplate_golang_web/pkg/controller/key"
+	"github.com/hngprojects/hng_boilerplate_golang_web/pkg/middleware"
+	"github.com/hngprojects/hng_boilerplate_golang_web/tests"
+	"github.com/hngprojects/hng_boilerplate_golang_web/utility"
+)
+
+func TestTwoFALogin(t *testing.T) {
+	router, authController := SetupAuthTestRouter()
+	db := authController.Db.Postgresql
+	currUUID := utility.GenerateUUID()
+	password, _ := utility.HashPassword("password")
+
+	router.POST("/api/v1/auth/login", authController.LoginUser)
+
+	secret, err := totp.Generate(totp.GenerateOpts{Issuer: "HNG_KIMIKO", AccountName: currUUID})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	userData := models.User{
+		ID:       utility.GenerateUUID(),
+		Name:     "2fa user",
+		Email:    fmt.Sprintf("test2fa%v@qa.team", currUUID),
+		Password: password,
+		Role:     int(models.RoleIdentity.User),
+	}
+	db.Create(&userData)
+	db.Create(&models.Key{ID: utility.GenerateUUID(), UserID: userData.ID, Key: secret.Secret()})
+	db.Create(&models.DataPrivacySettings{UserID: userData.ID, Enable2FA: true})
+
+	login := func(t *testing.T) map[string]interface{} {
+		reqBody, _ := json.Marshal(models.LoginRequestModel{Email: userData.Email, Password: "password"})
+		req, _ := http.NewRequest(http.MethodPost, "/api/v1/auth/login", bytes.NewBuffer(reqBody))
+		req.Header.Set("Content-Type", "application/json")
+
+		resp := httptest.NewRecorder()
+		router.ServeHTTP(resp, req)
+
+		tests.AssertStatusCode(t, resp.Code, http.StatusOK)
+		response := tests.ParseResponse(resp)
+		tests.AssertResponseMessage(t, response["message"].(string), "2fa verification required")
+		return response["data"].(map[string]interface{})
+	}
+
+	verify := func(challenge, code string) *httptest.ResponseRecorder {
+		reqBody, _ := json.Marshal(models.TwoFALoginRequestModel{ChallengeToken: challenge, Code: code})
+		req, _ := http.NewRequest(http.MethodPost, "/api/v1/auth/2fa/login", bytes.NewBuffer(reqBody))
+		req.Header.Set("Content-Type", "application/json")
+
+		resp := httptest.NewRecorder()
+		router.ServeHTTP(resp, req)
+		return resp
+	}
+
+	t.Run("Login returns challenge instead of access token", func(t *testing.T) {
+		data := login(t)
+		if _, ok := data["access_token"]; ok {
+			t.Errorf("expected no access token before 2fa verification")
+		}
+		tests.AssertBool(t, data["two_factor_required"].(bool), true)
+	})
+
+	t.Run("Challenge token is rejected by protected routes", func(t *testing.T) {
+		challenge := login(t)["challenge_token"].(string)
+
+		reqBody, _ := json.Marshal(models.ChangePasswordRequestModel{OldPassword: "password", NewPassword: "newpassword"})
+		req, _ := http.NewRequest(http.MethodPut, "/api/v1/auth/change-password", bytes.NewBuffer(reqBody))
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("Authorization", "Bearer "+challenge)
+
+		resp := httptest.NewRecorder()
+		router.ServeHTTP(resp, req)
+
+		tests.AssertStatusCode(t, resp.Code, http.StatusUnauthorized)
+	})
+
+	t.Run("Invalid code", func(t *testing.T) {
+		challenge := login(t)["challenge_token"].(string)
+
+		resp := verify(challenge, "000000")
+		tests.AssertStatusCode(t, resp.Code, http.StatusUnauthorized)
+		response := tests.ParseResponse(resp)
+		tests.AssertResponseMessage(t, response["message"].(string), "invalid 2fa code")
+	})
+
+	t.Run("Invalid challenge token", func(t *testing.T) {
+		code, _ := totp.GenerateCode(secret.Secret(), time.Now())
+
+		resp := verify("not-a-token", code)
+		tests.AssertStatusCode(t, resp.Code, http.StatusUnauthorized)
+		response := tests.ParseResponse(resp)
+		tests.AssertResponseMessage(t, response["message"].(string), "invalid or expired challenge token")
+	})
+
+	t.Run("Successful 2fa login", func(t *testing.T) {
+		challenge := login(t)["challenge_token"].(string)
+		code, _ := totp.GenerateCode(secret.Secret(), time.Now())
+
+		resp := verify(challenge, code)
+		tests.AssertStatusCode(t, resp.Code, http.StatusOK)
+		response := tests.ParseResponse(resp)
+		tests.AssertResponseMessage(t, response["message"].(string), "user login successfully")
+
+		data := response["data"].(map[string]interface{})
+		if _, ok := data["access_token"].(string); !ok {
+			t.Errorf("expected access token after 2fa verification")
+		}
+
+		resp = verify(challenge, code)
+		tests.AssertStatusCode(t, resp.Code, http.StatusUnauthorized)
+		response = tests.ParseResponse(resp)
+		tests.AssertResponseMessage(t, response["message"].(string), "invalid or expired challenge token")
+	})
+
+	t.Run("Password alone does not clear failed codes", func(t *testing.T) {
+		challenge := login(t)["challenge_token"].(string)
+		for i := 0; i < 2; i++ {
+			resp := verify(challenge, "000000")
+			tests.AssertStatusCode(t, resp.Code, http.StatusUnauthorized)
+		}
+
+		challenge = login(t)["challenge_token"].(string)
+		resp := verify(challenge, "000000")
+		tests.AssertStatusCode(t, resp.Code, http.StatusUnauthorized)
+
+		code, _ := totp.GenerateCode(secret.Secret(), time.Now())
+		resp = verify(challenge, code)
+		tests.AssertStatusCode(t, resp.Code, http.StatusTooManyRequests)
+	})
+}
+
+func TestTwoFARecoveryCodes(t *testing.T) {
+	router, authController := SetupAuthTestRouter()
+	db := authController.Db.Postgresql
+	currUUID := utility.GenerateUUID()
+	password, _ := utility.HashPassword("password")
+	recoveryCode := "abcde-fghjk"
+	recoveryHash, _ := utility.HashPassword(recoveryCode)
+
+	keyController := key.Controller{Db: authController.Db, Validator: authController.Validator, Logger: authController.Logger}
+	router.POST("/api/v1/auth/login", authController.LoginUser)
+	router.POST("/api/v1/auth/2fa/disable",
+		middleware.Authorize(db, models.RoleIdentity.SuperAdmin, models.RoleIdentity.User),
+		keyController.DisableTwoFA)
+
+	userData := models.User{
+		ID:       utility.GenerateUUID(),
+		Name:     "2fa recovery user",
+		Email:    fmt.Sprintf("test2farecovery%v@qa.team", currUUID),
+		Password: password,
+		Role:     int(models.RoleIdentity.User),
+	}
+	db.Create(&userData)
+	db.Create(&models.Key{ID: utility.GenerateUUID(), UserID: userData.ID, Key: "JBSWY3DPEHPK3PXP"})
+	db.Create(&models.DataPrivacySettings{UserID: userData.ID, Enable2FA: true})
+	db.Create(&models.RecoveryCode{ID: utility.GenerateUUID(), UserID: userData.ID, CodeHash: recoveryHash})
+
+	challenge := func() string {
+		reqBody, _ := json.Marshal(models.LoginRequestModel{Email: userData.Email, Password: "password"})
+		req, _ := http.NewRequest(http.MethodPost, "/api/v1/auth/login", bytes.NewBuffer(reqBody))
+		req.Header.Set("Content-Type", "application/json")
+
+		resp := httptest.NewRecorder()
+		router.ServeHTTP(resp, req)
+
+		data := tests.ParseResponse(resp)["data"].(map[string]interface{})
+		return data["challenge_token"].(string)
+	}
+
+	verify := func(code string) *httptest.ResponseRecorder {
+		reqBody, _ := json.Marshal(models.TwoFALoginRequestModel{ChallengeToken: challenge(), RecoveryCode: code})
+		req, _ := http.NewRequest(http.MethodPost, "/api/v1/auth/2fa/login", bytes.NewBuffer(reqBody))
+		req.Header.Set("Content-Type", "application/json")
+
+		resp := httptest.NewRecorder()
+		router.ServeHTTP(resp, req)
+		return resp
+	}
+
+	var accessToken string
+
+	t.Run("Login with recovery code", func(t *testing.T) {
+		resp := verify(recoveryCode)
+		tests.AssertStatusCode(t, resp.Code, http.StatusOK)
+
+		data := tests.ParseResponse(resp)["data"].(map[string]interface{})
+		accessToken, _ = data["access_token"].(string)
+	})
+
+	t.Run("Recovery code cannot be reused", func(t *testing.T) {
+		resp := verify(recoveryCode)
+		tests.AssertStatusCode(t, resp.Code, http.StatusUnauthorized)
+		response := tests.ParseResponse(resp)
+		tests.AssertResponseMessage(t, response["message"].(string), "invalid recovery code")
+	})
+
+	t.Run("Disable 2fa with wrong password", func(t *testing.T) {
+		reqBody, _ := json.Marshal(models.TwoFAPasswordRequestModel{Password: "wrongpassword"})
+		req, _ := http.NewRequest(http.MethodPost, "/api/v1/auth/2fa/disable", bytes.NewBuffer(reqBody))
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("Authorization", "Bearer "+accessToken)
+
+		resp := httptest.NewRecorder()
+		router.ServeHTTP(resp, req)
+
+		tests.AssertStatusCode(t, resp.Code, http.StatusUnauthorized)
+	})
+
+	t.Run("Disable 2fa", func(t *testing.T) {
+		reqBody, _ := json.Marshal(models.TwoFAPasswordRequestModel{Password: "password"})
+		req, _ := http.NewRequest(http.MethodPost, "/api/v1/auth/2fa/disable", bytes.NewBuffer(reqBody))
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("Authorization", "Bearer "+accessToken)
+
+		resp := httptest.NewRecorder()
+		router.ServeHTTP(resp, req)
+
+		tests.AssertStatusCode(t, resp.Code, http.StatusOK)
+
+		token := tests.GetLoginToken(t, *authController, models.LoginRequestModel{Email: userData.Email, Password: "password"})
+		if token == "" {
+			t.Errorf("expected plain login to return an access token once 2fa is disabled")
+		}
+	})
+}
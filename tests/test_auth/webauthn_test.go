@@ -0,0 +1,315 @@
+package test_auth
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-webauthn/webauthn/protocol/webauthncbor"
+
+	"github.com/hngprojects/hng_boilerplate_golang_web/internal/config"
+	"github.com/hngprojects/hng_boilerplate_golang_web/internal/models"
+	"github.com/hngprojects/hng_boilerplate_golang_web/tests"
+)
+
+const (
+	testRPID   = "localhost"
+	testOrigin = "http://localhost:3000"
+)
+
+// softAuthenticator is a software passkey holding a single P-256 credential.
+type softAuthenticator struct {
+	key          *ecdsa.PrivateKey
+	credentialID []byte
+	userHandle   []byte
+	signCount    uint32
+}
+
+func newSoftAuthenticator(t *testing.T) *softAuthenticator {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	credentialID := make([]byte, 16)
+	rand.Read(credentialID)
+
+	return &softAuthenticator{key: key, credentialID: credentialID}
+}
+
+func b64(data []byte) string {
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+func (a *softAuthenticator) clientData(ceremony, challenge string) []byte {
+	data, _ := json.Marshal(map[string]string{
+		"type":      ceremony,
+		"challenge": challenge,
+		"origin":    testOrigin,
+	})
+	return data
+}
+
+func (a *softAuthenticator) authData(flags byte, attested []byte) []byte {
+	rpIDHash := sha256.Sum256([]byte(testRPID))
+
+	data := append([]byte{}, rpIDHash[:]...)
+	data = append(data, flags)
+	data = binary.BigEndian.AppendUint32(data, a.signCount)
+	return append(data, attested...)
+}
+
+// create answers navigator.credentials.create with a "none" attestation.
+func (a *softAuthenticator) create(options map[string]interface{}) json.RawMessage {
+	publicKey := options["publicKey"].(map[string]interface{})
+	user := publicKey["user"].(map[string]interface{})
+	a.userHandle, _ = base64.RawURLEncoding.DecodeString(user["id"].(string))
+
+	coseKey, _ := webauthncbor.Marshal(map[int]interface{}{
+		1:  2,
+		3:  -7,
+		-1: 1,
+		-2: a.key.PublicKey.X.FillBytes(make([]byte, 32)),
+		-3: a.key.PublicKey.Y.FillBytes(make([]byte, 32)),
+	})
+
+	attested := make([]byte, 16)
+	attested = binary.BigEndian.AppendUint16(attested, uint16(len(a.credentialID)))
+	attested = append(attested, a.credentialID...)
+	attested = append(attested, coseKey...)
+
+	attestation, _ := webauthncbor.Marshal(map[string]interface{}{
+		"fmt":      "none",
+		"attStmt":  map[string]interface{}{},
+		"authData": a.authData(0x45, attested),
+	})
+
+	credential, _ := json.Marshal(map[string]interface{}{
+		"id":    b64(a.credentialID),
+		"rawId": b64(a.credentialID),
+		"type":  "public-key",
+		"response": map[string]string{
+			"clientDataJSON":    b64(a.clientData("webauthn.create", publicKey["challenge"].(string))),
+			"attestationObject": b64(attestation),
+		},
+	})
+	return credential
+}
+
+// get answers navigator.credentials.get with a user-verified assertion.
+func (a *softAuthenticator) get(options map[string]interface{}) json.RawMessage {
+	publicKey := options["publicKey"].(map[string]interface{})
+
+	a.signCount++
+	authData := a.authData(0x05, nil)
+	clientData := a.clientData("webauthn.get", publicKey["challenge"].(string))
+
+	clientDataHash := sha256.Sum256(clientData)
+	digest := sha256.Sum256(append(append([]byte{}, authData...), clientDataHash[:]...))
+	signature, _ := ecdsa.SignASN1(rand.Reader, a.key, digest[:])
+
+	credential, _ := json.Marshal(map[string]interface{}{
+		"id":    b64(a.credentialID),
+		"rawId": b64(a.credentialID),
+		"type":  "public-key",
+		"response": map[string]string{
+			"clientDataJSON":    b64(clientData),
+			"authenticatorData": b64(authData),
+			"signature":         b64(signature),
+			"userHandle":        b64(a.userHandle),
+		},
+	})
+	return credential
+}
+
+func TestWebAuthn(t *testing.T) {
+	router, authController := SetupAuthTestRouter()
+	db := authController.Db.Postgresql
+
+	router.POST("/api/v1/auth/login", authController.LoginUser)
+
+	webAuthnConfig := config.GetConfig().WebAuthn
+	config.GetConfig().WebAuthn = config.WebAuthn{RPID: testRPID, RPDisplayName: "Test", RPOrigins: []string{testOrigin}}
+	defer func() { config.GetConfig().WebAuthn = webAuthnConfig }()
+
+	data := func(resp *httptest.ResponseRecorder) map[string]interface{} {
+		return tests.ParseResponse(resp)["data"].(map[string]interface{})
+	}
+
+	login := func(email string) map[string]interface{} {
+		resp := tests.PerformRequest(router, http.MethodPost, "/api/v1/auth/login", "", models.LoginRequestModel{Email: email, Password: "password"})
+		tests.AssertStatusCode(t, resp.Code, http.StatusOK)
+		return data(resp)
+	}
+
+	register := func(t *testing.T, token string, authenticator *softAuthenticator, secondFactor bool) map[string]interface{} {
+		resp := tests.PerformRequest(router, http.MethodPost, "/api/v1/auth/webauthn/register/begin", token, nil)
+		tests.AssertStatusCode(t, resp.Code, http.StatusOK)
+		begin := data(resp)
+
+		resp = tests.PerformRequest(router, http.MethodPost, "/api/v1/auth/webauthn/register/finish", token, models.FinishWebAuthnRegistrationRequestModel{
+			SessionID:    begin["session_id"].(string),
+			Name:         "laptop",
+			SecondFactor: secondFactor,
+			Credential:   authenticator.create(begin["options"].(map[string]interface{})),
+		})
+		tests.AssertStatusCode(t, resp.Code, http.StatusCreated)
+		return data(resp)["credential"].(map[string]interface{})
+	}
+
+	passkeyLogin := func(email string, authenticator *softAuthenticator) *httptest.ResponseRecorder {
+		resp := tests.PerformRequest(router, http.MethodPost, "/api/v1/auth/webauthn/login/begin", "", models.BeginWebAuthnLoginRequestModel{Email: email})
+		tests.AssertStatusCode(t, resp.Code, http.StatusOK)
+		begin := data(resp)
+
+		return tests.PerformRequest(router, http.MethodPost, "/api/v1/auth/webauthn/login/finish", "", models.FinishWebAuthnLoginRequestModel{
+			SessionID:  begin["session_id"].(string),
+			Credential: authenticator.get(begin["options"].(map[string]interface{})),
+		})
+	}
+
+	t.Run("Register And Login", func(t *testing.T) {
+		user := tests.CreateTestUser(t, db, models.User{Name: "primary", IsVerified: true})
+		authenticator := newSoftAuthenticator(t)
+		token := login(user.Email)["access_token"].(string)
+
+		credential := register(t, token, authenticator, false)
+		if credential["name"] != "laptop" {
+			t.Errorf("expected credential name laptop, got %v", credential["name"])
+		}
+
+		resp := passkeyLogin(user.Email, authenticator)
+		tests.AssertStatusCode(t, resp.Code, http.StatusOK)
+		if data(resp)["access_token"] == nil {
+			t.Fatalf("expected a session after passkey login")
+		}
+
+		var stored models.WebAuthnCredential
+		db.Where("user_id = ?", user.ID).First(&stored)
+		if stored.SignCount != int64(authenticator.signCount) || stored.LastUsedAt == nil {
+			t.Errorf("expected sign count %v to be stored, got %v", authenticator.signCount, stored.SignCount)
+		}
+	})
+
+	t.Run("Discoverable Login", func(t *testing.T) {
+		user := tests.CreateTestUser(t, db, models.User{Name: "discoverable", IsVerified: true})
+		authenticator := newSoftAuthenticator(t)
+		register(t, login(user.Email)["access_token"].(string), authenticator, false)
+
+		resp := passkeyLogin("", authenticator)
+		tests.AssertStatusCode(t, resp.Code, http.StatusOK)
+	})
+
+	t.Run("Begin Without Passkeys Is Discoverable", func(t *testing.T) {
+		user := tests.CreateTestUser(t, db, models.User{Name: "nopasskey", IsVerified: true})
+
+		for _, email := range []string{user.Email, "nobody" + user.Email} {
+			resp := tests.PerformRequest(router, http.MethodPost, "/api/v1/auth/webauthn/login/begin", "", models.BeginWebAuthnLoginRequestModel{Email: email})
+			tests.AssertStatusCode(t, resp.Code, http.StatusOK)
+
+			publicKey := data(resp)["options"].(map[string]interface{})["publicKey"].(map[string]interface{})
+			_, allowList := publicKey["allowCredentials"]
+			tests.AssertBool(t, allowList, false)
+		}
+	})
+
+	t.Run("Session Is Single Use", func(t *testing.T) {
+		user := tests.CreateTestUser(t, db, models.User{Name: "replay", IsVerified: true})
+		authenticator := newSoftAuthenticator(t)
+		register(t, login(user.Email)["access_token"].(string), authenticator, false)
+
+		resp := tests.PerformRequest(router, http.MethodPost, "/api/v1/auth/webauthn/login/begin", "", models.BeginWebAuthnLoginRequestModel{Email: user.Email})
+		begin := data(resp)
+		finish := models.FinishWebAuthnLoginRequestModel{
+			SessionID:  begin["session_id"].(string),
+			Credential: authenticator.get(begin["options"].(map[string]interface{})),
+		}
+
+		resp = tests.PerformRequest(router, http.MethodPost, "/api/v1/auth/webauthn/login/finish", "", finish)
+		tests.AssertStatusCode(t, resp.Code, http.StatusOK)
+
+		resp = tests.PerformRequest(router, http.MethodPost, "/api/v1/auth/webauthn/login/finish", "", finish)
+		tests.AssertStatusCode(t, resp.Code, http.StatusBadRequest)
+	})
+
+	t.Run("Cloned Authenticator", func(t *testing.T) {
+		user := tests.CreateTestUser(t, db, models.User{Name: "cloned", IsVerified: true})
+		authenticator := newSoftAuthenticator(t)
+		register(t, login(user.Email)["access_token"].(string), authenticator, false)
+
+		authenticator.signCount = 10
+		resp := passkeyLogin(user.Email, authenticator)
+		tests.AssertStatusCode(t, resp.Code, http.StatusOK)
+
+		authenticator.signCount = 3
+		resp = passkeyLogin(user.Email, authenticator)
+		tests.AssertStatusCode(t, resp.Code, http.StatusUnauthorized)
+	})
+
+	t.Run("Unknown Authenticator", func(t *testing.T) {
+		user := tests.CreateTestUser(t, db, models.User{Name: "unknown", IsVerified: true})
+		register(t, login(user.Email)["access_token"].(string), newSoftAuthenticator(t), false)
+
+		other := newSoftAuthenticator(t)
+		other.userHandle = []byte(user.ID)
+
+		resp := passkeyLogin(user.Email, other)
+		tests.AssertStatusCode(t, resp.Code, http.StatusUnauthorized)
+	})
+
+	t.Run("Second Factor", func(t *testing.T) {
+		user := tests.CreateTestUser(t, db, models.User{Name: "secondfactor", IsVerified: true})
+		authenticator := newSoftAuthenticator(t)
+		register(t, login(user.Email)["access_token"].(string), authenticator, true)
+
+		challenge := login(user.Email)
+		tests.AssertBool(t, challenge["two_factor_required"].(bool), true)
+
+		resp := tests.PerformRequest(router, http.MethodPost, "/api/v1/auth/2fa/webauthn/begin", "", models.BeginWebAuthnTwoFARequestModel{
+			ChallengeToken: challenge["challenge_token"].(string),
+		})
+		tests.AssertStatusCode(t, resp.Code, http.StatusOK)
+		begin := data(resp)
+
+		resp = tests.PerformRequest(router, http.MethodPost, "/api/v1/auth/2fa/webauthn/finish", "", models.FinishWebAuthnTwoFARequestModel{
+			ChallengeToken: challenge["challenge_token"].(string),
+			SessionID:      begin["session_id"].(string),
+			Credential:     authenticator.get(begin["options"].(map[string]interface{})),
+		})
+		tests.AssertStatusCode(t, resp.Code, http.StatusOK)
+		if data(resp)["access_token"] == nil {
+			t.Fatalf("expected a session after passkey verification")
+		}
+	})
+
+	t.Run("Manage Credentials", func(t *testing.T) {
+		user := tests.CreateTestUser(t, db, models.User{Name: "manage", IsVerified: true})
+		token := login(user.Email)["access_token"].(string)
+		first := register(t, token, newSoftAuthenticator(t), false)
+		register(t, token, newSoftAuthenticator(t), false)
+
+		resp := tests.PerformRequest(router, http.MethodGet, "/api/v1/auth/webauthn/credentials", token, nil)
+		tests.AssertStatusCode(t, resp.Code, http.StatusOK)
+		if credentials := tests.ParseResponse(resp)["data"].([]interface{}); len(credentials) != 2 {
+			t.Fatalf("expected 2 credentials, got %v", len(credentials))
+		}
+
+		url := "/api/v1/auth/webauthn/credentials/" + first["id"].(string)
+
+		resp = tests.PerformRequest(router, http.MethodPatch, url, token, models.RenameWebAuthnCredentialRequestModel{Name: "phone"})
+		tests.AssertStatusCode(t, resp.Code, http.StatusOK)
+
+		resp = tests.PerformRequest(router, http.MethodDelete, url, token, nil)
+		tests.AssertStatusCode(t, resp.Code, http.StatusOK)
+
+		resp = tests.PerformRequest(router, http.MethodDelete, url, token, nil)
+		tests.AssertStatusCode(t, resp.Code, http.StatusNotFound)
+	})
+}
@@ -36,7 +36,7 @@ func Initialise(currUUID string, t *testing.T, r *gin.Engine, db *storage.Databa
 
 	tst.SignupUser(t, r, user, userSignUpData, status)
 
-	token := tst.GetLoginToken(t, r, user, loginData)
+	token := tst.GetLoginToken(t, user, loginData)
 
 	BillingCreationData := models.CreateBillingRequest{
 		Name:  fmt.Sprintf("Billing Name %s", currUUID),
@@ -0,0 +1,109 @@
+package test_billing
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+
+	"github.com/hngprojects/hng_boilerplate_golang_web/internal/models"
+	"github.com/hngprojects/hng_boilerplate_golang_web/pkg/controller/auth"
+	"github.com/hngprojects/hng_boilerplate_golang_web/pkg/controller/billing"
+	"github.com/hngprojects/hng_boilerplate_golang_web/pkg/middleware"
+	"github.com/hngprojects/hng_boilerplate_golang_web/pkg/repository/storage"
+	orgService "github.com/hngprojects/hng_boilerplate_golang_web/services/organisation"
+	tst "github.com/hngprojects/hng_boilerplate_golang_web/tests"
+	"github.com/hngprojects/hng_boilerplate_golang_web/utility"
+)
+
+func TestBillingLifecycle(t *testing.T) {
+	logger := tst.Setup()
+	gin.SetMode(gin.TestMode)
+
+	validatorRef := validator.New()
+	db := storage.Connection()
+	currUUID := utility.GenerateUUID()
+	user := auth.Controller{Db: db, Validator: validatorRef, Logger: logger}
+	billing := billing.Controller{Db: db, Validator: validatorRef, Logger: logger}
+
+	billingId, adminToken := Initialise(currUUID, t, gin.Default(), db, user, billing, true)
+
+	regularUser, userToken := tst.CreateLoggedInUser(t, user, models.User{Name: "Regular User"})
+
+	org, err := orgService.CreateOrganisation(models.CreateOrgRequestModel{
+		Name:    fmt.Sprintf("Org billing%v", currUUID),
+		Email:   fmt.Sprintf("billingorg%v@qa.team", currUUID),
+		State:   "test",
+		Type:    "type1",
+		Address: "wakanda land",
+		Country: "wakanda",
+	}, db.Postgresql, regularUser.ID)
+	if err != nil {
+		t.Fatalf("creating organisation failed: %v", err)
+	}
+
+	r := gin.Default()
+	billingAdminUrl := r.Group(fmt.Sprintf("%v", "/api/v1"), middleware.Authorize(db.Postgresql, models.RoleIdentity.SuperAdmin), middleware.RequireSession())
+	{
+		billingAdminUrl.POST("/billing-plans", billing.CreateBilling)
+		billingAdminUrl.DELETE("/billing-plans/:id", billing.DeleteBilling)
+		billingAdminUrl.POST("/billing-plans/:id/restore", billing.RestoreBilling)
+		billingAdminUrl.GET("/billing-plans/:id/audit-logs", billing.GetBillingAuditLogs)
+	}
+
+	planUrl := fmt.Sprintf("/api/v1/billing-plans/%s", billingId)
+
+	t.Run("Regular User Cannot Create Plan", func(t *testing.T) {
+		rr := tst.PerformRequest(r, http.MethodPost, "/api/v1/billing-plans", userToken, models.CreateBillingRequest{
+			Name:  fmt.Sprintf("Billing Name %s", utility.GenerateUUID()),
+			Price: 100,
+		})
+		tst.AssertStatusCode(t, rr.Code, http.StatusUnauthorized)
+		tst.AssertResponseMessage(t, tst.ParseResponse(rr)["message"].(string), "role not authorized!")
+	})
+
+	t.Run("Plan With Subscribers Is Archived", func(t *testing.T) {
+		db.Postgresql.Create(&models.Subscription{
+			ID:             utility.GenerateUUID(),
+			BillingID:      billingId,
+			OrganisationID: org.ID,
+		})
+
+		rr := tst.PerformRequest(r, http.MethodDelete, planUrl, adminToken, nil)
+		tst.AssertStatusCode(t, rr.Code, http.StatusOK)
+
+		var plan models.Billing
+		plan, _ = plan.GetBillingById(db.Postgresql, billingId)
+		tst.AssertResponseMessage(t, plan.Status, models.BillingStatusArchived)
+
+		rr = tst.PerformRequest(r, http.MethodDelete, planUrl, adminToken, nil)
+		tst.AssertStatusCode(t, rr.Code, http.StatusConflict)
+	})
+
+	t.Run("Restore Plan", func(t *testing.T) {
+		rr := tst.PerformRequest(r, http.MethodPost, planUrl+"/restore", adminToken, nil)
+		tst.AssertStatusCode(t, rr.Code, http.StatusOK)
+
+		rr = tst.PerformRequest(r, http.MethodPost, planUrl+"/restore", adminToken, nil)
+		tst.AssertStatusCode(t, rr.Code, http.StatusConflict)
+	})
+
+	t.Run("Mutations Are Audited", func(t *testing.T) {
+		rr := tst.PerformRequest(r, http.MethodGet, planUrl+"/audit-logs", adminToken, nil)
+		tst.AssertStatusCode(t, rr.Code, http.StatusOK)
+
+		logs := tst.ParseResponse(rr)["data"].([]interface{})
+		if len(logs) != 3 {
+			t.Errorf("expected create, archive and restore to be audited, got %d logs", len(logs))
+		}
+	})
+
+	t.Run("Deleted Organisations Are Not Subscribers", func(t *testing.T) {
+		db.Postgresql.Delete(org)
+
+		rr := tst.PerformRequest(r, http.MethodDelete, planUrl, adminToken, nil)
+		tst.AssertStatusCode(t, rr.Code, http.StatusNoContent)
+	})
+}
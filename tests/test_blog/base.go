@@ -36,7 +36,7 @@ func initialise(currUUID string, t *testing.T, r *gin.Engine, db *storage.Databa
 
 	tst.SignupUser(t, r, user, userSignUpData, status)
 
-	token := tst.GetLoginToken(t, r, user, loginData)
+	token := tst.GetLoginToken(t, user, loginData)
 
 	blogCreationData := models.CreateBlogRequest{
 		Title:   fmt.Sprintf("Blog %s", currUUID),
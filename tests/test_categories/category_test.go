@@ -44,7 +44,7 @@ func TestGetCategoryNames(t *testing.T) {
 	r := gin.Default()
 	tst.SignupUser(t, r, auth, userSignUpData, false)
 
-	token := tst.GetLoginToken(t, r, auth, loginData)
+	token := tst.GetLoginToken(t, auth, loginData)
 	category := category.Controller{Db: db, Validator: validatorRef, Logger: logger}
 
 	r = gin.Default()
@@ -49,7 +49,7 @@ func TestAddToContactUs(t *testing.T) {
 			Email:    regularUser.Email,
 			Password: "password",
 		}
-		token := tests.GetLoginToken(t, router, *authController, loginData)
+		token := tests.GetLoginToken(t, *authController, loginData)
 
 		contactData := models.ContactUs{
 			Name:    "John Doe",
@@ -84,7 +84,7 @@ func TestAddToContactUs(t *testing.T) {
 			Email:    regularUser.Email,
 			Password: "password",
 		}
-		token := tests.GetLoginToken(t, router, *authController, loginData)
+		token := tests.GetLoginToken(t, *authController, loginData)
 
 		contactData := models.ContactUs{
 			Name: "John Doe",
@@ -110,7 +110,7 @@ func TestAddToContactUs(t *testing.T) {
 			Email:    regularUser.Email,
 			Password: "password",
 		}
-		token := tests.GetLoginToken(t, router, *authController, loginData)
+		token := tests.GetLoginToken(t, *authController, loginData)
 
 		contactData := models.ContactUs{
 			Name:    "John Doe",
@@ -64,7 +64,7 @@ func TestDeleteContactUs(t *testing.T) {
 			Email:    adminUser.Email,
 			Password: "password",
 		}
-		token := tests.GetLoginToken(t, router, *authController, loginData)
+		token := tests.GetLoginToken(t, *authController, loginData)
 
 		req, _ := http.NewRequest(http.MethodDelete, fmt.Sprintf("/api/v1/contact/%s", contact.ID), nil)
 		req.Header.Set("Content-Type", "application/json")
@@ -107,7 +107,7 @@ func TestDeleteContactUs(t *testing.T) {
 			Email:    regularUser.Email,
 			Password: "password",
 		}
-		token := tests.GetLoginToken(t, router, *authController, loginData)
+		token := tests.GetLoginToken(t, *authController, loginData)
 
 		req, _ := http.NewRequest(http.MethodDelete, fmt.Sprintf("/api/v1/contact/%s", contact.ID), nil)
 		req.Header.Set("Content-Type", "application/json")
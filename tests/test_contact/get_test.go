@@ -71,7 +71,7 @@ func TestGetAllContactUs(t *testing.T) {
 			Email:    adminUser.Email,
 			Password: "password",
 		}
-		token := tests.GetLoginToken(t, router, *authController, loginData)
+		token := tests.GetLoginToken(t, *authController, loginData)
 
 		req, _ := http.NewRequest(http.MethodGet, "/api/v1/contact", nil)
 		req.Header.Set("Content-Type", "application/json")
@@ -107,7 +107,7 @@ func TestGetAllContactUs(t *testing.T) {
 			Email:    regularUser.Email,
 			Password: "password",
 		}
-		token := tests.GetLoginToken(t, router, *authController, loginData)
+		token := tests.GetLoginToken(t, *authController, loginData)
 
 		req, _ := http.NewRequest(http.MethodGet, "/api/v1/contact", nil)
 		req.Header.Set("Content-Type", "application/json")
@@ -173,7 +173,7 @@ func TestGetContactUsById(t *testing.T) {
 			Email:    adminUser.Email,
 			Password: "password",
 		}
-		token := tests.GetLoginToken(t, router, *authController, loginData)
+		token := tests.GetLoginToken(t, *authController, loginData)
 
 		req, _ := http.NewRequest(http.MethodGet, fmt.Sprintf("/api/v1/contact/id/%s", contact.ID), nil)
 		req.Header.Set("Content-Type", "application/json")
@@ -209,7 +209,7 @@ func TestGetContactUsById(t *testing.T) {
 			Email:    regularUser.Email,
 			Password: "password",
 		}
-		token := tests.GetLoginToken(t, router, *authController, loginData)
+		token := tests.GetLoginToken(t, *authController, loginData)
 
 		req, _ := http.NewRequest(http.MethodGet, fmt.Sprintf("/api/v1/contact/id/%s", contact.ID), nil)
 		req.Header.Set("Content-Type", "application/json")
@@ -275,7 +275,7 @@ func TestGetContactUsByEmail(t *testing.T) {
 			Email:    adminUser.Email,
 			Password: "password",
 		}
-		token := tests.GetLoginToken(t, router, *authController, loginData)
+		token := tests.GetLoginToken(t, *authController, loginData)
 
 		req, _ := http.NewRequest(http.MethodGet, fmt.Sprintf("/api/v1/contact/email/%s", contact.Email), nil)
 		req.Header.Set("Content-Type", "application/json")
@@ -311,7 +311,7 @@ func TestGetContactUsByEmail(t *testing.T) {
 			Email:    regularUser.Email,
 			Password: "password",
 		}
-		token := tests.GetLoginToken(t, router, *authController, loginData)
+		token := tests.GetLoginToken(t, *authController, loginData)
 
 		req, _ := http.NewRequest(http.MethodGet, fmt.Sprintf("/api/v1/contact/email/%s", contact.Email), nil)
 		req.Header.Set("Content-Type", "application/json")
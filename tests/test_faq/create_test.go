@@ -50,7 +50,7 @@ func TestAddToFaq(t *testing.T) {
 			Email:    adminUser.Email,
 			Password: "password",
 		}
-		token := tests.GetLoginToken(t, router, *authController, loginData)
+		token := tests.GetLoginToken(t, *authController, loginData)
 
 		faq := models.FAQ{
 			Question: fmt.Sprintf("What is the purpose of this %s FAQ?", utility.RandomString(6)),
@@ -78,7 +78,7 @@ func TestAddToFaq(t *testing.T) {
 			Email:    adminUser.Email,
 			Password: "password",
 		}
-		token := tests.GetLoginToken(t, router, *authController, loginData)
+		token := tests.GetLoginToken(t, *authController, loginData)
 
 		faq := models.FAQ{
 			Question: fmt.Sprintf("What is the purpose a %s FAQ?", utility.RandomString(5)),
@@ -106,7 +106,7 @@ func TestAddToFaq(t *testing.T) {
 			Email:    adminUser.Email,
 			Password: "password",
 		}
-		token := tests.GetLoginToken(t, router, *authController, loginData)
+		token := tests.GetLoginToken(t, *authController, loginData)
 		theRandom := utility.RandomString(6)
 
 		faq := models.FAQ{
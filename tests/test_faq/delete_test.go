@@ -61,7 +61,7 @@ func TestDeleteFaq(t *testing.T) {
 			Email:    adminUser.Email,
 			Password: "password",
 		}
-		token := tests.GetLoginToken(t, router, *authController, loginData)
+		token := tests.GetLoginToken(t, *authController, loginData)
 
 		req, _ := http.NewRequest(http.MethodDelete, fmt.Sprintf("/api/v1/faq/%s", faq.ID), nil)
 		req.Header.Set("Content-Type", "application/json")
@@ -82,7 +82,7 @@ func TestDeleteFaq(t *testing.T) {
 			Email:    adminUser.Email,
 			Password: "password",
 		}
-		token := tests.GetLoginToken(t, router, *authController, loginData)
+		token := tests.GetLoginToken(t, *authController, loginData)
 
 		req, _ := http.NewRequest(http.MethodDelete, fmt.Sprintf("/api/v1/faq/%s", currUUID), nil)
 		req.Header.Set("Content-Type", "application/json")
@@ -118,7 +118,7 @@ func TestDeleteFaq(t *testing.T) {
 			Email:    regularUser.Email,
 			Password: "password",
 		}
-		token := tests.GetLoginToken(t, router, *authController, loginData)
+		token := tests.GetLoginToken(t, *authController, loginData)
 
 		req, _ := http.NewRequest(http.MethodDelete, fmt.Sprintf("/api/v1/faq/%s", faq.ID), nil)
 		req.Header.Set("Content-Type", "application/json")
@@ -59,7 +59,7 @@ func TestUpdateFaq(t *testing.T) {
 			Email:    adminUser.Email,
 			Password: "password",
 		}
-		token := tests.GetLoginToken(t, router, *authController, loginData)
+		token := tests.GetLoginToken(t, *authController, loginData)
 
 		updateFaq := models.UpdateFAQ{
 			Question: fmt.Sprintf("Update of this %s FAQ?", utility.RandomString(8)),
@@ -87,7 +87,7 @@ func TestUpdateFaq(t *testing.T) {
 			Email:    adminUser.Email,
 			Password: "password",
 		}
-		token := tests.GetLoginToken(t, router, *authController, loginData)
+		token := tests.GetLoginToken(t, *authController, loginData)
 
 		updateFaq := models.UpdateFAQ{
 			Question: "",
@@ -114,7 +114,7 @@ func TestUpdateFaq(t *testing.T) {
 			Email:    adminUser.Email,
 			Password: "password",
 		}
-		token := tests.GetLoginToken(t, router, *authController, loginData)
+		token := tests.GetLoginToken(t, *authController, loginData)
 
 		updateFaq := models.UpdateFAQ{
 			Question: fmt.Sprintf("the purpose of this %s FAQ?", utility.RandomString(10)),
@@ -46,7 +46,7 @@ func TestHelpCenterCreate(t *testing.T) {
 	r := gin.Default()
 	tst.SignupUser(t, r, auth, userSignUpData, false)
 
-	token := tst.GetLoginToken(t, r, auth, loginData)
+	token := tst.GetLoginToken(t, auth, loginData)
 
 	tests := []struct {
 		Name         string
@@ -209,7 +209,7 @@ func TestFetchHelpCenterPostById(t *testing.T) {
 	r := gin.Default()
 	tst.SignupUser(t, r, authController, userSignUpData, false)
 
-	token := tst.GetLoginToken(t, r, authController, loginData)
+	token := tst.GetLoginToken(t, authController, loginData)
 
 	helpCenterData := models.CreateHelpCenter{
 		Title:   "How to reset password",
@@ -306,7 +306,7 @@ func TestUpdateHelpCenterPost(t *testing.T) {
 	r := gin.Default()
 	tst.SignupUser(t, r, authController, userSignUpData, false)
 
-	token := tst.GetLoginToken(t, r, authController, loginData)
+	token := tst.GetLoginToken(t, authController, loginData)
 
 	helpCenterData := models.CreateHelpCenter{
 		Title:   "How to reset password",
@@ -417,7 +417,7 @@ func TestDeleteHelpCenterPost(t *testing.T) {
 	r := gin.Default()
 	tst.SignupUser(t, r, authController, userSignUpData, false)
 
-	token := tst.GetLoginToken(t, r, authController, loginData)
+	token := tst.GetLoginToken(t, authController, loginData)
 
 	helpCenterData := models.CreateHelpCenter{
 		Title:   "How to reset password",
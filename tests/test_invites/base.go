@@ -58,7 +58,7 @@ func InviteSetup(t *testing.T, admin bool) InviteSetupResp {
 	auth := auth.Controller{Db: db, Validator: validatorRef, Logger: logger}
 	r := gin.Default()
 	tst.SignupUser(t, r, auth, userSignUpData, admin)
-	token := tst.GetLoginToken(t, r, auth, loginData)
+	token := tst.GetLoginToken(t, auth, loginData)
 
 	//create an organisation
 	orgReq := models.CreateOrgRequestModel{
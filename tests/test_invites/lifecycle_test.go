@@ -0,0 +1,148 @@
+package test_invites
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+
+	"github.com/hngprojects/hng_boilerplate_golang_web/internal/models"
+	"github.com/hngprojects/hng_boilerplate_golang_web/pkg/controller/auth"
+	"github.com/hngprojects/hng_boilerplate_golang_web/pkg/middleware"
+	tst "github.com/hngprojects/hng_boilerplate_golang_web/tests"
+	"github.com/hngprojects/hng_boilerplate_golang_web/utility"
+)
+
+func TestInvitationLifecycle(t *testing.T) {
+	setup := InviteSetup(t, false)
+	db := setup.DB.Postgresql
+	currUUID := utility.GenerateUUID()
+
+	authController := auth.Controller{Db: setup.DB, Validator: validator.New(), Logger: tst.Setup()}
+
+	_, outsiderToken := tst.CreateLoggedInUser(t, authController, models.User{Name: "outsider"})
+
+	r := gin.Default()
+	inviteUrl := r.Group("/api/v1", middleware.Authorize(db))
+	{
+		inviteUrl.GET("/organizations/:org_id/invitations", middleware.RequireOrgPermission(db, models.PermInviteMembers), setup.InviteController.GetOrgInvitations)
+		inviteUrl.DELETE("/organizations/:org_id/invitations/:invitation_id", middleware.RequireOrgPermission(db, models.PermInviteMembers), setup.InviteController.RevokeInvitation)
+		inviteUrl.POST("/organizations/:org_id/invitations/:invitation_id/resend", middleware.RequireOrgPermission(db, models.PermInviteMembers), setup.InviteController.ResendInvitation)
+	}
+	r.POST("/api/v1/invite/decline", setup.InviteController.DeclineInvite)
+
+	listUrl := fmt.Sprintf("/api/v1/organizations/%s/invitations", setup.OrgID)
+	count := func(status string) int {
+		rr := tst.PerformRequest(r, http.MethodGet, listUrl+"?status="+status, setup.Token, nil)
+		tst.AssertStatusCode(t, rr.Code, http.StatusOK)
+		return len(tst.ParseResponse(rr)["data"].(map[string]interface{})["invitations"].([]interface{}))
+	}
+
+	db.Model(&models.Organisation{}).Where("id = ?", setup.OrgID).Update("invite_expiry_hours", 48)
+
+	invitation := models.Invitation{
+		ID:             utility.GenerateUUID(),
+		OrganisationID: setup.OrgID,
+		Token:          utility.GenerateUUID(),
+		Email:          fmt.Sprintf("invitee%v@qa.team", currUUID),
+	}
+
+	t.Run("Expiry Follows Organisation Setting", func(t *testing.T) {
+		if err := invitation.CreateInvitation(db); err != nil {
+			t.Fatalf("creating invitation failed: %v", err)
+		}
+
+		if ttl := time.Until(invitation.ExpiresAt); ttl < 47*time.Hour || ttl > 48*time.Hour {
+			t.Errorf("expected the invitation to expire in 48 hours, expires in %v", ttl)
+		}
+	})
+
+	t.Run("List By Status", func(t *testing.T) {
+		if n := count(models.InvitationStatusPending); n != 1 {
+			t.Errorf("expected 1 pending invitation, got %d", n)
+		}
+
+		rr := tst.PerformRequest(r, http.MethodGet, listUrl+"?status=unknown", setup.Token, nil)
+		tst.AssertStatusCode(t, rr.Code, http.StatusBadRequest)
+
+		rr = tst.PerformRequest(r, http.MethodGet, listUrl, outsiderToken, nil)
+		tst.AssertStatusCode(t, rr.Code, http.StatusForbidden)
+	})
+
+	t.Run("Revoke", func(t *testing.T) {
+		rr := tst.PerformRequest(r, http.MethodDelete, listUrl+"/"+invitation.ID, setup.Token, nil)
+		tst.AssertStatusCode(t, rr.Code, http.StatusOK)
+
+		rr = tst.PerformRequest(r, http.MethodDelete, listUrl+"/"+invitation.ID, setup.Token, nil)
+		tst.AssertStatusCode(t, rr.Code, http.StatusBadRequest)
+
+		if n := count(models.InvitationStatusRevoked); n != 1 {
+			t.Errorf("expected 1 revoked invitation, got %d", n)
+		}
+
+		rr = tst.PerformRequest(r, http.MethodPost, "/api/v1/invite/decline", "", models.InvitationDeclineReq{InvitationLink: invitation.Token})
+		tst.AssertStatusCode(t, rr.Code, http.StatusBadRequest)
+	})
+
+	t.Run("Resend Issues A Fresh Token", func(t *testing.T) {
+		rr := tst.PerformRequest(r, http.MethodPost, listUrl+"/"+invitation.ID+"/resend", setup.Token, nil)
+		tst.AssertStatusCode(t, rr.Code, http.StatusOK)
+
+		resent, _ := invitation.GetOrgInvitationByID(db, setup.OrgID, invitation.ID)
+		if resent.Token == invitation.Token || resent.CurrentStatus() != models.InvitationStatusPending {
+			t.Fatalf("expected a fresh pending invitation, got status %v", resent.CurrentStatus())
+		}
+		invitation = resent
+	})
+
+	t.Run("Invitee Declines", func(t *testing.T) {
+		rr := tst.PerformRequest(r, http.MethodPost, "/api/v1/invite/decline", "", models.InvitationDeclineReq{InvitationLink: "http://example.com/invite/accept/" + invitation.Token})
+		tst.AssertStatusCode(t, rr.Code, http.StatusOK)
+
+		rr = tst.PerformRequest(r, http.MethodPost, "/api/v1/invite/decline", "", models.InvitationDeclineReq{InvitationLink: "http://example.com/invite/accept/" + invitation.Token})
+		tst.AssertStatusCode(t, rr.Code, http.StatusBadRequest)
+
+		if n := count(models.InvitationStatusDeclined); n != 1 {
+			t.Errorf("expected 1 declined invitation, got %d", n)
+		}
+	})
+
+	t.Run("Invitee Joins On Sign Up", func(t *testing.T) {
+		signup := models.Invitation{
+			ID:             utility.GenerateUUID(),
+			OrganisationID: setup.OrgID,
+			Token:          utility.GenerateUUID(),
+			Email:          fmt.Sprintf("newinvitee%v@qa.team", currUUID),
+		}
+		signup.CreateInvitation(db)
+
+		tst.SignupUser(t, gin.Default(), authController, models.CreateUserRequestModel{
+			Email:       signup.Email,
+			PhoneNumber: fmt.Sprintf("+234%v", utility.GetRandomNumbersInRange(7000000000, 9099999999)),
+			FirstName:   "new",
+			LastName:    "invitee",
+			Password:    "password",
+			UserName:    fmt.Sprintf("newinvitee%v", currUUID),
+			InviteToken: signup.Token,
+		}, false)
+
+		var user models.User
+		user, err := user.GetUserByEmail(db, signup.Email)
+		if err != nil {
+			t.Fatalf("expected the invitee to be registered: %v", err)
+		}
+
+		var org models.Organisation
+		isMember, _ := org.CheckUserIsMemberOfOrg(user.ID, setup.OrgID, db)
+		if !isMember {
+			t.Errorf("expected the invitee to join the organisation on sign up")
+		}
+
+		if n := count(models.InvitationStatusAccepted); n != 1 {
+			t.Errorf("expected 1 accepted invitation, got %d", n)
+		}
+	})
+}
@@ -47,7 +47,7 @@ func TestJobPostCreate(t *testing.T) {
 	r := gin.Default()
 	tst.SignupUser(t, r, auth, userSignUpData, false)
 
-	token := tst.GetLoginToken(t, r, auth, loginData)
+	token := tst.GetLoginToken(t, auth, loginData)
 
 	tests := []struct {
 		Name         string
@@ -248,7 +248,7 @@ func TestFetchJobPostById(t *testing.T) {
 	r := gin.Default()
 	tst.SignupUser(t, r, authController, userSignUpData, false)
 
-	token := tst.GetLoginToken(t, r, authController, loginData)
+	token := tst.GetLoginToken(t, authController, loginData)
 
 	jobPostData := models.CreateJobPostModel{
 		Title:               "Software Engineer Intern",
@@ -354,7 +354,7 @@ func TestUpdateJobPostById(t *testing.T) {
 	r := gin.Default()
 	tst.SignupUser(t, r, authController, userSignUpData, false)
 
-	token := tst.GetLoginToken(t, r, authController, loginData)
+	token := tst.GetLoginToken(t, authController, loginData)
 
 	jobPostData := models.CreateJobPostModel{
 		Title:               "Software Engineer Intern",
@@ -472,7 +472,7 @@ func TestDeleteJobPostById(t *testing.T) {
 	r := gin.Default()
 	tst.SignupUser(t, r, authController, userSignUpData, false)
 
-	token := tst.GetLoginToken(t, r, authController, loginData)
+	token := tst.GetLoginToken(t, authController, loginData)
 
 	jobPostData := models.CreateJobPostModel{
 		Title:               "Software Engineer Intern",
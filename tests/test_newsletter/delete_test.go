@@ -65,7 +65,7 @@ func TestDeleteNewsletter(t *testing.T) {
 			Email:    adminUser.Email,
 			Password: "password",
 		}
-		token := tests.GetLoginToken(t, router, *authController, loginData)
+		token := tests.GetLoginToken(t, *authController, loginData)
 
 		req, _ := http.NewRequest(http.MethodDelete, fmt.Sprintf("/api/v1/newsletter-subscription/%s", newsletter.ID), nil)
 		req.Header.Set("Content-Type", "application/json")
@@ -101,7 +101,7 @@ func TestDeleteNewsletter(t *testing.T) {
 			Email:    regularUser.Email,
 			Password: "password",
 		}
-		token := tests.GetLoginToken(t, router, *authController, loginData)
+		token := tests.GetLoginToken(t, *authController, loginData)
 
 		req, _ := http.NewRequest(http.MethodDelete, fmt.Sprintf("/api/v1/newsletter-subscription/%s", newsletter.ID), nil)
 		req.Header.Set("Content-Type", "application/json")
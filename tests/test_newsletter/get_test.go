@@ -67,7 +67,7 @@ func TestGetAllNewsletters(t *testing.T) {
 			Email:    adminUser.Email,
 			Password: "password",
 		}
-		token := tests.GetLoginToken(t, router, *authController, loginData)
+		token := tests.GetLoginToken(t, *authController, loginData)
 
 		req, _ := http.NewRequest(http.MethodGet, "/api/v1/newsletter-subscription", nil)
 		req.Header.Set("Content-Type", "application/json")
@@ -103,7 +103,7 @@ func TestGetAllNewsletters(t *testing.T) {
 			Email:    regularUser.Email,
 			Password: "password",
 		}
-		token := tests.GetLoginToken(t, router, *authController, loginData)
+		token := tests.GetLoginToken(t, *authController, loginData)
 
 		req, _ := http.NewRequest(http.MethodGet, "/api/v1/newsletter-subscription", nil)
 		req.Header.Set("Content-Type", "application/json")
@@ -63,7 +63,7 @@ func TestRestoreNewsLetter(t *testing.T) {
 			Email:    adminUser.Email,
 			Password: "password",
 		}
-		token := tests.GetLoginToken(t, router, *authController, loginData)
+		token := tests.GetLoginToken(t, *authController, loginData)
 
 		req, _ := http.NewRequest(http.MethodPatch, fmt.Sprintf("/api/v1/newsletter-subscription/restore/%s", newsletter.ID), nil)
 		req.Header.Set("Content-Type", "application/json")
@@ -99,7 +99,7 @@ func TestRestoreNewsLetter(t *testing.T) {
 			Email:    regularUser.Email,
 			Password: "password",
 		}
-		token := tests.GetLoginToken(t, router, *authController, loginData)
+		token := tests.GetLoginToken(t, *authController, loginData)
 
 		req, _ := http.NewRequest(http.MethodPatch, fmt.Sprintf("/api/v1/newsletter-subscription/restore/%s", newsletter.ID), nil)
 		req.Header.Set("Content-Type", "application/json")
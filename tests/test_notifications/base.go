@@ -56,7 +56,7 @@ func NotifSetup(t *testing.T, admin bool) NotificationSetupResp {
 	auth := auth.Controller{Db: db, Validator: validatorRef, Logger: logger}
 	r := gin.Default()
 	tst.SignupUser(t, r, auth, userSignUpData, admin)
-	token := tst.GetLoginToken(t, r, auth, loginData)
+	token := tst.GetLoginToken(t, auth, loginData)
 
 	//create an organisation
 	notReq := models.NotificationReq{
@@ -32,7 +32,7 @@ func initialise(currUUID string, t *testing.T, r *gin.Engine, db *storage.Databa
 
 	tst.SignupUser(t, r, user, userSignUpData, status)
 
-	token := tst.GetLoginToken(t, r, user, loginData)
+	token := tst.GetLoginToken(t, user, loginData)
 
 	organisationCreationData := models.CreateOrgRequestModel{
 		Name:        fmt.Sprintf("Org %v", currUUID),
@@ -72,10 +72,68 @@ func SetupOrgRoutes(r *gin.Engine, orgController *organisation.Controller) {
 	orgUrl := r.Group("/api/v1",
 		middleware.Authorize(orgController.Db.Postgresql, models.RoleIdentity.SuperAdmin, models.RoleIdentity.User))
 
-	orgUrl.POST("/organizations/:org_id/roles", orgController.CreateOrgRole)
-	orgUrl.GET("/organizations/:org_id/roles", orgController.GetOrgRoles)
-	orgUrl.GET("/organizations/:org_id/roles/:role_id", orgController.GetAOrgRole)
-	orgUrl.DELETE("/organizations/:org_id/roles/:role_id", orgController.DeleteOrgRole)
-	orgUrl.PATCH("/organizations/:org_id/roles/:role_id", orgController.UpdateOrgRole)
-	orgUrl.PATCH("/organizations/:org_id/roles/:role_id/permissions", orgController.UpdateOrgPermissions)
+	orgUrl.GET("/permissions", orgController.GetPermissionCatalog)
+	orgUrl.POST("/organizations/:org_id/roles",
+		middleware.RequireOrgPermission(orgController.Db.Postgresql, models.PermManageRoles), orgController.CreateOrgRole)
+	orgUrl.GET("/organizations/:org_id/roles",
+		middleware.RequireOrgPermission(orgController.Db.Postgresql, models.PermViewRoles), orgController.GetOrgRoles)
+	orgUrl.GET("/organizations/:org_id/roles/:role_id",
+		middleware.RequireOrgPermission(orgController.Db.Postgresql, models.PermViewRoles), orgController.GetAOrgRole)
+	orgUrl.DELETE("/organizations/:org_id/roles/:role_id",
+		middleware.RequireOrgPermission(orgController.Db.Postgresql, models.PermManageRoles), orgController.DeleteOrgRole)
+	orgUrl.PATCH("/organizations/:org_id/roles/:role_id",
+		middleware.RequireOrgPermission(orgController.Db.Postgresql, models.PermManageRoles), orgController.UpdateOrgRole)
+	orgUrl.PATCH("/organizations/:org_id/roles/:role_id/permissions",
+		middleware.RequireOrgPermission(orgController.Db.Postgresql, models.PermManageRoles), orgController.UpdateOrgPermissions)
+	orgUrl.PUT("/organizations/:org_id/roles/:role_id/default",
+		middleware.RequireOrgPermission(orgController.Db.Postgresql, models.PermManageRoles), orgController.SetDefaultOrgRole)
+	orgUrl.PUT("/organizations/:org_id/users/:user_id/role",
+		middleware.RequireOrgPermission(orgController.Db.Postgresql, models.PermManageMembers), orgController.AssignMemberRole)
+	orgUrl.DELETE("/organizations/:org_id/users/:user_id/role",
+		middleware.RequireOrgPermission(orgController.Db.Postgresql, models.PermManageMembers), orgController.RemoveMemberRole)
+	orgUrl.DELETE("/organizations/:org_id/users/:user_id", middleware.RequireSession(),
+		middleware.RequireOrgPermission(orgController.Db.Postgresql, models.PermManageMembers), orgController.RemoveMember)
+	orgUrl.POST("/organizations/:org_id/leave", middleware.RequireSession(), middleware.BlockImpersonation(), orgController.LeaveOrganisation)
+	orgUrl.POST("/organizations/:org_id/domains",
+		middleware.RequireOrgPermission(orgController.Db.Postgresql, models.PermEditOrganisation), orgController.ClaimOrgDomain)
+	orgUrl.GET("/organizations/:org_id/domains",
+		middleware.RequireOrgPermission(orgController.Db.Postgresql, models.PermEditOrganisation), orgController.GetOrgDomains)
+	orgUrl.POST("/organizations/:org_id/domains/:domain_id/verify",
+		middleware.RequireOrgPermission(orgController.Db.Postgresql, models.PermEditOrganisation), orgController.VerifyOrgDomain)
+	orgUrl.PATCH("/organizations/:org_id/domains/:domain_id",
+		middleware.RequireOrgPermission(orgController.Db.Postgresql, models.PermEditOrganisation), orgController.UpdateOrgDomain)
+	orgUrl.DELETE("/organizations/:org_id/domains/:domain_id",
+		middleware.RequireOrgPermission(orgController.Db.Postgresql, models.PermEditOrganisation), orgController.DeleteOrgDomain)
+	orgUrl.GET("/organizations/:org_id/join-requests",
+		middleware.RequireOrgPermission(orgController.Db.Postgresql, models.PermInviteMembers), orgController.GetJoinRequests)
+	orgUrl.POST("/organizations/:org_id/join-requests/:request_id/approve",
+		middleware.RequireOrgPermission(orgController.Db.Postgresql, models.PermInviteMembers), orgController.ApproveJoinRequest)
+	orgUrl.POST("/organizations/:org_id/join-requests/:request_id/reject",
+		middleware.RequireOrgPermission(orgController.Db.Postgresql, models.PermInviteMembers), orgController.RejectJoinRequest)
+	orgUrl.POST("/organizations/:org_id/teams",
+		middleware.RequireOrgPermission(orgController.Db.Postgresql, models.PermManageTeams), orgController.CreateTeam)
+	orgUrl.GET("/organizations/:org_id/teams", orgController.GetTeams)
+	orgUrl.GET("/organizations/:org_id/teams/:team_id", orgController.GetTeam)
+	orgUrl.PATCH("/organizations/:org_id/teams/:team_id",
+		middleware.RequireOrgPermission(orgController.Db.Postgresql, models.PermManageTeams), orgController.UpdateTeam)
+	orgUrl.DELETE("/organizations/:org_id/teams/:team_id",
+		middleware.RequireOrgPermission(orgController.Db.Postgresql, models.PermManageTeams), orgController.DeleteTeam)
+	orgUrl.PUT("/organizations/:org_id/teams/:team_id/role",
+		middleware.RequireOrgPermission(orgController.Db.Postgresql, models.PermManageMembers), orgController.SetTeamRole)
+	orgUrl.DELETE("/organizations/:org_id/teams/:team_id/role",
+		middleware.RequireOrgPermission(orgController.Db.Postgresql, models.PermManageMembers), orgController.RemoveTeamRole)
+	orgUrl.GET("/organizations/:org_id/teams/:team_id/members", orgController.GetTeamMembers)
+	orgUrl.POST("/organizations/:org_id/teams/:team_id/members", orgController.AddTeamMember)
+	orgUrl.PATCH("/organizations/:org_id/teams/:team_id/members/:user_id",
+		middleware.RequireOrgPermission(orgController.Db.Postgresql, models.PermManageTeams), orgController.UpdateTeamMember)
+	orgUrl.DELETE("/organizations/:org_id/teams/:team_id/members/:user_id", orgController.RemoveTeamMember)
+	orgUrl.GET("/organizations/:org_id/teams/:team_id/resources", orgController.GetTeamResources)
+	orgUrl.POST("/organizations/:org_id/teams/:team_id/resources", orgController.ShareTeamResource)
+	orgUrl.DELETE("/organizations/:org_id/teams/:team_id/resources/:share_id", orgController.UnshareTeamResource)
+	orgUrl.POST("/organizations/:org_id/transfer", middleware.RequireSession(), middleware.BlockImpersonation(), orgController.RequestOwnershipTransfer)
+	orgUrl.DELETE("/organizations/:org_id/transfer", middleware.RequireSession(), middleware.BlockImpersonation(), orgController.CancelOwnershipTransfer)
+	orgUrl.POST("/organizations/transfer/accept", middleware.RequireSession(), middleware.BlockImpersonation(), orgController.AcceptOwnershipTransfer)
+
+	superAdminUrl := r.Group("/api/v1", middleware.Authorize(orgController.Db.Postgresql, models.RoleIdentity.SuperAdmin))
+	superAdminUrl.POST("/organizations/:org_id/transfer/force", middleware.RequireSession(), orgController.ForceOwnershipTransfer)
 }
@@ -68,7 +68,7 @@ func TestCreateOrgRole(t *testing.T) {
 			Email:    adminUser.Email,
 			Password: "password",
 		}
-		token := tests.GetLoginToken(t, router, *orgController, loginData)
+		token := tests.GetLoginToken(t, *orgController, loginData)
 
 		role := models.OrgRole{
 			Name:        fmt.Sprintf("Admin Role-%v", utility.RandomString(5)),
@@ -116,7 +116,7 @@ func TestCreateOrgRole(t *testing.T) {
 			Email:    regularUser.Email,
 			Password: "password",
 		}
-		token := tests.GetLoginToken(t, router, *orgController, loginData)
+		token := tests.GetLoginToken(t, *orgController, loginData)
 
 		role := models.OrgRole{
 			Name:           fmt.Sprintf("Admin Role-%v", utility.RandomString(5)),
@@ -134,7 +134,7 @@ func TestCreateOrgRole(t *testing.T) {
 
 		tests.AssertStatusCode(t, resp.Code, http.StatusForbidden)
 		response := tests.ParseResponse(resp)
-		tests.AssertResponseMessage(t, response["message"].(string), "not organization owner")
+		tests.AssertResponseMessage(t, response["message"].(string), "user is not a member of this organisation")
 	})
 
 	t.Run("Bad Request - Missing Fields", func(t *testing.T) {
@@ -144,7 +144,7 @@ func TestCreateOrgRole(t *testing.T) {
 			Email:    adminUser.Email,
 			Password: "password",
 		}
-		token := tests.GetLoginToken(t, router, *orgController, loginData)
+		token := tests.GetLoginToken(t, *orgController, loginData)
 
 		role := models.OrgRole{
 			Description:    "Missing Name",
@@ -77,7 +77,7 @@ func TestDeleteOrgRole(t *testing.T) {
 			Email:    adminUser.Email,
 			Password: "password",
 		}
-		token := tests.GetLoginToken(t, router, *orgController, loginData)
+		token := tests.GetLoginToken(t, *orgController, loginData)
 
 		req, _ := http.NewRequest(http.MethodDelete, fmt.Sprintf("/api/v1/organizations/%s/roles/%s", orgID, roleID), nil)
 		req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", token))
@@ -111,7 +111,7 @@ func TestDeleteOrgRole(t *testing.T) {
 			Email:    regularUser.Email,
 			Password: "password",
 		}
-		token := tests.GetLoginToken(t, router, *orgController, loginData)
+		token := tests.GetLoginToken(t, *orgController, loginData)
 
 		req, _ := http.NewRequest(http.MethodDelete, fmt.Sprintf("/api/v1/organizations/%s/roles/%s", orgID, roleID), nil)
 		req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", token))
@@ -121,7 +121,7 @@ func TestDeleteOrgRole(t *testing.T) {
 
 		tests.AssertStatusCode(t, resp.Code, http.StatusForbidden)
 		response := tests.ParseResponse(resp)
-		tests.AssertResponseMessage(t, response["message"].(string), "not organization owner")
+		tests.AssertResponseMessage(t, response["message"].(string), "user is not a member of this organisation")
 	})
 
 	t.Run("Bad Request - Non-Existent Role", func(t *testing.T) {
@@ -131,7 +131,7 @@ func TestDeleteOrgRole(t *testing.T) {
 			Email:    adminUser.Email,
 			Password: "password",
 		}
-		token := tests.GetLoginToken(t, router, *orgController, loginData)
+		token := tests.GetLoginToken(t, *orgController, loginData)
 
 		nonExistentRoleID := utility.GenerateUUID()
 
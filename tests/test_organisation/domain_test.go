@@ -0,0 +1,151 @@
+package test_organisation
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/hngprojects/hng_boilerplate_golang_web/external/thirdparty/dns"
+	"github.com/hngprojects/hng_boilerplate_golang_web/internal/models"
+	"github.com/hngprojects/hng_boilerplate_golang_web/pkg/controller/auth"
+	authService "github.com/hngprojects/hng_boilerplate_golang_web/services/auth"
+	service "github.com/hngprojects/hng_boilerplate_golang_web/services/organisation"
+	"github.com/hngprojects/hng_boilerplate_golang_web/tests"
+	"github.com/hngprojects/hng_boilerplate_golang_web/utility"
+)
+
+type stubResolver map[string][]string
+
+func (s stubResolver) LookupTXT(ctx context.Context, name string) ([]string, error) {
+	return s[name], nil
+}
+
+func TestOrgDomains(t *testing.T) {
+	router, orgController := SetupOrgTestRouter()
+	db := orgController.Db.Postgresql
+	currUUID := utility.GenerateUUID()
+	domain := fmt.Sprintf("d%v.example.com", utility.GenerateUUID()[:8])
+
+	resolver := stubResolver{}
+	previous := dns.Resolver
+	dns.Resolver = resolver
+	defer func() { dns.Resolver = previous }()
+
+	authController := auth.Controller{
+		Db:        orgController.Db,
+		Validator: orgController.Validator,
+		Logger:    orgController.Logger,
+	}
+
+	owner, ownerToken := tests.CreateLoggedInUser(t, authController, models.User{Name: "owner", IsVerified: true})
+
+	org, err := service.CreateOrganisation(models.CreateOrgRequestModel{
+		Name:    fmt.Sprintf("Org domain%v", currUUID),
+		Email:   fmt.Sprintf("domainorg%v@qa.team", currUUID),
+		State:   "test",
+		Type:    "type1",
+		Address: "wakanda land",
+		Country: "wakanda",
+	}, db, owner.ID)
+	if err != nil {
+		t.Fatalf("creating organisation failed: %v", err)
+	}
+
+	// verifiedUser signs a user up on the domain and verifies their email the
+	// way the verification endpoint does.
+	verifiedUser := func(name string) models.User {
+		user := tests.CreateTestUser(t, db, models.User{Name: name, Email: fmt.Sprintf("%v@%v", name, domain)})
+
+		if err := authService.SendEmailVerification(user, db); err != nil {
+			t.Fatalf("sending verification failed: %v", err)
+		}
+		var verification models.EmailVerification
+		db.Where("email = ?", user.Email).First(&verification)
+
+		_, code, err := authService.VerifyEmail(models.VerifyEmailRequestModel{Email: user.Email, Code: verification.Code}, db)
+		if err != nil {
+			t.Fatalf("verifying email failed with %d: %v", code, err)
+		}
+		return user
+	}
+
+	isMember := func(userID string) bool {
+		var membership models.UserOrganisation
+		_, err := membership.GetMembership(db, org.ID, userID)
+		return err == nil
+	}
+
+	domainsUrl := fmt.Sprintf("/api/v1/organizations/%s/domains", org.ID)
+	var domainID, txtRecord string
+
+	t.Run("Claim Domain", func(t *testing.T) {
+		resp := tests.PerformRequest(router, http.MethodPost, domainsUrl, ownerToken, models.ClaimOrgDomainRequestModel{Domain: domain})
+		tests.AssertStatusCode(t, resp.Code, http.StatusCreated)
+
+		data := tests.ParseResponse(resp)["data"].(map[string]interface{})["domain"].(map[string]interface{})
+		domainID = data["id"].(string)
+		txtRecord = data["txt_record"].(string)
+
+		resp = tests.PerformRequest(router, http.MethodPost, domainsUrl, ownerToken, models.ClaimOrgDomainRequestModel{Domain: domain})
+		tests.AssertStatusCode(t, resp.Code, http.StatusConflict)
+	})
+
+	t.Run("Unverified Domain Does Not Join", func(t *testing.T) {
+		user := verifiedUser("early")
+		if isMember(user.ID) {
+			t.Errorf("expected no membership before the domain is verified")
+		}
+	})
+
+	t.Run("Verify Without Record", func(t *testing.T) {
+		resp := tests.PerformRequest(router, http.MethodPost, domainsUrl+"/"+domainID+"/verify", ownerToken, nil)
+		tests.AssertStatusCode(t, resp.Code, http.StatusBadRequest)
+	})
+
+	t.Run("Verify With Record", func(t *testing.T) {
+		resolver[domain] = []string{"v=spf1 -all", txtRecord}
+
+		resp := tests.PerformRequest(router, http.MethodPost, domainsUrl+"/"+domainID+"/verify", ownerToken, nil)
+		tests.AssertStatusCode(t, resp.Code, http.StatusOK)
+	})
+
+	t.Run("Verified User Joins Automatically", func(t *testing.T) {
+		user := verifiedUser("auto")
+		if !isMember(user.ID) {
+			t.Fatalf("expected the user to join the organisation")
+		}
+
+		var membership models.UserOrganisation
+		membership, _ = membership.GetMembership(db, org.ID, user.ID)
+		if membership.OrgRoleID == nil {
+			t.Errorf("expected the default role to be granted")
+		}
+	})
+
+	t.Run("Request Policy Needs Approval", func(t *testing.T) {
+		resp := tests.PerformRequest(router, http.MethodPatch, domainsUrl+"/"+domainID, ownerToken, models.UpdateOrgDomainRequestModel{JoinPolicy: models.DomainJoinRequest})
+		tests.AssertStatusCode(t, resp.Code, http.StatusOK)
+
+		user := verifiedUser("asking")
+		if isMember(user.ID) {
+			t.Fatalf("expected the user to wait for approval")
+		}
+
+		var joinRequest models.OrgJoinRequest
+		if err := db.Where("organisation_id = ? AND user_id = ?", org.ID, user.ID).First(&joinRequest).Error; err != nil {
+			t.Fatalf("expected a join request: %v", err)
+		}
+
+		joinUrl := fmt.Sprintf("/api/v1/organizations/%s/join-requests/%s", org.ID, joinRequest.ID)
+		resp = tests.PerformRequest(router, http.MethodPost, joinUrl+"/approve", ownerToken, nil)
+		tests.AssertStatusCode(t, resp.Code, http.StatusOK)
+
+		if !isMember(user.ID) {
+			t.Errorf("expected the approved user to join the organisation")
+		}
+
+		resp = tests.PerformRequest(router, http.MethodPost, joinUrl+"/reject", ownerToken, nil)
+		tests.AssertStatusCode(t, resp.Code, http.StatusBadRequest)
+	})
+}
@@ -85,7 +85,7 @@ func TestGetOrgRoles(t *testing.T) {
 			Email:    adminUser.Email,
 			Password: "password",
 		}
-		token := tests.GetLoginToken(t, router, *orgController, loginData)
+		token := tests.GetLoginToken(t, *orgController, loginData)
 
 		req, _ := http.NewRequest(http.MethodGet, fmt.Sprintf("/api/v1/organizations/%s/roles", orgID), nil)
 		req.Header.Set("Content-Type", "application/json")
@@ -121,7 +121,7 @@ func TestGetOrgRoles(t *testing.T) {
 			Email:    regularUser.Email,
 			Password: "password",
 		}
-		token := tests.GetLoginToken(t, router, *orgController, loginData)
+		token := tests.GetLoginToken(t, *orgController, loginData)
 
 		req, _ := http.NewRequest(http.MethodGet, fmt.Sprintf("/api/v1/organizations/%s/roles", orgID), nil)
 		req.Header.Set("Content-Type", "application/json")
@@ -132,7 +132,7 @@ func TestGetOrgRoles(t *testing.T) {
 
 		tests.AssertStatusCode(t, resp.Code, http.StatusForbidden)
 		response := tests.ParseResponse(resp)
-		tests.AssertResponseMessage(t, response["message"].(string), "not organization owner")
+		tests.AssertResponseMessage(t, response["message"].(string), "user is not a member of this organisation")
 	})
 }
 
@@ -200,7 +200,7 @@ func TestGetAOrgRole(t *testing.T) {
 			Email:    adminUser.Email,
 			Password: "password",
 		}
-		token := tests.GetLoginToken(t, router, *orgController, loginData)
+		token := tests.GetLoginToken(t, *orgController, loginData)
 
 		req, _ := http.NewRequest(http.MethodGet, fmt.Sprintf("/api/v1/organizations/%s/roles/%s", orgID, roleID), nil)
 		req.Header.Set("Content-Type", "application/json")
@@ -236,7 +236,7 @@ func TestGetAOrgRole(t *testing.T) {
 			Email:    regularUser.Email,
 			Password: "password",
 		}
-		token := tests.GetLoginToken(t, router, *orgController, loginData)
+		token := tests.GetLoginToken(t, *orgController, loginData)
 
 		req, _ := http.NewRequest(http.MethodGet, fmt.Sprintf("/api/v1/organizations/%s/roles/%s", orgID, roleID), nil)
 		req.Header.Set("Content-Type", "application/json")
@@ -247,6 +247,6 @@ func TestGetAOrgRole(t *testing.T) {
 
 		tests.AssertStatusCode(t, resp.Code, http.StatusForbidden)
 		response := tests.ParseResponse(resp)
-		tests.AssertResponseMessage(t, response["message"].(string), "not organization owner")
+		tests.AssertResponseMessage(t, response["message"].(string), "user is not a member of this organisation")
 	})
 }
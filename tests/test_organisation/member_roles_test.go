@@ -0,0 +1,268 @@
+package test_organisation
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/hngprojects/hng_boilerplate_golang_web/internal/models"
+	"github.com/hngprojects/hng_boilerplate_golang_web/pkg/controller/auth"
+	"github.com/hngprojects/hng_boilerplate_golang_web/tests"
+	"github.com/hngprojects/hng_boilerplate_golang_web/utility"
+)
+
+func TestMemberRoles(t *testing.T) {
+	router, orgController := SetupOrgTestRouter()
+	db := orgController.Db.Postgresql
+	currUUID := utility.GenerateUUID()
+
+	authController := auth.Controller{
+		Db:        orgController.Db,
+		Validator: orgController.Validator,
+		Logger:    orgController.Logger,
+	}
+
+	owner := tests.CreateTestUser(t, db, models.User{Name: "owner"})
+	member := tests.CreateTestUser(t, db, models.User{Name: "member"})
+	org := models.Organisation{
+		ID:      utility.GenerateUUID(),
+		Name:    fmt.Sprintf("Org memberroles%v", currUUID),
+		Email:   fmt.Sprintf("memberrolesorg%v@qa.team", currUUID),
+		OwnerID: owner.ID,
+	}
+	db.Create(&org)
+	member.AddUserToOrganisation(db, &member, []interface{}{&org})
+
+	role := models.OrgRole{
+		ID:             utility.GenerateUUID(),
+		Name:           fmt.Sprintf("Mgr-%v", utility.RandomString(5)),
+		Description:    "Manages roles",
+		OrganisationID: org.ID,
+	}
+	db.Create(&role)
+	db.Create(&models.Permission{
+		ID:             utility.GenerateUUID(),
+		RoleID:         role.ID,
+		Category:       "Roles",
+		PermissionList: models.PermissionList{models.PermManageRoles: true},
+	})
+
+	ownerToken := tests.GetLoginToken(t, authController, models.LoginRequestModel{Email: owner.Email, Password: "password"})
+	memberToken := tests.GetLoginToken(t, authController, models.LoginRequestModel{Email: member.Email, Password: "password"})
+
+	rolesUrl := fmt.Sprintf("/api/v1/organizations/%s/roles", org.ID)
+	memberRoleUrl := fmt.Sprintf("/api/v1/organizations/%s/users/%s/role", org.ID, member.ID)
+	newRole := func() models.OrgRole {
+		return models.OrgRole{Name: fmt.Sprintf("Role-%v", utility.RandomString(5)), Description: "New role description"}
+	}
+
+	t.Run("Assigned Role Grants Permission", func(t *testing.T) {
+		resp := tests.PerformRequest(router, http.MethodPost, rolesUrl, memberToken, newRole())
+		tests.AssertStatusCode(t, resp.Code, http.StatusForbidden)
+
+		resp = tests.PerformRequest(router, http.MethodPut, memberRoleUrl, memberToken, models.AssignOrgRoleRequestModel{RoleID: role.ID})
+		tests.AssertStatusCode(t, resp.Code, http.StatusForbidden)
+
+		resp = tests.PerformRequest(router, http.MethodPut, memberRoleUrl, ownerToken, models.AssignOrgRoleRequestModel{RoleID: role.ID})
+		tests.AssertStatusCode(t, resp.Code, http.StatusOK)
+
+		resp = tests.PerformRequest(router, http.MethodPost, rolesUrl, memberToken, newRole())
+		tests.AssertStatusCode(t, resp.Code, http.StatusCreated)
+	})
+
+	t.Run("Assigned Role Cannot Be Deleted", func(t *testing.T) {
+		resp := tests.PerformRequest(router, http.MethodDelete, fmt.Sprintf("%s/%s", rolesUrl, role.ID), ownerToken, nil)
+		tests.AssertStatusCode(t, resp.Code, http.StatusConflict)
+	})
+
+	t.Run("Unknown Role", func(t *testing.T) {
+		resp := tests.PerformRequest(router, http.MethodPut, memberRoleUrl, ownerToken, models.AssignOrgRoleRequestModel{RoleID: utility.GenerateUUID()})
+		tests.AssertStatusCode(t, resp.Code, http.StatusNotFound)
+	})
+
+	t.Run("Remove Role", func(t *testing.T) {
+		resp := tests.PerformRequest(router, http.MethodDelete, memberRoleUrl, ownerToken, nil)
+		tests.AssertStatusCode(t, resp.Code, http.StatusOK)
+
+		resp = tests.PerformRequest(router, http.MethodPost, rolesUrl, memberToken, newRole())
+		tests.AssertStatusCode(t, resp.Code, http.StatusForbidden)
+	})
+
+	t.Run("Default Role On Join", func(t *testing.T) {
+		resp := tests.PerformRequest(router, http.MethodPut, fmt.Sprintf("%s/%s/default", rolesUrl, role.ID), ownerToken, nil)
+		tests.AssertStatusCode(t, resp.Code, http.StatusOK)
+
+		joiner := tests.CreateTestUser(t, db, models.User{Name: "joiner"})
+		joiner.AddUserToOrganisation(db, &joiner, []interface{}{&org})
+
+		var stored models.Organisation
+		stored, _ = stored.GetOrgByID(db, org.ID)
+		if err := stored.GrantDefaultRole(db, joiner.ID); err != nil {
+			t.Fatalf("granting default role failed: %v", err)
+		}
+
+		var membership models.UserOrganisation
+		membership, _ = membership.GetMembership(db, org.ID, joiner.ID)
+		if membership.OrgRoleID == nil || *membership.OrgRoleID != role.ID {
+			t.Errorf("expected the default role %v, got %v", role.ID, membership.OrgRoleID)
+		}
+	})
+}
+
+func TestRoleEscalation(t *testing.T) {
+	router, orgController := SetupOrgTestRouter()
+	db := orgController.Db.Postgresql
+	currUUID := utility.GenerateUUID()
+
+	authController := auth.Controller{
+		Db:        orgController.Db,
+		Validator: orgController.Validator,
+		Logger:    orgController.Logger,
+	}
+
+	owner, ownerToken := tests.CreateLoggedInUser(t, authController, models.User{Name: "owner"})
+	manager, managerToken := tests.CreateLoggedInUser(t, authController, models.User{Name: "manager"})
+	member := tests.CreateTestUser(t, db, models.User{Name: "member"})
+	senior := tests.CreateTestUser(t, db, models.User{Name: "senior"})
+	org := models.Organisation{
+		ID:      utility.GenerateUUID(),
+		Name:    fmt.Sprintf("Org escalation%v", currUUID),
+		Email:   fmt.Sprintf("escalationorg%v@qa.team", currUUID),
+		OwnerID: owner.ID,
+	}
+	db.Create(&org)
+	manager.AddUserToOrganisation(db, &manager, []interface{}{&org})
+	member.AddUserToOrganisation(db, &member, []interface{}{&org})
+	senior.AddUserToOrganisation(db, &senior, []interface{}{&org})
+
+	createRole := func(permissions models.PermissionList) models.OrgRole {
+		role := models.OrgRole{
+			ID:             utility.GenerateUUID(),
+			Name:           fmt.Sprintf("Role-%v", utility.RandomString(5)),
+			Description:    "Escalation role",
+			OrganisationID: org.ID,
+		}
+		db.Create(&role)
+		db.Create(&models.Permission{ID: utility.GenerateUUID(), RoleID: role.ID, Category: "Test", PermissionList: permissions})
+		return role
+	}
+
+	managerRole := createRole(models.PermissionList{models.PermManageRoles: true, models.PermManageMembers: true})
+	viewerRole := createRole(models.PermissionList{models.PermManageRoles: true})
+	adminRole := createRole(models.PermissionList{models.PermManageRoles: true, models.PermDeleteOrganisation: true})
+
+	var membership models.UserOrganisation
+	membership, _ = membership.GetMembership(db, org.ID, manager.ID)
+	membership.AssignRole(db, &managerRole.ID)
+	membership, _ = membership.GetMembership(db, org.ID, senior.ID)
+	membership.AssignRole(db, &adminRole.ID)
+
+	rolesUrl := fmt.Sprintf("/api/v1/organizations/%s/roles", org.ID)
+	newRole := func(permissions models.PermissionList) models.OrgRole {
+		return models.OrgRole{
+			Name:        fmt.Sprintf("Role-%v", utility.RandomString(5)),
+			Description: "New role description",
+			Permissions: models.Permission{PermissionList: permissions},
+		}
+	}
+
+	t.Run("Create Role With Permission Not Held", func(t *testing.T) {
+		resp := tests.PerformRequest(router, http.MethodPost, rolesUrl, managerToken, newRole(models.PermissionList{models.PermDeleteOrganisation: true}))
+		tests.AssertStatusCode(t, resp.Code, http.StatusForbidden)
+
+		resp = tests.PerformRequest(router, http.MethodPost, rolesUrl, managerToken, newRole(models.PermissionList{models.PermManageMembers: true}))
+		tests.AssertStatusCode(t, resp.Code, http.StatusCreated)
+
+		resp = tests.PerformRequest(router, http.MethodPost, rolesUrl, ownerToken, newRole(models.PermissionList{models.PermDeleteOrganisation: true}))
+		tests.AssertStatusCode(t, resp.Code, http.StatusCreated)
+	})
+
+	t.Run("Edit Role With Permission Not Held", func(t *testing.T) {
+		permissionsUrl := fmt.Sprintf("%s/%s/permissions", rolesUrl, managerRole.ID)
+
+		resp := tests.PerformRequest(router, http.MethodPatch, permissionsUrl, managerToken, models.Permission{
+			Category:       "Test",
+			PermissionList: models.PermissionList{models.PermManageRoles: true, models.PermManageMembers: true, models.PermDeleteOrganisation: true},
+		})
+		tests.AssertStatusCode(t, resp.Code, http.StatusForbidden)
+
+		var stored models.OrgRole
+		stored, _ = stored.GetAOrgRole(db, org.ID, managerRole.ID)
+		if stored.HasPermission(models.PermDeleteOrganisation) {
+			t.Errorf("expected the role to be left without %v", models.PermDeleteOrganisation)
+		}
+	})
+
+	t.Run("Edit Role Holding Permission Not Held", func(t *testing.T) {
+		permissionsUrl := fmt.Sprintf("%s/%s/permissions", rolesUrl, adminRole.ID)
+
+		resp := tests.PerformRequest(router, http.MethodPatch, permissionsUrl, managerToken, models.Permission{
+			Category:       "Test",
+			PermissionList: models.PermissionList{models.PermManageRoles: true},
+		})
+		tests.AssertStatusCode(t, resp.Code, http.StatusForbidden)
+
+		var stored models.OrgRole
+		stored, _ = stored.GetAOrgRole(db, org.ID, adminRole.ID)
+		if !stored.HasPermission(models.PermDeleteOrganisation) {
+			t.Errorf("expected the role to keep %v", models.PermDeleteOrganisation)
+		}
+	})
+
+	t.Run("Assign Role With Permission Not Held", func(t *testing.T) {
+		memberRoleUrl := fmt.Sprintf("/api/v1/organizations/%s/users/%s/role", org.ID, member.ID)
+
+		resp := tests.PerformRequest(router, http.MethodPut, memberRoleUrl, managerToken, models.AssignOrgRoleRequestModel{RoleID: adminRole.ID})
+		tests.AssertStatusCode(t, resp.Code, http.StatusForbidden)
+
+		resp = tests.PerformRequest(router, http.MethodPut, fmt.Sprintf("%s/%s/default", rolesUrl, adminRole.ID), managerToken, nil)
+		tests.AssertStatusCode(t, resp.Code, http.StatusForbidden)
+
+		resp = tests.PerformRequest(router, http.MethodPut, memberRoleUrl, managerToken, models.AssignOrgRoleRequestModel{RoleID: viewerRole.ID})
+		tests.AssertStatusCode(t, resp.Code, http.StatusOK)
+	})
+
+	t.Run("Remove Member With Permission Not Held", func(t *testing.T) {
+		seniorUrl := fmt.Sprintf("/api/v1/organizations/%s/users/%s", org.ID, senior.ID)
+
+		resp := tests.PerformRequest(router, http.MethodDelete, seniorUrl, managerToken, nil)
+		tests.AssertStatusCode(t, resp.Code, http.StatusForbidden)
+
+		var stored models.UserOrganisation
+		if _, err := stored.GetMembership(db, org.ID, senior.ID); err != nil {
+			t.Errorf("expected the senior member to stay in the organisation")
+		}
+	})
+
+	t.Run("Demote Member With Permission Not Held", func(t *testing.T) {
+		seniorRoleUrl := fmt.Sprintf("/api/v1/organizations/%s/users/%s/role", org.ID, senior.ID)
+
+		resp := tests.PerformRequest(router, http.MethodPut, seniorRoleUrl, managerToken, models.AssignOrgRoleRequestModel{RoleID: viewerRole.ID})
+		tests.AssertStatusCode(t, resp.Code, http.StatusForbidden)
+
+		resp = tests.PerformRequest(router, http.MethodDelete, seniorRoleUrl, managerToken, nil)
+		tests.AssertStatusCode(t, resp.Code, http.StatusForbidden)
+
+		var stored models.UserOrganisation
+		stored, _ = stored.GetMembership(db, org.ID, senior.ID)
+		if stored.OrgRoleID == nil || *stored.OrgRoleID != adminRole.ID {
+			t.Errorf("expected the senior member to keep the role %v", adminRole.ID)
+		}
+
+		resp = tests.PerformRequest(router, http.MethodPut, seniorRoleUrl, ownerToken, models.AssignOrgRoleRequestModel{RoleID: viewerRole.ID})
+		tests.AssertStatusCode(t, resp.Code, http.StatusOK)
+	})
+
+	t.Run("Change Own Role", func(t *testing.T) {
+		managerRoleUrl := fmt.Sprintf("/api/v1/organizations/%s/users/%s/role", org.ID, manager.ID)
+
+		resp := tests.PerformRequest(router, http.MethodPut, managerRoleUrl, managerToken, models.AssignOrgRoleRequestModel{RoleID: viewerRole.ID})
+		tests.AssertStatusCode(t, resp.Code, http.StatusForbidden)
+
+		resp = tests.PerformRequest(router, http.MethodDelete, managerRoleUrl, managerToken, nil)
+		tests.AssertStatusCode(t, resp.Code, http.StatusForbidden)
+
+		resp = tests.PerformRequest(router, http.MethodPut, managerRoleUrl, ownerToken, models.AssignOrgRoleRequestModel{RoleID: adminRole.ID})
+		tests.AssertStatusCode(t, resp.Code, http.StatusOK)
+	})
+}
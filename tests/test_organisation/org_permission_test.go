@@ -0,0 +1,85 @@
+package test_organisation
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/hngprojects/hng_boilerplate_golang_web/internal/models"
+	"github.com/hngprojects/hng_boilerplate_golang_web/pkg/controller/auth"
+	"github.com/hngprojects/hng_boilerplate_golang_web/tests"
+	"github.com/hngprojects/hng_boilerplate_golang_web/utility"
+)
+
+func TestRequireOrgPermission(t *testing.T) {
+	router, orgController := SetupOrgTestRouter()
+	db := orgController.Db.Postgresql
+	currUUID := utility.GenerateUUID()
+	password, _ := utility.HashPassword("password")
+
+	authController := auth.Controller{
+		Db:        orgController.Db,
+		Validator: orgController.Validator,
+		Logger:    orgController.Logger,
+	}
+
+	owner := models.User{
+		ID:       utility.GenerateUUID(),
+		Name:     "Owner User",
+		Email:    fmt.Sprintf("permowner%v@qa.team", currUUID),
+		Password: password,
+		Role:     int(models.RoleIdentity.User),
+	}
+	member := models.User{
+		ID:       utility.GenerateUUID(),
+		Name:     "Member User",
+		Email:    fmt.Sprintf("permmember%v@qa.team", currUUID),
+		Password: password,
+		Role:     int(models.RoleIdentity.User),
+	}
+	org := models.Organisation{
+		ID:      utility.GenerateUUID(),
+		Name:    fmt.Sprintf("Org perm%v", currUUID),
+		Email:   fmt.Sprintf("permorg%v@qa.team", currUUID),
+		OwnerID: owner.ID,
+	}
+
+	db.Create(&owner)
+	db.Create(&member)
+	db.Create(&org)
+	member.AddUserToOrganisation(db, &member, []interface{}{&org})
+
+	createRole := func(token string) *httptest.ResponseRecorder {
+		roleJSON, _ := json.Marshal(models.OrgRole{
+			Name:        fmt.Sprintf("Role-%v", utility.RandomString(5)),
+			Description: "New role description",
+		})
+
+		req, _ := http.NewRequest(http.MethodPost, fmt.Sprintf("/api/v1/organizations/%s/roles", org.ID), bytes.NewBuffer(roleJSON))
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", token))
+
+		resp := httptest.NewRecorder()
+		router.ServeHTTP(resp, req)
+		return resp
+	}
+
+	t.Run("Owner Holds Every Permission", func(t *testing.T) {
+		token := tests.GetLoginToken(t, authController, models.LoginRequestModel{Email: owner.Email, Password: "password"})
+
+		resp := createRole(token)
+		tests.AssertStatusCode(t, resp.Code, http.StatusCreated)
+	})
+
+	t.Run("Member Without Permission", func(t *testing.T) {
+		token := tests.GetLoginToken(t, authController, models.LoginRequestModel{Email: member.Email, Password: "password"})
+
+		resp := createRole(token)
+		tests.AssertStatusCode(t, resp.Code, http.StatusForbidden)
+		response := tests.ParseResponse(resp)
+		tests.AssertResponseMessage(t, response["message"].(string), "missing organisation permission: can_manage_roles")
+	})
+}
@@ -46,7 +46,7 @@ func TestOrganizationCreate(t *testing.T) {
 	r := gin.Default()
 	tst.SignupUser(t, r, auth, userSignUpData, false)
 
-	token := tst.GetLoginToken(t, r, auth, loginData)
+	token := tst.GetLoginToken(t, auth, loginData)
 
 	tests := []struct {
 		Name         string
@@ -386,7 +386,7 @@ func TestOrganisationUpdate(t *testing.T) {
 
 	orgUrl := r.Group("/api/v1", middleware.Authorize(db.Postgresql, models.RoleIdentity.SuperAdmin, models.RoleIdentity.User))
 	{
-		orgUrl.PATCH("/organizations/:org_id", org.UpdateOrganisation)
+		orgUrl.PATCH("/organizations/:org_id", middleware.RequireOrgPermission(db.Postgresql, models.PermEditOrganisation), org.UpdateOrganisation)
 	}
 
 	for _, test := range tests {
@@ -498,7 +498,7 @@ func TestOrganisationDelete(t *testing.T) {
 
 	orgUrl := r.Group("/api/v1", middleware.Authorize(db.Postgresql, models.RoleIdentity.SuperAdmin, models.RoleIdentity.User))
 	{
-		orgUrl.DELETE("/organizations/:org_id", org.DeleteOrganisation)
+		orgUrl.DELETE("/organizations/:org_id", middleware.RequireOrgPermission(db.Postgresql, models.PermDeleteOrganisation), org.DeleteOrganisation)
 	}
 
 	for _, test := range tests {
@@ -0,0 +1,114 @@
+package test_organisation
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/hngprojects/hng_boilerplate_golang_web/internal/models"
+	"github.com/hngprojects/hng_boilerplate_golang_web/pkg/controller/auth"
+	service "github.com/hngprojects/hng_boilerplate_golang_web/services/organisation"
+	"github.com/hngprojects/hng_boilerplate_golang_web/tests"
+	"github.com/hngprojects/hng_boilerplate_golang_web/utility"
+)
+
+func TestOwnershipTransfer(t *testing.T) {
+	router, orgController := SetupOrgTestRouter()
+	db := orgController.Db.Postgresql
+	currUUID := utility.GenerateUUID()
+
+	authController := auth.Controller{
+		Db:        orgController.Db,
+		Validator: orgController.Validator,
+		Logger:    orgController.Logger,
+	}
+
+	owner, ownerToken := tests.CreateLoggedInUser(t, authController, models.User{Name: "owner"})
+	member, memberToken := tests.CreateLoggedInUser(t, authController, models.User{Name: "member"})
+	outsider, outsiderToken := tests.CreateLoggedInUser(t, authController, models.User{Name: "outsider"})
+	_, adminToken := tests.CreateLoggedInUser(t, authController, models.User{Name: "admin", Role: int(models.RoleIdentity.SuperAdmin)})
+
+	org, err := service.CreateOrganisation(models.CreateOrgRequestModel{
+		Name:    fmt.Sprintf("Org transfer%v", currUUID),
+		Email:   fmt.Sprintf("transferorg%v@qa.team", currUUID),
+		State:   "test",
+		Type:    "type1",
+		Address: "wakanda land",
+		Country: "wakanda",
+	}, db, owner.ID)
+	if err != nil {
+		t.Fatalf("creating organisation failed: %v", err)
+	}
+	member.AddUserToOrganisation(db, &member, []interface{}{org})
+
+	transferUrl := fmt.Sprintf("/api/v1/organizations/%s/transfer", org.ID)
+
+	t.Run("Only Owner Can Nominate", func(t *testing.T) {
+		resp := tests.PerformRequest(router, http.MethodPost, transferUrl, memberToken, models.TransferOwnershipRequestModel{UserID: member.ID})
+		tests.AssertStatusCode(t, resp.Code, http.StatusForbidden)
+		tests.AssertResponseMessage(t, tests.ParseResponse(resp)["message"].(string), "only the owner can transfer the organisation")
+	})
+
+	t.Run("Nominee Must Be A Member", func(t *testing.T) {
+		resp := tests.PerformRequest(router, http.MethodPost, transferUrl, ownerToken, models.TransferOwnershipRequestModel{UserID: outsider.ID})
+		tests.AssertStatusCode(t, resp.Code, http.StatusBadRequest)
+		tests.AssertResponseMessage(t, tests.ParseResponse(resp)["message"].(string), "the new owner must be a member of the organisation")
+	})
+
+	t.Run("Nominee Accepts", func(t *testing.T) {
+		resp := tests.PerformRequest(router, http.MethodPost, transferUrl, ownerToken, models.TransferOwnershipRequestModel{UserID: member.ID})
+		tests.AssertStatusCode(t, resp.Code, http.StatusOK)
+
+		// the mailed token is only stored hashed, so replace it with a known one
+		token := utility.GenerateUUID()
+		transfer := models.OwnershipTransfer{
+			ID:             utility.GenerateUUID(),
+			OrganisationID: org.ID,
+			FromUserID:     owner.ID,
+			ToUserID:       member.ID,
+			TokenHash:      utility.HashToken(token),
+			ExpiresAt:      time.Now().Add(time.Hour),
+		}
+		transfer.ReplaceOwnershipTransfer(db)
+
+		resp = tests.PerformRequest(router, http.MethodPost, "/api/v1/organizations/transfer/accept", outsiderToken, models.OwnershipTransferTokenRequestModel{Token: token})
+		tests.AssertStatusCode(t, resp.Code, http.StatusForbidden)
+
+		resp = tests.PerformRequest(router, http.MethodPost, "/api/v1/organizations/transfer/accept", memberToken, models.OwnershipTransferTokenRequestModel{Token: token})
+		tests.AssertStatusCode(t, resp.Code, http.StatusOK)
+
+		resp = tests.PerformRequest(router, http.MethodPost, "/api/v1/organizations/transfer/accept", memberToken, models.OwnershipTransferTokenRequestModel{Token: token})
+		tests.AssertStatusCode(t, resp.Code, http.StatusBadRequest)
+
+		var stored models.Organisation
+		stored, _ = stored.GetOrgByID(db, org.ID)
+		tests.AssertResponseMessage(t, stored.OwnerID, member.ID)
+
+		role, isOwner, err := stored.GetMemberRole(db, org.ID, owner.ID)
+		if err != nil || isOwner || role == nil {
+			t.Fatalf("expected the previous owner to stay on with a role, got %v %v %v", role, isOwner, err)
+		}
+		tests.AssertResponseMessage(t, role.Name, models.OrgRoleAdmin)
+	})
+
+	t.Run("Superadmin Forces Transfer", func(t *testing.T) {
+		forceUrl := transferUrl + "/force"
+
+		resp := tests.PerformRequest(router, http.MethodPost, forceUrl, memberToken, models.TransferOwnershipRequestModel{UserID: owner.ID})
+		tests.AssertStatusCode(t, resp.Code, http.StatusUnauthorized)
+
+		resp = tests.PerformRequest(router, http.MethodPost, forceUrl, adminToken, models.TransferOwnershipRequestModel{UserID: owner.ID})
+		tests.AssertStatusCode(t, resp.Code, http.StatusOK)
+
+		var stored models.Organisation
+		stored, _ = stored.GetOrgByID(db, org.ID)
+		tests.AssertResponseMessage(t, stored.OwnerID, owner.ID)
+
+		var auditLog models.AuditLog
+		logs, _ := auditLog.GetTargetAuditLogs(db, "organisation", org.ID)
+		if len(logs) == 0 || logs[0].Action != models.AuditActionOwnershipForced {
+			t.Errorf("expected the forced transfer to be audited")
+		}
+	})
+}
@@ -0,0 +1,124 @@
+package test_organisation
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/hngprojects/hng_boilerplate_golang_web/internal/models"
+	"github.com/hngprojects/hng_boilerplate_golang_web/pkg/controller/auth"
+	service "github.com/hngprojects/hng_boilerplate_golang_web/services/organisation"
+	"github.com/hngprojects/hng_boilerplate_golang_web/tests"
+	"github.com/hngprojects/hng_boilerplate_golang_web/utility"
+)
+
+func TestPermissionCatalog(t *testing.T) {
+	router, orgController := SetupOrgTestRouter()
+	db := orgController.Db.Postgresql
+	currUUID := utility.GenerateUUID()
+	password, _ := utility.HashPassword("password")
+
+	authController := auth.Controller{
+		Db:        orgController.Db,
+		Validator: orgController.Validator,
+		Logger:    orgController.Logger,
+	}
+
+	owner := models.User{
+		ID:       utility.GenerateUUID(),
+		Name:     "Owner User",
+		Email:    fmt.Sprintf("catalogowner%v@qa.team", currUUID),
+		Password: password,
+		Role:     int(models.RoleIdentity.User),
+	}
+	db.Create(&owner)
+
+	org, err := service.CreateOrganisation(models.CreateOrgRequestModel{
+		Name:    fmt.Sprintf("Org catalog%v", currUUID),
+		Email:   fmt.Sprintf("catalogorg%v@qa.team", currUUID),
+		State:   "test",
+		Type:    "type1",
+		Address: "wakanda land",
+		Country: "wakanda",
+	}, db, owner.ID)
+	if err != nil {
+		t.Fatalf("creating organisation failed: %v", err)
+	}
+
+	token := tests.GetLoginToken(t, authController, models.LoginRequestModel{Email: owner.Email, Password: "password"})
+
+	t.Run("Get Catalog", func(t *testing.T) {
+		resp := tests.PerformRequest(router, http.MethodGet, "/api/v1/permissions", token, nil)
+		tests.AssertStatusCode(t, resp.Code, http.StatusOK)
+
+		categories := tests.ParseResponse(resp)["data"].([]interface{})
+		if len(categories) != len(models.PermissionCatalog) {
+			t.Errorf("expected %d categories, got %d", len(models.PermissionCatalog), len(categories))
+		}
+	})
+
+	t.Run("Role Templates Created", func(t *testing.T) {
+		var role models.OrgRole
+		roles, _ := role.GetOrgRoles(db, org.ID)
+
+		names := map[string]bool{}
+		for _, role := range roles {
+			names[role.Name] = true
+		}
+		for _, template := range models.OrgRoleTemplates {
+			if !names[template.Name] {
+				t.Errorf("expected the %v role to be created", template.Name)
+			}
+		}
+
+		var membership models.UserOrganisation
+		membership, _ = membership.GetMembership(db, org.ID, owner.ID)
+		if membership.OrgRoleID == nil {
+			t.Fatalf("expected the creator to hold the owner role")
+		}
+		ownerRole, _ := role.GetAOrgRole(db, org.ID, *membership.OrgRoleID)
+		tests.AssertResponseMessage(t, ownerRole.Name, models.OrgRoleOwner)
+	})
+
+	t.Run("Unknown Permission Rejected", func(t *testing.T) {
+		var role models.OrgRole
+		roles, _ := role.GetOrgRoles(db, org.ID)
+
+		resp := tests.PerformRequest(router, http.MethodPatch, fmt.Sprintf("/api/v1/organizations/%s/roles/%s/permissions", org.ID, roles[0].ID), token, models.Permission{
+			Category:       "Refunds",
+			PermissionList: models.PermissionList{"can_veiw_refunds": true},
+		})
+		tests.AssertStatusCode(t, resp.Code, http.StatusUnprocessableEntity)
+		tests.AssertResponseMessage(t, tests.ParseResponse(resp)["message"].(string), "unknown permissions: can_veiw_refunds")
+	})
+
+	t.Run("Template Roles Are Kept", func(t *testing.T) {
+		viewerRole, _ := org.GetTemplateRole(db, models.OrgRoleViewer)
+		url := fmt.Sprintf("/api/v1/organizations/%s/roles/%s", org.ID, viewerRole.ID)
+
+		resp := tests.PerformRequest(router, http.MethodDelete, url, token, nil)
+		tests.AssertStatusCode(t, resp.Code, http.StatusBadRequest)
+		tests.AssertResponseMessage(t, tests.ParseResponse(resp)["message"].(string), "built-in roles cannot be renamed or deleted")
+
+		resp = tests.PerformRequest(router, http.MethodPatch, url, token, models.OrgRole{Name: "auditor", Description: "Reads the books"})
+		tests.AssertStatusCode(t, resp.Code, http.StatusBadRequest)
+
+		resp = tests.PerformRequest(router, http.MethodPatch, url, token, models.OrgRole{Name: models.OrgRoleViewer, Description: "Reads the books"})
+		tests.AssertStatusCode(t, resp.Code, http.StatusOK)
+	})
+
+	t.Run("Deleted Role Name Can Be Reused", func(t *testing.T) {
+		rolesURL := fmt.Sprintf("/api/v1/organizations/%s/roles", org.ID)
+		createRole := func() string {
+			resp := tests.PerformRequest(router, http.MethodPost, rolesURL, token, models.OrgRole{Name: "auditor", Description: "Reads the books"})
+			tests.AssertStatusCode(t, resp.Code, http.StatusCreated)
+			return tests.ParseResponse(resp)["data"].(map[string]interface{})["id"].(string)
+		}
+
+		roleID := createRole()
+		resp := tests.PerformRequest(router, http.MethodDelete, rolesURL+"/"+roleID, token, nil)
+		tests.AssertStatusCode(t, resp.Code, http.StatusOK)
+
+		createRole()
+	})
+}
@@ -0,0 +1,153 @@
+package test_organisation
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/hngprojects/hng_boilerplate_golang_web/internal/models"
+	"github.com/hngprojects/hng_boilerplate_golang_web/pkg/controller/auth"
+	service "github.com/hngprojects/hng_boilerplate_golang_web/services/organisation"
+	"github.com/hngprojects/hng_boilerplate_golang_web/tests"
+	"github.com/hngprojects/hng_boilerplate_golang_web/utility"
+)
+
+func TestRemoveAndLeave(t *testing.T) {
+	router, orgController := SetupOrgTestRouter()
+	db := orgController.Db.Postgresql
+	currUUID := utility.GenerateUUID()
+
+	authController := auth.Controller{
+		Db:        orgController.Db,
+		Validator: orgController.Validator,
+		Logger:    orgController.Logger,
+	}
+
+	owner, ownerToken := tests.CreateLoggedInUser(t, authController, models.User{Name: "owner"})
+	admin, adminToken := tests.CreateLoggedInUser(t, authController, models.User{Name: "admin"})
+	member, memberToken := tests.CreateLoggedInUser(t, authController, models.User{Name: "member"})
+	colleague, _ := tests.CreateLoggedInUser(t, authController, models.User{Name: "colleague"})
+	outsider, _ := tests.CreateLoggedInUser(t, authController, models.User{Name: "outsider"})
+
+	org, err := service.CreateOrganisation(models.CreateOrgRequestModel{
+		Name:    fmt.Sprintf("Org removal%v", currUUID),
+		Email:   fmt.Sprintf("removalorg%v@qa.team", currUUID),
+		State:   "test",
+		Type:    "type1",
+		Address: "wakanda land",
+		Country: "wakanda",
+	}, db, owner.ID)
+	if err != nil {
+		t.Fatalf("creating organisation failed: %v", err)
+	}
+	for _, user := range []models.User{admin, member, colleague} {
+		user.AddUserToOrganisation(db, &user, []interface{}{org})
+	}
+
+	adminRole, _ := org.GetTemplateRole(db, models.OrgRoleAdmin)
+	adminMembership := models.UserOrganisation{UserID: admin.ID, OrganisationID: org.ID}
+	adminMembership.AssignRole(db, &adminRole.ID)
+
+	newProduct := func(ownerID string) models.Product {
+		product := models.Product{
+			ID:             utility.GenerateUUID(),
+			Name:           "Org Sneaker",
+			Description:    "Sold by the organisation",
+			Price:          120,
+			OwnerID:        ownerID,
+			OrganisationID: &org.ID,
+		}
+		db.Create(&product)
+		return product
+	}
+
+	usersUrl := fmt.Sprintf("/api/v1/organizations/%s/users/", org.ID)
+	leaveUrl := fmt.Sprintf("/api/v1/organizations/%s/leave", org.ID)
+
+	isMember := func(userID string) bool {
+		var membership models.UserOrganisation
+		_, err := membership.GetMembership(db, org.ID, userID)
+		return err == nil
+	}
+
+	t.Run("Member Without Permission Cannot Remove", func(t *testing.T) {
+		resp := tests.PerformRequest(router, http.MethodDelete, usersUrl+colleague.ID, memberToken, nil)
+		tests.AssertStatusCode(t, resp.Code, http.StatusForbidden)
+	})
+
+	t.Run("Owner Cannot Be Removed Or Leave", func(t *testing.T) {
+		resp := tests.PerformRequest(router, http.MethodDelete, usersUrl+owner.ID, adminToken, nil)
+		tests.AssertStatusCode(t, resp.Code, http.StatusBadRequest)
+
+		resp = tests.PerformRequest(router, http.MethodPost, leaveUrl, ownerToken, nil)
+		tests.AssertStatusCode(t, resp.Code, http.StatusBadRequest)
+		tests.AssertResponseMessage(t, tests.ParseResponse(resp)["message"].(string), "the owner must transfer ownership before leaving the organisation")
+	})
+
+	t.Run("Resources Only Go To Members", func(t *testing.T) {
+		resp := tests.PerformRequest(router, http.MethodDelete, usersUrl+member.ID+"?reassign_to="+outsider.ID, adminToken, nil)
+		tests.AssertStatusCode(t, resp.Code, http.StatusBadRequest)
+
+		resp = tests.PerformRequest(router, http.MethodDelete, usersUrl+member.ID+"?resources=archive", adminToken, nil)
+		tests.AssertStatusCode(t, resp.Code, http.StatusUnprocessableEntity)
+
+		if !isMember(member.ID) {
+			t.Fatalf("expected the member to stay after a rejected removal")
+		}
+	})
+
+	t.Run("Admin Removes Member And Reassigns Products", func(t *testing.T) {
+		product := newProduct(member.ID)
+
+		resp := tests.PerformRequest(router, http.MethodDelete, usersUrl+member.ID+"?reassign_to="+colleague.ID, adminToken, nil)
+		tests.AssertStatusCode(t, resp.Code, http.StatusOK)
+
+		if isMember(member.ID) {
+			t.Errorf("expected the member to be removed")
+		}
+
+		var stored models.Product
+		db.First(&stored, "id = ?", product.ID)
+		tests.AssertResponseMessage(t, stored.OwnerID, colleague.ID)
+
+		var auditLog models.AuditLog
+		logs, _ := auditLog.GetTargetAuditLogs(db, "organisation", org.ID)
+		if len(logs) == 0 || logs[0].Action != models.AuditActionMemberRemoved {
+			t.Errorf("expected the removal to be audited")
+		}
+
+		resp = tests.PerformRequest(router, http.MethodDelete, usersUrl+member.ID, adminToken, nil)
+		tests.AssertStatusCode(t, resp.Code, http.StatusNotFound)
+	})
+
+	t.Run("Removed Member Keeps Products", func(t *testing.T) {
+		product := newProduct(colleague.ID)
+
+		resp := tests.PerformRequest(router, http.MethodDelete, usersUrl+colleague.ID+"?resources=keep", ownerToken, nil)
+		tests.AssertStatusCode(t, resp.Code, http.StatusOK)
+
+		var stored models.Product
+		db.First(&stored, "id = ?", product.ID)
+		if stored.OwnerID != colleague.ID || stored.OrganisationID != nil {
+			t.Errorf("expected the product to leave the organisation with its owner")
+		}
+	})
+
+	t.Run("Member Leaves", func(t *testing.T) {
+		product := newProduct(admin.ID)
+
+		resp := tests.PerformRequest(router, http.MethodPost, leaveUrl, adminToken, nil)
+		tests.AssertStatusCode(t, resp.Code, http.StatusOK)
+
+		if isMember(admin.ID) {
+			t.Errorf("expected the admin to have left")
+		}
+
+		var stored models.Product
+		db.First(&stored, "id = ?", product.ID)
+		tests.AssertResponseMessage(t, stored.OwnerID, owner.ID)
+
+		resp = tests.PerformRequest(router, http.MethodDelete, usersUrl+owner.ID, adminToken, nil)
+		tests.AssertStatusCode(t, resp.Code, http.StatusForbidden)
+	})
+}
@@ -0,0 +1,189 @@
+package test_organisation
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/hngprojects/hng_boilerplate_golang_web/internal/models"
+	"github.com/hngprojects/hng_boilerplate_golang_web/pkg/controller/auth"
+	service "github.com/hngprojects/hng_boilerplate_golang_web/services/organisation"
+	"github.com/hngprojects/hng_boilerplate_golang_web/tests"
+	"github.com/hngprojects/hng_boilerplate_golang_web/utility"
+)
+
+func TestTeams(t *testing.T) {
+	router, orgController := SetupOrgTestRouter()
+	db := orgController.Db.Postgresql
+	currUUID := utility.GenerateUUID()
+
+	authController := auth.Controller{
+		Db:        orgController.Db,
+		Validator: orgController.Validator,
+		Logger:    orgController.Logger,
+	}
+
+	owner, ownerToken := tests.CreateLoggedInUser(t, authController, models.User{Name: "owner"})
+	lead, leadToken := tests.CreateLoggedInUser(t, authController, models.User{Name: "lead"})
+	viewer, viewerToken := tests.CreateLoggedInUser(t, authController, models.User{Name: "viewer"})
+	colleague, colleagueToken := tests.CreateLoggedInUser(t, authController, models.User{Name: "colleague"})
+	outsider, outsiderToken := tests.CreateLoggedInUser(t, authController, models.User{Name: "outsider"})
+
+	org, err := service.CreateOrganisation(models.CreateOrgRequestModel{
+		Name:    fmt.Sprintf("Org team%v", currUUID),
+		Email:   fmt.Sprintf("teamorg%v@qa.team", currUUID),
+		State:   "test",
+		Type:    "type1",
+		Address: "wakanda land",
+		Country: "wakanda",
+	}, db, owner.ID)
+	if err != nil {
+		t.Fatalf("creating organisation failed: %v", err)
+	}
+
+	viewerRole, _ := org.GetTemplateRole(db, models.OrgRoleViewer)
+	adminRole, _ := org.GetTemplateRole(db, models.OrgRoleAdmin)
+	for _, user := range []models.User{lead, viewer, colleague} {
+		user.AddUserToOrganisation(db, &user, []interface{}{org})
+		membership := models.UserOrganisation{UserID: user.ID, OrganisationID: org.ID}
+		membership.AssignRole(db, &viewerRole.ID)
+	}
+
+	teamsUrl := fmt.Sprintf("/api/v1/organizations/%s/teams", org.ID)
+	var teamUrl string
+
+	t.Run("Create Team", func(t *testing.T) {
+		resp := tests.PerformRequest(router, http.MethodPost, teamsUrl, viewerToken, models.CreateTeamRequestModel{Name: "Sales"})
+		tests.AssertStatusCode(t, resp.Code, http.StatusForbidden)
+
+		resp = tests.PerformRequest(router, http.MethodPost, teamsUrl, ownerToken, models.CreateTeamRequestModel{Name: "Sales", Description: "Sells things"})
+		tests.AssertStatusCode(t, resp.Code, http.StatusCreated)
+
+		data := tests.ParseResponse(resp)["data"].(map[string]interface{})["team"].(map[string]interface{})
+		teamUrl = teamsUrl + "/" + data["id"].(string)
+
+		resp = tests.PerformRequest(router, http.MethodPost, teamsUrl, ownerToken, models.CreateTeamRequestModel{Name: "sales"})
+		tests.AssertStatusCode(t, resp.Code, http.StatusConflict)
+	})
+
+	t.Run("Only Members See Teams", func(t *testing.T) {
+		resp := tests.PerformRequest(router, http.MethodGet, teamsUrl, viewerToken, nil)
+		tests.AssertStatusCode(t, resp.Code, http.StatusOK)
+
+		resp = tests.PerformRequest(router, http.MethodGet, teamUrl, outsiderToken, nil)
+		tests.AssertStatusCode(t, resp.Code, http.StatusForbidden)
+	})
+
+	t.Run("Leads Manage Membership", func(t *testing.T) {
+		resp := tests.PerformRequest(router, http.MethodPost, teamUrl+"/members", leadToken, models.AddTeamMemberRequestModel{UserID: viewer.ID})
+		tests.AssertStatusCode(t, resp.Code, http.StatusForbidden)
+
+		resp = tests.PerformRequest(router, http.MethodPost, teamUrl+"/members", ownerToken, models.AddTeamMemberRequestModel{UserID: lead.ID, IsLead: true})
+		tests.AssertStatusCode(t, resp.Code, http.StatusCreated)
+
+		resp = tests.PerformRequest(router, http.MethodPost, teamUrl+"/members", leadToken, models.AddTeamMemberRequestModel{UserID: viewer.ID})
+		tests.AssertStatusCode(t, resp.Code, http.StatusCreated)
+
+		resp = tests.PerformRequest(router, http.MethodPost, teamUrl+"/members", leadToken, models.AddTeamMemberRequestModel{UserID: colleague.ID, IsLead: true})
+		tests.AssertStatusCode(t, resp.Code, http.StatusForbidden)
+
+		resp = tests.PerformRequest(router, http.MethodPost, teamUrl+"/members", leadToken, models.AddTeamMemberRequestModel{UserID: outsider.ID})
+		tests.AssertStatusCode(t, resp.Code, http.StatusBadRequest)
+
+		resp = tests.PerformRequest(router, http.MethodGet, teamUrl+"/members", viewerToken, nil)
+		tests.AssertStatusCode(t, resp.Code, http.StatusOK)
+		members := tests.ParseResponse(resp)["data"].(map[string]interface{})["members"].([]interface{})
+		if len(members) != 2 {
+			t.Errorf("expected 2 team members, got %d", len(members))
+		}
+	})
+
+	t.Run("Shared Product Can Be Managed By Team", func(t *testing.T) {
+		product := models.Product{
+			ID:             utility.GenerateUUID(),
+			Name:           "Team Sneaker",
+			Description:    "Sold by the team",
+			Price:          120,
+			OwnerID:        owner.ID,
+			OrganisationID: &org.ID,
+		}
+		db.Create(&product)
+
+		allowed, _ := product.CanBeManagedBy(db, viewer.ID)
+		tests.AssertBool(t, allowed, false)
+
+		resp := tests.PerformRequest(router, http.MethodPost, teamUrl+"/resources", leadToken, models.ShareTeamResourceRequestModel{ResourceType: models.TeamResourceProduct, ResourceID: product.ID})
+		tests.AssertStatusCode(t, resp.Code, http.StatusForbidden)
+
+		resp = tests.PerformRequest(router, http.MethodPost, teamUrl+"/resources", ownerToken, models.ShareTeamResourceRequestModel{ResourceType: models.TeamResourceProduct, ResourceID: product.ID})
+		tests.AssertStatusCode(t, resp.Code, http.StatusCreated)
+
+		allowed, _ = product.CanBeManagedBy(db, viewer.ID)
+		tests.AssertBool(t, allowed, true)
+
+		resp = tests.PerformRequest(router, http.MethodPost, teamUrl+"/resources", ownerToken, models.ShareTeamResourceRequestModel{ResourceType: models.TeamResourceProduct, ResourceID: product.ID})
+		tests.AssertStatusCode(t, resp.Code, http.StatusConflict)
+	})
+
+	t.Run("Team Role Needs Permissions Held", func(t *testing.T) {
+		managerRole := models.OrgRole{
+			ID:             utility.GenerateUUID(),
+			Name:           fmt.Sprintf("Mgr-%v", utility.RandomString(5)),
+			Description:    "Manages members",
+			OrganisationID: org.ID,
+		}
+		db.Create(&managerRole)
+		db.Create(&models.Permission{
+			ID:             utility.GenerateUUID(),
+			RoleID:         managerRole.ID,
+			Category:       "Members",
+			PermissionList: models.PermissionList{models.PermManageMembers: true},
+		})
+		membership := models.UserOrganisation{UserID: colleague.ID, OrganisationID: org.ID}
+		membership.AssignRole(db, &managerRole.ID)
+
+		resp := tests.PerformRequest(router, http.MethodPut, teamUrl+"/role", colleagueToken, models.AssignOrgRoleRequestModel{RoleID: adminRole.ID})
+		tests.AssertStatusCode(t, resp.Code, http.StatusForbidden)
+
+		resp = tests.PerformRequest(router, http.MethodPut, teamUrl+"/role", colleagueToken, models.AssignOrgRoleRequestModel{RoleID: managerRole.ID})
+		tests.AssertStatusCode(t, resp.Code, http.StatusOK)
+
+		resp = tests.PerformRequest(router, http.MethodDelete, teamUrl+"/role", colleagueToken, nil)
+		tests.AssertStatusCode(t, resp.Code, http.StatusOK)
+	})
+
+	t.Run("Team Role Grants Permissions", func(t *testing.T) {
+		allowed, _ := org.HasPermission(db, org.ID, viewer.ID, models.PermManageTeams)
+		tests.AssertBool(t, allowed, false)
+
+		resp := tests.PerformRequest(router, http.MethodPut, teamUrl+"/role", leadToken, models.AssignOrgRoleRequestModel{RoleID: adminRole.ID})
+		tests.AssertStatusCode(t, resp.Code, http.StatusForbidden)
+
+		resp = tests.PerformRequest(router, http.MethodPut, teamUrl+"/role", ownerToken, models.AssignOrgRoleRequestModel{RoleID: adminRole.ID})
+		tests.AssertStatusCode(t, resp.Code, http.StatusOK)
+
+		resp = tests.PerformRequest(router, http.MethodPost, teamsUrl, viewerToken, models.CreateTeamRequestModel{Name: "Support"})
+		tests.AssertStatusCode(t, resp.Code, http.StatusCreated)
+
+		resp = tests.PerformRequest(router, http.MethodDelete, fmt.Sprintf("/api/v1/organizations/%s/roles/%s", org.ID, adminRole.ID), ownerToken, nil)
+		tests.AssertStatusCode(t, resp.Code, http.StatusBadRequest)
+	})
+
+	t.Run("Removed Members Leave Their Teams", func(t *testing.T) {
+		resp := tests.PerformRequest(router, http.MethodDelete, fmt.Sprintf("/api/v1/organizations/%s/users/%s", org.ID, viewer.ID), ownerToken, nil)
+		tests.AssertStatusCode(t, resp.Code, http.StatusOK)
+
+		var teamMember models.TeamMember
+		if err := db.Where("user_id = ?", viewer.ID).First(&teamMember).Error; err == nil {
+			t.Errorf("expected the team membership to be removed with the organisation membership")
+		}
+	})
+
+	t.Run("Delete Team", func(t *testing.T) {
+		resp := tests.PerformRequest(router, http.MethodDelete, teamUrl, ownerToken, nil)
+		tests.AssertStatusCode(t, resp.Code, http.StatusOK)
+
+		resp = tests.PerformRequest(router, http.MethodGet, teamUrl, ownerToken, nil)
+		tests.AssertStatusCode(t, resp.Code, http.StatusNotFound)
+	})
+}
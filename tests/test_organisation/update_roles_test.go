@@ -79,7 +79,7 @@ func TestUpdateOrgRole(t *testing.T) {
 			Email:    adminUser.Email,
 			Password: "password",
 		}
-		token := tests.GetLoginToken(t, router, *orgController, loginData)
+		token := tests.GetLoginToken(t, *orgController, loginData)
 
 		updatedRole := models.OrgRole{
 			Name:        fmt.Sprintf("%v-New name", utility.RandomString(5)),
@@ -127,7 +127,7 @@ func TestUpdateOrgRole(t *testing.T) {
 			Email:    regularUser.Email,
 			Password: "password",
 		}
-		token := tests.GetLoginToken(t, router, *orgController, loginData)
+		token := tests.GetLoginToken(t, *orgController, loginData)
 
 		updatedRole := models.OrgRole{
 			Name:        fmt.Sprintf("%v-Regur name", utility.RandomString(5)),
@@ -144,7 +144,7 @@ func TestUpdateOrgRole(t *testing.T) {
 
 		tests.AssertStatusCode(t, resp.Code, http.StatusForbidden)
 		response := tests.ParseResponse(resp)
-		tests.AssertResponseMessage(t, response["message"].(string), "not organization owner")
+		tests.AssertResponseMessage(t, response["message"].(string), "user is not a member of this organisation")
 	})
 
 	t.Run("Bad Request - Validation Errors", func(t *testing.T) {
@@ -154,7 +154,7 @@ func TestUpdateOrgRole(t *testing.T) {
 			Email:    adminUser.Email,
 			Password: "password",
 		}
-		token := tests.GetLoginToken(t, router, *orgController, loginData)
+		token := tests.GetLoginToken(t, *orgController, loginData)
 
 		invalidRole := models.OrgRole{
 			Description: "Missing name field",
@@ -238,7 +238,7 @@ func TestUpdateOrgPermissions(t *testing.T) {
 			Email:    adminUser.Email,
 			Password: "password",
 		}
-		token := tests.GetLoginToken(t, router, *orgController, loginData)
+		token := tests.GetLoginToken(t, *orgController, loginData)
 
 		updatedPermissions := models.Permission{
 			Category: "market",
@@ -294,7 +294,7 @@ func TestUpdateOrgPermissions(t *testing.T) {
 			Email:    regularUser.Email,
 			Password: "password",
 		}
-		token := tests.GetLoginToken(t, router, *orgController, loginData)
+		token := tests.GetLoginToken(t, *orgController, loginData)
 
 		updatedPermissions := models.Permission{
 			Category: "market",
@@ -315,7 +315,7 @@ func TestUpdateOrgPermissions(t *testing.T) {
 
 		tests.AssertStatusCode(t, resp.Code, http.StatusForbidden)
 		response := tests.ParseResponse(resp)
-		tests.AssertResponseMessage(t, response["message"].(string), "not organization owner")
+		tests.AssertResponseMessage(t, response["message"].(string), "user is not a member of this organisation")
 	})
 
 	t.Run("Bad Request - bad body", func(t *testing.T) {
@@ -325,7 +325,7 @@ func TestUpdateOrgPermissions(t *testing.T) {
 			Email:    adminUser.Email,
 			Password: "password",
 		}
-		token := tests.GetLoginToken(t, router, *orgController, loginData)
+		token := tests.GetLoginToken(t, *orgController, loginData)
 
 		invalidPermissions := map[string]interface{}{
 			"category":        "market",
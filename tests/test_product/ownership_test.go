@@ -0,0 +1,147 @@
+package tests
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+
+	"github.com/hngprojects/hng_boilerplate_golang_web/internal/models"
+	"github.com/hngprojects/hng_boilerplate_golang_web/pkg/controller/auth"
+	"github.com/hngprojects/hng_boilerplate_golang_web/pkg/controller/product"
+	"github.com/hngprojects/hng_boilerplate_golang_web/pkg/middleware"
+	"github.com/hngprojects/hng_boilerplate_golang_web/pkg/repository/storage"
+	tst "github.com/hngprojects/hng_boilerplate_golang_web/tests"
+	"github.com/hngprojects/hng_boilerplate_golang_web/utility"
+)
+
+func TestProductOwnership(t *testing.T) {
+	logger := tst.Setup()
+	gin.SetMode(gin.TestMode)
+	validatorRef := validator.New()
+	db := storage.Connection()
+	currUUID := utility.GenerateUUID()
+
+	authController := auth.Controller{Db: db, Validator: validatorRef, Logger: logger}
+	productController := product.Controller{Db: db, Validator: validatorRef, Logger: logger}
+
+	owner, ownerToken := tst.CreateLoggedInUser(t, authController, models.User{Name: "owner"})
+	member, memberToken := tst.CreateLoggedInUser(t, authController, models.User{Name: "member"})
+	_, outsiderToken := tst.CreateLoggedInUser(t, authController, models.User{Name: "outsider"})
+
+	org := models.Organisation{
+		ID:      utility.GenerateUUID(),
+		Name:    fmt.Sprintf("Org products%v", currUUID),
+		Email:   fmt.Sprintf("productsorg%v@qa.team", currUUID),
+		OwnerID: owner.ID,
+	}
+	db.Postgresql.Create(&org)
+	member.AddUserToOrganisation(db.Postgresql, &member, []interface{}{&org})
+
+	role := models.OrgRole{
+		ID:             utility.GenerateUUID(),
+		Name:           fmt.Sprintf("Catalog-%v", utility.RandomString(5)),
+		Description:    "Manages products",
+		OrganisationID: org.ID,
+	}
+	db.Postgresql.Create(&role)
+	db.Postgresql.Create(&models.Permission{
+		ID:             utility.GenerateUUID(),
+		RoleID:         role.ID,
+		Category:       "Products",
+		PermissionList: models.PermissionList{models.PermManageProducts: true},
+	})
+
+	r := gin.Default()
+	productUrl := r.Group("/api/v1", middleware.Authorize(db.Postgresql))
+	{
+		productUrl.POST("/products", productController.CreateProduct)
+		productUrl.PUT("/products/", productController.UpdateProduct)
+		productUrl.GET("/products/:product_id", productController.GetProduct)
+		productUrl.GET("/products", productController.GetAllProducts)
+	}
+
+	newProduct := models.CreateProductRequestModel{
+		Name:           "Org Sneaker",
+		Description:    "Sold by the organisation",
+		Price:          120,
+		Category:       "Fashion",
+		OrganisationID: org.ID,
+	}
+	update := func(productID string) models.UpdateProductRequestModel {
+		return models.UpdateProductRequestModel{
+			ProductID:   productID,
+			Name:        "Org Sneaker Updated",
+			Description: "Sold by the organisation",
+			Price:       150,
+		}
+	}
+
+	var productID string
+
+	t.Run("Outsider Cannot Add To Organisation", func(t *testing.T) {
+		rr := tst.PerformRequest(r, http.MethodPost, "/api/v1/products", outsiderToken, newProduct)
+		tst.AssertStatusCode(t, rr.Code, http.StatusForbidden)
+	})
+
+	t.Run("Unknown Organisation", func(t *testing.T) {
+		unknown := newProduct
+		unknown.OrganisationID = utility.GenerateUUID()
+
+		rr := tst.PerformRequest(r, http.MethodPost, "/api/v1/products", ownerToken, unknown)
+		tst.AssertStatusCode(t, rr.Code, http.StatusNotFound)
+		tst.AssertResponseMessage(t, tst.ParseResponse(rr)["message"].(string), "organisation not found")
+	})
+
+	t.Run("Owner Adds To Organisation", func(t *testing.T) {
+		rr := tst.PerformRequest(r, http.MethodPost, "/api/v1/products", ownerToken, newProduct)
+		tst.AssertStatusCode(t, rr.Code, http.StatusCreated)
+
+		productID = tst.ParseResponse(rr)["data"].(map[string]interface{})["product_id"].(string)
+	})
+
+	t.Run("Member Without Permission Cannot Update", func(t *testing.T) {
+		rr := tst.PerformRequest(r, http.MethodPut, "/api/v1/products/", memberToken, update(productID))
+		tst.AssertStatusCode(t, rr.Code, http.StatusForbidden)
+	})
+
+	t.Run("Member With Permission Updates", func(t *testing.T) {
+		var membership models.UserOrganisation
+		membership, _ = membership.GetMembership(db.Postgresql, org.ID, member.ID)
+		membership.AssignRole(db.Postgresql, &role.ID)
+
+		rr := tst.PerformRequest(r, http.MethodPut, "/api/v1/products/", memberToken, update(productID))
+		tst.AssertStatusCode(t, rr.Code, http.StatusOK)
+	})
+
+	t.Run("Outsider Cannot See Or Update", func(t *testing.T) {
+		rr := tst.PerformRequest(r, http.MethodGet, "/api/v1/products/"+productID, outsiderToken, nil)
+		tst.AssertStatusCode(t, rr.Code, http.StatusNotFound)
+
+		rr = tst.PerformRequest(r, http.MethodPut, "/api/v1/products/", outsiderToken, update(productID))
+		tst.AssertStatusCode(t, rr.Code, http.StatusForbidden)
+
+		rr = tst.PerformRequest(r, http.MethodGet, "/api/v1/products?organisation_id="+org.ID, outsiderToken, nil)
+		tst.AssertStatusCode(t, rr.Code, http.StatusForbidden)
+	})
+
+	t.Run("Listing Scopes", func(t *testing.T) {
+		count := func(token, query string) int {
+			rr := tst.PerformRequest(r, http.MethodGet, "/api/v1/products"+query, token, nil)
+			tst.AssertStatusCode(t, rr.Code, http.StatusOK)
+			return len(tst.ParseResponse(rr)["data"].(map[string]interface{})["products"].([]interface{}))
+		}
+
+		if n := count(memberToken, "?scope=organisation&organisation_id="+org.ID); n != 1 {
+			t.Errorf("expected the organisation's product, got %d products", n)
+		}
+		if n := count(memberToken, "?scope=mine"); n != 0 {
+			t.Errorf("expected no products of the member's own, got %d", n)
+		}
+		if n := count(outsiderToken, ""); n != 0 {
+			t.Errorf("expected the outsider to see no products, got %d", n)
+		}
+	})
+}
@@ -46,7 +46,7 @@ func TestProductCreate(t *testing.T) {
 	r := gin.Default()
 	tst.SignupUser(t, r, auth, userSignUpData, false)
 
-	token := tst.GetLoginToken(t, r, auth, loginData)
+	token := tst.GetLoginToken(t, auth, loginData)
 
 	tests := []struct {
 		Name         string
@@ -165,7 +165,7 @@ func TestProductGet(t *testing.T) {
 
 	tst.SignupUser(t, r, auth, userSignUpData, false)
 
-	token := tst.GetLoginToken(t, r, auth, loginData)
+	token := tst.GetLoginToken(t, auth, loginData)
 
 	testProduct := models.CreateProductRequestModel{
 		Name:        "Nike SB",
@@ -285,7 +285,7 @@ func TestProductUpdate(t *testing.T) {
 	auth := auth.Controller{Db: db, Validator: validatorRef, Logger: logger}
 	r := gin.Default()
 	tst.SignupUser(t, r, auth, userSignUpData, false)
-	token := tst.GetLoginToken(t, r, auth, loginData)
+	token := tst.GetLoginToken(t, auth, loginData)
 	testProduct := models.CreateProductRequestModel{
 		Name:        "Nike SB",
 		Description: "One of the best, common and cloned nike product of all time",
@@ -50,7 +50,7 @@ func TestAddToLanguage(t *testing.T) {
 			Email:    adminUser.Email,
 			Password: "password",
 		}
-		token := tests.GetLoginToken(t, router, *authController, loginData)
+		token := tests.GetLoginToken(t, *authController, loginData)
 
 		language := models.Language{
 			Name: fmt.Sprintf("English-%s", utility.RandomString(10)),
@@ -77,7 +77,7 @@ func TestAddToLanguage(t *testing.T) {
 			Email:    adminUser.Email,
 			Password: "password",
 		}
-		token := tests.GetLoginToken(t, router, *authController, loginData)
+		token := tests.GetLoginToken(t, *authController, loginData)
 
 		language := models.Language{
 			Name: "",
@@ -104,7 +104,7 @@ func TestAddToLanguage(t *testing.T) {
 			Email:    adminUser.Email,
 			Password: "password",
 		}
-		token := tests.GetLoginToken(t, router, *authController, loginData)
+		token := tests.GetLoginToken(t, *authController, loginData)
 		theRandom := utility.RandomString(6)
 
 		language := models.Language{
@@ -198,7 +198,7 @@ func TestGetLanguages(t *testing.T) {
 			Email:    adminUser.Email,
 			Password: "password",
 		}
-		token := tests.GetLoginToken(t, router, *authController, loginData)
+		token := tests.GetLoginToken(t, *authController, loginData)
 
 		req, _ := http.NewRequest(http.MethodGet, "/api/v1/languages", nil)
 		req.Header.Set("Content-Type", "application/json")
@@ -218,7 +218,7 @@ func TestGetLanguages(t *testing.T) {
 			Email:    regularUser.Email,
 			Password: "password",
 		}
-		token := tests.GetLoginToken(t, router, *authController, loginData)
+		token := tests.GetLoginToken(t, *authController, loginData)
 
 		req, _ := http.NewRequest(http.MethodGet, "/api/v1/languages", nil)
 		req.Header.Set("Content-Type", "application/json")
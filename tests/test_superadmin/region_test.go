@@ -50,7 +50,7 @@ func TestAddToRegion(t *testing.T) {
 			Email:    adminUser.Email,
 			Password: "password",
 		}
-		token := tests.GetLoginToken(t, router, *authController, loginData)
+		token := tests.GetLoginToken(t, *authController, loginData)
 
 		region := models.Region{
 			Name: fmt.Sprintf("North Amercia-%s", utility.RandomString(6)),
@@ -77,7 +77,7 @@ func TestAddToRegion(t *testing.T) {
 			Email:    adminUser.Email,
 			Password: "password",
 		}
-		token := tests.GetLoginToken(t, router, *authController, loginData)
+		token := tests.GetLoginToken(t, *authController, loginData)
 
 		region := models.Region{
 			Name: fmt.Sprintf("North Amercia-%s", utility.RandomString(6)),
@@ -104,7 +104,7 @@ func TestAddToRegion(t *testing.T) {
 			Email:    adminUser.Email,
 			Password: "password",
 		}
-		token := tests.GetLoginToken(t, router, *authController, loginData)
+		token := tests.GetLoginToken(t, *authController, loginData)
 		theRandom := utility.RandomString(6)
 
 		region := models.Region{
@@ -198,7 +198,7 @@ func TestGetRegions(t *testing.T) {
 			Email:    adminUser.Email,
 			Password: "password",
 		}
-		token := tests.GetLoginToken(t, router, *authController, loginData)
+		token := tests.GetLoginToken(t, *authController, loginData)
 
 		req, _ := http.NewRequest(http.MethodGet, "/api/v1/regions", nil)
 		req.Header.Set("Content-Type", "application/json")
@@ -218,7 +218,7 @@ func TestGetRegions(t *testing.T) {
 			Email:    regularUser.Email,
 			Password: "password",
 		}
-		token := tests.GetLoginToken(t, router, *authController, loginData)
+		token := tests.GetLoginToken(t, *authController, loginData)
 
 		req, _ := http.NewRequest(http.MethodGet, "/api/v1/regions", nil)
 		req.Header.Set("Content-Type", "application/json")
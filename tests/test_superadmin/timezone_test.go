@@ -50,7 +50,7 @@ func TestAddToTimezone(t *testing.T) {
 			Email:    adminUser.Email,
 			Password: "password",
 		}
-		token := tests.GetLoginToken(t, router, *authController, loginData)
+		token := tests.GetLoginToken(t, *authController, loginData)
 
 		timezone := models.Timezone{
 			ID:          utility.GenerateUUID(),
@@ -80,7 +80,7 @@ func TestAddToTimezone(t *testing.T) {
 			Email:    adminUser.Email,
 			Password: "password",
 		}
-		token := tests.GetLoginToken(t, router, *authController, loginData)
+		token := tests.GetLoginToken(t, *authController, loginData)
 
 		timezone := models.Timezone{
 			ID:          utility.GenerateUUID(),
@@ -109,7 +109,7 @@ func TestAddToTimezone(t *testing.T) {
 			Email:    adminUser.Email,
 			Password: "password",
 		}
-		token := tests.GetLoginToken(t, router, *authController, loginData)
+		token := tests.GetLoginToken(t, *authController, loginData)
 		theRandom := utility.RandomString(6)
 
 		timezone := models.Timezone{
@@ -209,7 +209,7 @@ func TestGetTimezones(t *testing.T) {
 			Email:    adminUser.Email,
 			Password: "password",
 		}
-		token := tests.GetLoginToken(t, router, *authController, loginData)
+		token := tests.GetLoginToken(t, *authController, loginData)
 
 		req, _ := http.NewRequest(http.MethodGet, "/api/v1/timezones", nil)
 		req.Header.Set("Content-Type", "application/json")
@@ -229,7 +229,7 @@ func TestGetTimezones(t *testing.T) {
 			Email:    regularUser.Email,
 			Password: "password",
 		}
-		token := tests.GetLoginToken(t, router, *authController, loginData)
+		token := tests.GetLoginToken(t, *authController, loginData)
 
 		req, _ := http.NewRequest(http.MethodGet, "/api/v1/timezones", nil)
 		req.Header.Set("Content-Type", "application/json")
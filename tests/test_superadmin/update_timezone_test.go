@@ -58,7 +58,7 @@ func TestUpdateTimezone(t *testing.T) {
 			Email:    adminUser.Email,
 			Password: "password",
 		}
-		token := tests.GetLoginToken(t, router, *authController, loginData)
+		token := tests.GetLoginToken(t, *authController, loginData)
 
 		updateTimezone := models.Timezone{
 			Timezone:    fmt.Sprintf("America/Los_Angeles-%s", utility.RandomString(10)),
@@ -86,7 +86,7 @@ func TestUpdateTimezone(t *testing.T) {
 			Email:    adminUser.Email,
 			Password: "password",
 		}
-		token := tests.GetLoginToken(t, router, *authController, loginData)
+		token := tests.GetLoginToken(t, *authController, loginData)
 
 		updateTimezone := models.Timezone{
 			Timezone:    fmt.Sprintf("America/Los_Angeles-%s", utility.RandomString(10)),
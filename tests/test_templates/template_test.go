@@ -49,7 +49,7 @@ func TestTemplatePostCreate(t *testing.T) {
 	r := gin.Default()
 	tst.SignupUser(t, r, auth, userSignUpData, false)
 
-	token := tst.GetLoginToken(t, r, auth, loginData)
+	token := tst.GetLoginToken(t, auth, loginData)
 
 	tests := []struct {
 		Name         string
@@ -173,7 +173,7 @@ func TestFetchAllTemplates(t *testing.T) {
 	r := gin.Default()
 	tst.SignupUser(t, r, authController, userSignUpData, false)
 
-	token := tst.GetLoginToken(t, r, authController, loginData)
+	token := tst.GetLoginToken(t, authController, loginData)
 
 	tests := []struct {
 		name         string
@@ -243,7 +243,7 @@ func TestFetchTemplateById(t *testing.T) {
 	r := gin.Default()
 	tst.SignupUser(t, r, authController, userSignUpData, false)
 
-	token := tst.GetLoginToken(t, r, authController, loginData)
+	token := tst.GetLoginToken(t, authController, loginData)
 
 	templateData := models.EmailTemplate{
 		Name: "Test Template",
@@ -339,7 +339,7 @@ func TestUpdateTemplateById(t *testing.T) {
 	r := gin.Default()
 	tst.SignupUser(t, r, authController, userSignUpData, false)
 
-	token := tst.GetLoginToken(t, r, authController, loginData)
+	token := tst.GetLoginToken(t, authController, loginData)
 
 	templateData := models.TemplateRequest{
 		Name: "Test Template",
@@ -437,7 +437,7 @@ func TestDeleteJobPostById(t *testing.T) {
 	r := gin.Default()
 	tst.SignupUser(t, r, authController, userSignUpData, false)
 
-	token := tst.GetLoginToken(t, r, authController, loginData)
+	token := tst.GetLoginToken(t, authController, loginData)
 
 	templateData := models.EmailTemplate{
 		Name: "Test Template",
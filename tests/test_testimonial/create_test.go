@@ -48,7 +48,7 @@ func TestCreateTestimonial(t *testing.T) {
 		Email:    user.Email,
 		Password: "password",
 	}
-	token := tst.GetLoginToken(t, router, *authController, loginData)
+	token := tst.GetLoginToken(t, *authController, loginData)
 
 	tests := []struct {
 		Name         string
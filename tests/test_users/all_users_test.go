@@ -56,7 +56,7 @@ func TestGetAllUsers(t *testing.T) {
 			Email:    adminUser.Email,
 			Password: "password",
 		}
-		token := tests.GetLoginToken(t, router, *authController, loginData)
+		token := tests.GetLoginToken(t, *authController, loginData)
 
 		req, _ := http.NewRequest(http.MethodGet, "/api/v1/users", nil)
 		req.Header.Set("Content-Type", "application/json")
@@ -92,7 +92,7 @@ func TestGetAllUsers(t *testing.T) {
 			Email:    regularUser.Email,
 			Password: "password",
 		}
-		token := tests.GetLoginToken(t, router, *authController, loginData)
+		token := tests.GetLoginToken(t, *authController, loginData)
 
 		req, _ := http.NewRequest(http.MethodGet, "/api/v1/users", nil)
 		req.Header.Set("Content-Type", "application/json")
@@ -36,15 +36,24 @@ func SetupUsersTestRouter() (*gin.Engine, *user.Controller) {
 func SetupUsersRoutes(r *gin.Engine, userController *user.Controller) {
 	r.PUT("/api/v1/users/:user_id/roles/:role_id",
 		middleware.Authorize(userController.Db.Postgresql, models.RoleIdentity.SuperAdmin),
+		middleware.RequireSession(),
 		userController.AssignRoleToUser)
 	r.GET("/api/v1/users", middleware.Authorize(userController.Db.Postgresql, models.RoleIdentity.SuperAdmin),
+		middleware.RequireSession(),
 		userController.GetAllUsers)
 	r.GET("/api/v1/users/:user_id",
 		middleware.Authorize(userController.Db.Postgresql, models.RoleIdentity.SuperAdmin, models.RoleIdentity.User),
 		userController.GetAUser)
 	r.DELETE("/api/v1/users/:user_id",
 		middleware.Authorize(userController.Db.Postgresql, models.RoleIdentity.SuperAdmin, models.RoleIdentity.User),
+		middleware.RequireSession(),
+		middleware.BlockImpersonation(),
 		userController.DeleteAUser)
+	r.DELETE("/api/v1/users/:user_id/deletion",
+		middleware.Authorize(userController.Db.Postgresql, models.RoleIdentity.SuperAdmin, models.RoleIdentity.User),
+		middleware.RequireSession(),
+		middleware.BlockImpersonation(),
+		userController.CancelAccountDeletion)
 	r.PUT("/api/v1/users/:user_id",
 		middleware.Authorize(userController.Db.Postgresql, models.RoleIdentity.SuperAdmin, models.RoleIdentity.User),
 		userController.UpdateAUser)
@@ -62,5 +71,6 @@ func SetupUsersRoutes(r *gin.Engine, userController *user.Controller) {
 		userController.GetUserDataPrivacySettings)
 	r.PUT("/api/v1/users/:user_id/data-privacy-settings",
 		middleware.Authorize(userController.Db.Postgresql, models.RoleIdentity.SuperAdmin, models.RoleIdentity.User),
+		middleware.RequireSession(),
 		userController.UpdateUserDataPrivacySettings)
 }
@@ -9,6 +9,7 @@ import (
 	"github.com/gin-gonic/gin"
 	"github.com/hngprojects/hng_boilerplate_golang_web/internal/models"
 	"github.com/hngprojects/hng_boilerplate_golang_web/pkg/controller/auth"
+	authService "github.com/hngprojects/hng_boilerplate_golang_web/services/auth"
 	"github.com/hngprojects/hng_boilerplate_golang_web/tests"
 	"github.com/hngprojects/hng_boilerplate_golang_web/utility"
 	"github.com/stretchr/testify/assert"
@@ -56,7 +57,7 @@ func TestDeleteAUser(t *testing.T) {
 			Email:    adminUser.Email,
 			Password: "password",
 		}
-		token := tests.GetLoginToken(t, router, *authController, loginData)
+		token := tests.GetLoginToken(t, *authController, loginData)
 
 		req, _ := http.NewRequest(http.MethodDelete, fmt.Sprintf("/api/v1/users/%s", regularUser.ID), nil)
 		req.Header.Set("Content-Type", "application/json")
@@ -75,7 +76,7 @@ func TestDeleteAUser(t *testing.T) {
 			Email:    adminUser.Email,
 			Password: "password",
 		}
-		token := tests.GetLoginToken(t, router, *authController, loginData)
+		token := tests.GetLoginToken(t, *authController, loginData)
 
 		req, _ := http.NewRequest(http.MethodDelete, fmt.Sprintf("/api/v1/users/%s", utility.GenerateUUID()), nil)
 		req.Header.Set("Content-Type", "application/json")
@@ -103,4 +104,25 @@ func TestDeleteAUser(t *testing.T) {
 		tests.AssertResponseMessage(t, response["message"].(string), "Token could not be found!")
 	})
 
+	t.Run("Personal Access Token Cannot Delete", func(t *testing.T) {
+		router, _ := setup()
+		tokenUser := tests.CreateTestUser(t, db, models.User{Name: "Token User"})
+
+		data, _, err := authService.CreatePersonalAccessToken(tokenUser.ID, models.CreatePersonalAccessTokenRequestModel{
+			Name:   "ci deploy",
+			Scopes: []string{models.PersonalAccessTokenScopeWrite},
+		}, db)
+		if err != nil {
+			t.Fatalf("creating token failed: %v", err)
+		}
+
+		resp := tests.PerformRequest(router, http.MethodDelete, "/api/v1/users/"+tokenUser.ID, data["token"].(string), nil)
+		assert.Equal(t, http.StatusForbidden, resp.Code)
+
+		var user models.User
+		if err := db.Where("id = ?", tokenUser.ID).First(&user).Error; err != nil {
+			t.Errorf("expected the user to remain: %v", err)
+		}
+	})
+
 }
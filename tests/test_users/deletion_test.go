@@ -0,0 +1,192 @@
+package test_users
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"gorm.io/gorm"
+
+	"github.com/hngprojects/hng_boilerplate_golang_web/internal/models"
+	"github.com/hngprojects/hng_boilerplate_golang_web/pkg/controller/auth"
+	"github.com/hngprojects/hng_boilerplate_golang_web/services/user"
+	"github.com/hngprojects/hng_boilerplate_golang_web/tests"
+	"github.com/hngprojects/hng_boilerplate_golang_web/utility"
+)
+
+func TestAccountDeletion(t *testing.T) {
+	router, userController := SetupUsersTestRouter()
+	db := userController.Db.Postgresql
+	currUUID := utility.GenerateUUID()
+
+	authController := auth.Controller{
+		Db:        userController.Db,
+		Validator: userController.Validator,
+		Logger:    userController.Logger,
+	}
+
+	login := func(email string) string {
+		return tests.GetLoginToken(t, authController, models.LoginRequestModel{Email: email, Password: "password"})
+	}
+
+	t.Run("Schedule And Cancel", func(t *testing.T) {
+		owner := tests.CreateTestUser(t, db, models.User{Name: "schedule"})
+		token := login(owner.Email)
+
+		resp := tests.PerformRequest(router, http.MethodDelete, fmt.Sprintf("/api/v1/users/%s", owner.ID), token, nil)
+		tests.AssertStatusCode(t, resp.Code, http.StatusAccepted)
+
+		var stored models.User
+		db.Where("id = ?", owner.ID).First(&stored)
+		if stored.DeletionAt == nil || !stored.DeletionAt.After(time.Now()) {
+			t.Errorf("expected a deletion date in the future, got %v", stored.DeletionAt)
+		}
+
+		resp = tests.PerformRequest(router, http.MethodDelete, fmt.Sprintf("/api/v1/users/%s", owner.ID), token, nil)
+		tests.AssertStatusCode(t, resp.Code, http.StatusConflict)
+
+		resp = tests.PerformRequest(router, http.MethodDelete, fmt.Sprintf("/api/v1/users/%s/deletion", owner.ID), token, nil)
+		tests.AssertStatusCode(t, resp.Code, http.StatusOK)
+
+		db.Where("id = ?", owner.ID).First(&stored)
+		if stored.DeletionAt != nil {
+			t.Errorf("expected the deletion to be cancelled, got %v", stored.DeletionAt)
+		}
+
+		resp = tests.PerformRequest(router, http.MethodDelete, fmt.Sprintf("/api/v1/users/%s/deletion", owner.ID), token, nil)
+		tests.AssertStatusCode(t, resp.Code, http.StatusBadRequest)
+	})
+
+	t.Run("Owner Of Shared Organisation", func(t *testing.T) {
+		owner := tests.CreateTestUser(t, db, models.User{Name: "sharedowner"})
+		member := tests.CreateTestUser(t, db, models.User{Name: "sharedmember"})
+
+		org := models.Organisation{
+			ID:      utility.GenerateUUID(),
+			Name:    "Shared Org",
+			Email:   fmt.Sprintf("testdeletionorg%v@qa.team", currUUID),
+			OwnerID: owner.ID,
+		}
+		db.Create(&org)
+		owner.AddUserToOrganisation(db, &owner, []interface{}{&org})
+		member.AddUserToOrganisation(db, &member, []interface{}{&org})
+
+		resp := tests.PerformRequest(router, http.MethodDelete, fmt.Sprintf("/api/v1/users/%s", owner.ID), login(owner.Email), nil)
+		tests.AssertStatusCode(t, resp.Code, http.StatusConflict)
+	})
+
+	t.Run("Purge Anonymises User", func(t *testing.T) {
+		owner := tests.CreateTestUser(t, db, models.User{Name: "purge"})
+
+		if err := user.PurgeUser(db, owner.ID); err != nil {
+			t.Fatalf("purge failed: %v", err)
+		}
+
+		var stored models.User
+		db.Unscoped().Where("id = ?", owner.ID).First(&stored)
+		tests.AssertResponseMessage(t, stored.Email, fmt.Sprintf("deleted-%v@deleted.invalid", owner.ID))
+		if !stored.DeletedAt.Valid {
+			t.Errorf("expected the user to be soft deleted")
+		}
+	})
+
+	t.Run("Purge Leaves No Rows Behind", func(t *testing.T) {
+		purged := tests.CreateTestUser(t, db, models.User{Name: "purgerows"})
+		other := tests.CreateTestUser(t, db, models.User{Name: "purgeother"})
+
+		solo := models.Organisation{
+			ID:      utility.GenerateUUID(),
+			Name:    "Solo Org",
+			Email:   fmt.Sprintf("testpurgesolo%v@qa.team", currUUID),
+			OwnerID: purged.ID,
+		}
+		shared := models.Organisation{
+			ID:      utility.GenerateUUID(),
+			Name:    "Other Org",
+			Email:   fmt.Sprintf("testpurgeshared%v@qa.team", currUUID),
+			OwnerID: other.ID,
+		}
+		db.Create(&solo)
+		db.Create(&shared)
+		purged.AddUserToOrganisation(db, &purged, []interface{}{&solo, &shared})
+		other.AddUserToOrganisation(db, &other, []interface{}{&shared})
+
+		soloTeam := models.Team{ID: utility.GenerateUUID(), OrganisationID: solo.ID, Name: "Solo Team"}
+		sharedTeam := models.Team{ID: utility.GenerateUUID(), OrganisationID: shared.ID, Name: "Shared Team"}
+		db.Create(&soloTeam)
+		db.Create(&sharedTeam)
+		db.Create(&models.TeamMember{TeamID: soloTeam.ID, UserID: purged.ID, IsLead: true})
+		db.Create(&models.TeamMember{TeamID: sharedTeam.ID, UserID: purged.ID})
+		db.Create(&models.TeamResource{ID: utility.GenerateUUID(), TeamID: soloTeam.ID, ResourceType: models.TeamResourceTemplate, ResourceID: utility.GenerateUUID(), SharedBy: purged.ID})
+		db.Create(&models.TeamResource{ID: utility.GenerateUUID(), TeamID: sharedTeam.ID, ResourceType: models.TeamResourceTemplate, ResourceID: utility.GenerateUUID(), SharedBy: purged.ID})
+
+		db.Create(&models.OrgDomain{ID: utility.GenerateUUID(), OrganisationID: solo.ID, Domain: "purge.example", VerificationToken: "token"})
+		db.Create(&models.OrgJoinRequest{ID: utility.GenerateUUID(), OrganisationID: solo.ID, UserID: other.ID, Email: other.Email})
+		db.Create(&models.OrgJoinRequest{ID: utility.GenerateUUID(), OrganisationID: shared.ID, UserID: purged.ID, Email: purged.Email})
+		db.Create(&models.OrgJoinRequest{ID: utility.GenerateUUID(), OrganisationID: shared.ID, UserID: utility.GenerateUUID(), ReviewedBy: &purged.ID})
+		db.Create(&models.Subscription{ID: utility.GenerateUUID(), BillingID: utility.GenerateUUID(), OrganisationID: solo.ID})
+		db.Create(&models.OwnershipTransfer{ID: utility.GenerateUUID(), OrganisationID: shared.ID, FromUserID: other.ID, ToUserID: purged.ID, TokenHash: utility.HashToken(utility.GenerateUUID()), ExpiresAt: time.Now().Add(time.Hour)})
+		db.Create(&models.Invitation{ID: utility.GenerateUUID(), UserID: other.ID, OrganisationID: shared.ID, Email: purged.Email, ExpiresAt: time.Now().Add(time.Hour)})
+
+		if err := user.PurgeUser(db, purged.ID); err != nil {
+			t.Fatalf("purge failed: %v", err)
+		}
+
+		remaining := map[string]*gorm.DB{
+			"team members":        db.Model(&models.TeamMember{}).Where("user_id = ? OR team_id = ?", purged.ID, soloTeam.ID),
+			"teams":               db.Model(&models.Team{}).Where("organisation_id = ?", solo.ID),
+			"team resources":      db.Model(&models.TeamResource{}).Where("team_id = ? OR shared_by = ?", soloTeam.ID, purged.ID),
+			"org domains":         db.Model(&models.OrgDomain{}).Where("organisation_id = ?", solo.ID),
+			"join requests":       db.Model(&models.OrgJoinRequest{}).Where("organisation_id = ? OR user_id = ? OR email = ? OR reviewed_by = ?", solo.ID, purged.ID, purged.Email, purged.ID),
+			"subscriptions":       db.Model(&models.Subscription{}).Where("organisation_id = ?", solo.ID),
+			"ownership transfers": db.Model(&models.OwnershipTransfer{}).Where("from_user_id = ? OR to_user_id = ?", purged.ID, purged.ID),
+			"invitations":         db.Model(&models.Invitation{}).Where("email = ? OR organisation_id = ?", purged.Email, solo.ID),
+		}
+		for name, query := range remaining {
+			var count int64
+			if err := query.Count(&count).Error; err != nil {
+				t.Fatalf("counting %v failed: %v", name, err)
+			}
+			if count != 0 {
+				t.Errorf("expected no %v left for the purged user, got %d", name, count)
+			}
+		}
+	})
+
+	t.Run("Purge Keeps Other Organisations' Products", func(t *testing.T) {
+		purged := tests.CreateTestUser(t, db, models.User{Name: "purgemember"})
+		owner := tests.CreateTestUser(t, db, models.User{Name: "purgecatalogowner"})
+
+		org := models.Organisation{
+			ID:      utility.GenerateUUID(),
+			Name:    "Catalog Org",
+			Email:   fmt.Sprintf("testpurgecatalog%v@qa.team", currUUID),
+			OwnerID: owner.ID,
+		}
+		db.Create(&org)
+		owner.AddUserToOrganisation(db, &owner, []interface{}{&org})
+		purged.AddUserToOrganisation(db, &purged, []interface{}{&org})
+
+		catalogProduct := models.Product{ID: utility.GenerateUUID(), Name: "Catalog Product", OwnerID: purged.ID, OrganisationID: &org.ID}
+		personalProduct := models.Product{ID: utility.GenerateUUID(), Name: "Personal Product", OwnerID: purged.ID}
+		db.Create(&catalogProduct)
+		db.Create(&personalProduct)
+
+		if err := user.PurgeUser(db, purged.ID); err != nil {
+			t.Fatalf("purge failed: %v", err)
+		}
+
+		var kept models.Product
+		if err := db.Where("id = ?", catalogProduct.ID).First(&kept).Error; err != nil {
+			t.Fatalf("expected the organisation's product to survive: %v", err)
+		}
+		tests.AssertResponseMessage(t, kept.OwnerID, owner.ID)
+
+		var count int64
+		db.Model(&models.Product{}).Where("id = ?", personalProduct.ID).Count(&count)
+		if count != 0 {
+			t.Errorf("expected the personal product to be deleted")
+		}
+	})
+}
@@ -73,7 +73,7 @@ func TestGetUserOrganisations(t *testing.T) {
 			Email:    regularUser.Email,
 			Password: "password",
 		}
-		token := tests.GetLoginToken(t, router, *authController, loginData)
+		token := tests.GetLoginToken(t, *authController, loginData)
 
 		req, _ := http.NewRequest(http.MethodGet, "/api/v1/organizations", nil)
 		req.Header.Set("Content-Type", "application/json")
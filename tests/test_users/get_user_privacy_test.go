@@ -68,7 +68,7 @@ func TestGetUserDataPrivacy(t *testing.T) {
 			Email:    adminUser.Email,
 			Password: "password",
 		}
-		token := tests.GetLoginToken(t, router, *authController, loginData)
+		token := tests.GetLoginToken(t, *authController, loginData)
 
 		req, _ := http.NewRequest(http.MethodGet, fmt.Sprintf("/api/v1/users/%s/data-privacy-settings", regularUser.ID), nil)
 		req.Header.Set("Content-Type", "application/json")
@@ -89,7 +89,7 @@ func TestGetUserDataPrivacy(t *testing.T) {
 			Email:    regularUser.Email,
 			Password: "password",
 		}
-		token := tests.GetLoginToken(t, router, *authController, loginData)
+		token := tests.GetLoginToken(t, *authController, loginData)
 
 		req, _ := http.NewRequest(http.MethodGet, fmt.Sprintf("/api/v1/users/%s/data-privacy-settings", regularUser.ID), nil)
 		req.Header.Set("Content-Type", "application/json")
@@ -123,7 +123,7 @@ func TestGetUserDataPrivacy(t *testing.T) {
 			Email:    adminUser.Email,
 			Password: "password",
 		}
-		token := tests.GetLoginToken(t, router, *authController, loginData)
+		token := tests.GetLoginToken(t, *authController, loginData)
 
 		req, _ := http.NewRequest(http.MethodGet, fmt.Sprintf("/api/v1/users/%s/data-privacy-settings", utility.GenerateUUID()), nil)
 		req.Header.Set("Content-Type", "application/json")
@@ -64,7 +64,7 @@ func TestGetUserRegion(t *testing.T) {
 			Email:    adminUser.Email,
 			Password: "password",
 		}
-		token := tests.GetLoginToken(t, router, *authController, loginData)
+		token := tests.GetLoginToken(t, *authController, loginData)
 
 		req, _ := http.NewRequest(http.MethodGet, fmt.Sprintf("/api/v1/users/%s/regions", regularUser.ID), nil)
 		req.Header.Set("Content-Type", "application/json")
@@ -86,7 +86,7 @@ func TestGetUserRegion(t *testing.T) {
 			Email:    regularUser.Email,
 			Password: "password",
 		}
-		token := tests.GetLoginToken(t, router, *authController, loginData)
+		token := tests.GetLoginToken(t, *authController, loginData)
 
 		req, _ := http.NewRequest(http.MethodGet, fmt.Sprintf("/api/v1/users/%s/regions", regularUser.ID), nil)
 		req.Header.Set("Content-Type", "application/json")
@@ -120,7 +120,7 @@ func TestGetUserRegion(t *testing.T) {
 			Email:    adminUser.Email,
 			Password: "password",
 		}
-		token := tests.GetLoginToken(t, router, *authController, loginData)
+		token := tests.GetLoginToken(t, *authController, loginData)
 
 		req, _ := http.NewRequest(http.MethodGet, fmt.Sprintf("/api/v1/users/%s/regions", utility.GenerateUUID()), nil)
 		req.Header.Set("Content-Type", "application/json")
@@ -58,7 +58,7 @@ func TestGetUser(t *testing.T) {
 			Email:    adminUser.Email,
 			Password: "password",
 		}
-		token := tests.GetLoginToken(t, router, *authController, loginData)
+		token := tests.GetLoginToken(t, *authController, loginData)
 
 		req, _ := http.NewRequest(http.MethodGet, fmt.Sprintf("/api/v1/users/%s", regularUser.ID), nil)
 		req.Header.Set("Content-Type", "application/json")
@@ -79,7 +79,7 @@ func TestGetUser(t *testing.T) {
 			Email:    adminUser.Email,
 			Password: "password",
 		}
-		token := tests.GetLoginToken(t, router, *authController, loginData)
+		token := tests.GetLoginToken(t, *authController, loginData)
 
 		req, _ := http.NewRequest(http.MethodGet, fmt.Sprintf("/api/v1/users/%s", utility.GenerateUUID()), nil)
 		req.Header.Set("Content-Type", "application/json")
@@ -115,7 +115,7 @@ func TestGetUser(t *testing.T) {
 			Email:    regularUser.Email,
 			Password: "password",
 		}
-		token := tests.GetLoginToken(t, router, *authController, loginData)
+		token := tests.GetLoginToken(t, *authController, loginData)
 
 		req, _ := http.NewRequest(http.MethodGet, fmt.Sprintf("/api/v1/users/%s", adminUser.ID), nil)
 		req.Header.Set("Content-Type", "application/json")
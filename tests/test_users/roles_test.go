@@ -44,7 +44,7 @@ func TestUpdateUserRole(t *testing.T) {
 	}
 
 	auth := auth.Controller{Db: userController.Db, Validator: userController.Validator, Logger: userController.Logger}
-	token := tests.GetLoginToken(t, router, auth, loginData)
+	token := tests.GetLoginToken(t, auth, loginData)
 
 	t.Run("Successful Update", func(t *testing.T) {
 		req, _ := http.NewRequest(http.MethodPut, fmt.Sprintf("/api/v1/users/%s/roles/%d", userData.ID, theRole), nil)
@@ -78,7 +78,7 @@ func TestUpdateUserRegion(t *testing.T) {
 			Email:    adminUser.Email,
 			Password: "password",
 		}
-		token := tests.GetLoginToken(t, router, *authController, loginData)
+		token := tests.GetLoginToken(t, *authController, loginData)
 
 		updateData := models.UserRegionTimezoneLanguage{
 			RegionID:   region.ID,
@@ -105,7 +105,7 @@ func TestUpdateUserRegion(t *testing.T) {
 			Email:    adminUser.Email,
 			Password: "password",
 		}
-		token := tests.GetLoginToken(t, router, *authController, loginData)
+		token := tests.GetLoginToken(t, *authController, loginData)
 
 		updateData := models.UserRegionTimezoneLanguage{
 			RegionID:   utility.GenerateUUID(),
@@ -131,7 +131,7 @@ func TestUpdateUserRegion(t *testing.T) {
 			Email:    adminUser.Email,
 			Password: "password",
 		}
-		token := tests.GetLoginToken(t, router, *authController, loginData)
+		token := tests.GetLoginToken(t, *authController, loginData)
 
 		updateData := models.UserRegionTimezoneLanguage{
 			RegionID:   region.ID,
@@ -158,7 +158,7 @@ func TestUpdateUserRegion(t *testing.T) {
 			Email:    regularUser.Email,
 			Password: "password",
 		}
-		token := tests.GetLoginToken(t, router, *authController, loginData)
+		token := tests.GetLoginToken(t, *authController, loginData)
 
 		updateData := models.UserRegionTimezoneLanguage{
 			RegionID:   region.ID,
@@ -73,7 +73,7 @@ func TestUpdateAUser(t *testing.T) {
 			Email:    adminUser.Email,
 			Password: "password",
 		}
-		token := tests.GetLoginToken(t, router, *authController, loginData)
+		token := tests.GetLoginToken(t, *authController, loginData)
 
 		updateData := models.UpdateUserRequestModel{
 			FirstName:   "UpdatedFirstName",
@@ -102,7 +102,7 @@ func TestUpdateAUser(t *testing.T) {
 			Email:    adminUser.Email,
 			Password: "password",
 		}
-		token := tests.GetLoginToken(t, router, *authController, loginData)
+		token := tests.GetLoginToken(t, *authController, loginData)
 
 		updateData := models.UpdateUserRequestModel{
 			FirstName:   "UpdatedFirstName",
@@ -153,7 +153,7 @@ func TestUpdateAUser(t *testing.T) {
 			Email:    adminUser.Email,
 			Password: "password",
 		}
-		token := tests.GetLoginToken(t, router, *authController, loginData)
+		token := tests.GetLoginToken(t, *authController, loginData)
 
 		updateData := models.UpdateUserRequestModel{
 			FirstName:   "UpdatedFirstName",
@@ -181,7 +181,7 @@ func TestUpdateAUser(t *testing.T) {
 			Email:    regularUser.Email,
 			Password: "password",
 		}
-		token := tests.GetLoginToken(t, router, *authController, loginData)
+		token := tests.GetLoginToken(t, *authController, loginData)
 
 		updateData := models.UpdateUserRequestModel{
 			FirstName:   "UpdatedFirstName",
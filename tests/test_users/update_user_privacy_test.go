@@ -70,7 +70,7 @@ func TestUpdateUserDataPrivacy(t *testing.T) {
 			Email:    adminUser.Email,
 			Password: "password",
 		}
-		token := tests.GetLoginToken(t, router, *authController, loginData)
+		token := tests.GetLoginToken(t, *authController, loginData)
 
 		updateData := models.DataPrivacySettings{
 			ProfileVisibility:     true,
@@ -102,7 +102,7 @@ func TestUpdateUserDataPrivacy(t *testing.T) {
 			Email:    regularUser.Email,
 			Password: "password",
 		}
-		token := tests.GetLoginToken(t, router, *authController, loginData)
+		token := tests.GetLoginToken(t, *authController, loginData)
 
 		updateData := models.DataPrivacySettings{
 			ProfileVisibility:     true,
@@ -159,7 +159,7 @@ func TestUpdateUserDataPrivacy(t *testing.T) {
 			Email:    adminUser.Email,
 			Password: "password",
 		}
-		token := tests.GetLoginToken(t, router, *authController, loginData)
+		token := tests.GetLoginToken(t, *authController, loginData)
 
 		updateData := models.DataPrivacySettings{
 			ProfileVisibility:     true,
@@ -66,7 +66,7 @@ func TestGetAllWaitList(t *testing.T) {
 			Email:    adminUser.Email,
 			Password: "password",
 		}
-		token := tests.GetLoginToken(t, router, *authController, loginData)
+		token := tests.GetLoginToken(t, *authController, loginData)
 
 		req, _ := http.NewRequest(http.MethodGet, "/api/v1/waitlist", nil)
 		req.Header.Set("Content-Type", "application/json")
@@ -102,7 +102,7 @@ func TestGetAllWaitList(t *testing.T) {
 			Email:    regularUser.Email,
 			Password: "password",
 		}
-		token := tests.GetLoginToken(t, router, *authController, loginData)
+		token := tests.GetLoginToken(t, *authController, loginData)
 
 		req, _ := http.NewRequest(http.MethodGet, "/api/v1/waitlist", nil)
 		req.Header.Set("Content-Type", "application/json")
@@ -0,0 +1,107 @@
+package utility
+
+import (
+	"bufio"
+	"crypto/sha1"
+	"encoding/hex"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+)
+
+const breachedPrefixLength = 5
+
+// BreachedPasswords looks up passwords in a local copy of a breached password
+// list keyed by uppercase SHA-1. The list is either one file of HASH:COUNT lines,
+// loaded into memory, or a directory of range files named after the first five
+// hash characters and holding SUFFIX:COUNT lines, read one range at a time.
+type BreachedPasswords struct {
+	path   string
+	dir    bool
+	once   sync.Once
+	err    error
+	hashes map[string]map[string]struct{}
+}
+
+func NewBreachedPasswords(path string) (*BreachedPasswords, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, err
+	}
+	return &BreachedPasswords{path: path, dir: info.IsDir()}, nil
+}
+
+// Contains reports whether the password appears in the list.
+func (b *BreachedPasswords) Contains(password string) (bool, error) {
+	sum := sha1.Sum([]byte(password))
+	hash := strings.ToUpper(hex.EncodeToString(sum[:]))
+	prefix, suffix := hash[:breachedPrefixLength], hash[breachedPrefixLength:]
+
+	if b.dir {
+		return b.rangeContains(prefix, suffix)
+	}
+
+	b.once.Do(b.load)
+	if b.err != nil {
+		return false, b.err
+	}
+
+	_, found := b.hashes[prefix][suffix]
+	return found, nil
+}
+
+func (b *BreachedPasswords) rangeContains(prefix, suffix string) (bool, error) {
+	for _, name := range []string{prefix, prefix + ".txt"} {
+		file, err := os.Open(filepath.Join(b.path, name))
+		if errors.Is(err, os.ErrNotExist) {
+			continue
+		}
+		if err != nil {
+			return false, err
+		}
+		defer file.Close()
+
+		scanner := bufio.NewScanner(file)
+		for scanner.Scan() {
+			if breachedLineHash(scanner.Text()) == suffix {
+				return true, nil
+			}
+		}
+		return false, scanner.Err()
+	}
+
+	return false, nil
+}
+
+func (b *BreachedPasswords) load() {
+	file, err := os.Open(b.path)
+	if err != nil {
+		b.err = err
+		return
+	}
+	defer file.Close()
+
+	b.hashes = map[string]map[string]struct{}{}
+
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		hash := breachedLineHash(scanner.Text())
+		if len(hash) <= breachedPrefixLength {
+			continue
+		}
+
+		prefix := hash[:breachedPrefixLength]
+		if b.hashes[prefix] == nil {
+			b.hashes[prefix] = map[string]struct{}{}
+		}
+		b.hashes[prefix][hash[breachedPrefixLength:]] = struct{}{}
+	}
+	b.err = scanner.Err()
+}
+
+func breachedLineHash(line string) string {
+	hash, _, _ := strings.Cut(strings.TrimSpace(line), ":")
+	return strings.ToUpper(hash)
+}
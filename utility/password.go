@@ -1,6 +1,11 @@
 package utility
 
-import "golang.org/x/crypto/bcrypt"
+import (
+	"crypto/sha256"
+	"encoding/hex"
+
+	"golang.org/x/crypto/bcrypt"
+)
 
 func HashPassword(str string) (string, error) {
 	hashed, err := bcrypt.GenerateFromPassword([]byte(str), bcrypt.DefaultCost)
@@ -10,3 +15,10 @@ func HashPassword(str string) (string, error) {
 func CompareHash(str string, hashed string) bool {
 	return bcrypt.CompareHashAndPassword([]byte(hashed), []byte(str)) == nil
 }
+
+// HashToken returns a sha256 digest of a high-entropy token, so it can be stored
+// and looked up directly. Use HashPassword for user chosen secrets.
+func HashToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
@@ -2,6 +2,7 @@ package utility
 
 import (
 	crand "crypto/rand"
+	"encoding/base64"
 	"io"
 	"math/rand"
 	"regexp"
@@ -52,3 +53,12 @@ func GenerateOTP(max int) (int, error) {
 	}
 	return strconv.Atoi(string(b))
 }
+
+// GenerateSecureToken returns a url-safe random string built from n bytes of crypto/rand.
+func GenerateSecureToken(n int) (string, error) {
+	b := make([]byte, n)
+	if _, err := crand.Read(b); err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
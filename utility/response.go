@@ -1,9 +1,11 @@
 package utility
 
 import (
+	"errors"
 	"fmt"
 	"net/http"
 	"reflect"
+	"strings"
 
 	"github.com/go-playground/locales/en"
 	ut "github.com/go-playground/universal-translator"
@@ -64,7 +66,24 @@ func UnauthorisedResponse(code int, status string, name string, message string)
 	return res
 }
 
+// FieldValidationError is a validation failure found outside of the struct tags,
+// such as a rule that needs the database. ValidationResponse reports it in the
+// same shape as a failed tag.
+type FieldValidationError struct {
+	Field    string
+	Messages []string
+}
+
+func (e *FieldValidationError) Error() string {
+	return strings.Join(e.Messages, ", ")
+}
+
 func ValidationResponse(err error, validate *validator.Validate) validator.ValidationErrorsTranslations {
+	var fieldErr *FieldValidationError
+	if errors.As(err, &fieldErr) {
+		return validator.ValidationErrorsTranslations{fieldErr.Field: strings.Join(fieldErr.Messages, ", ")}
+	}
+
 	errs := err.(validator.ValidationErrors)
 	english := en.New()
 	uni := ut.New(english, english)